@@ -44,6 +44,27 @@ func (gq *GossipQueue) Pop() *types.Gossip {
 	return gossip
 }
 
+// - Remove the gossip from the queue and the exists map, returns false when it is not queued
+func (gq *GossipQueue) Remove(hash string) bool {
+	if !gq.ExistsMap.Exists(hash) {
+		return false
+	}
+	itm := HeapRemove(gq.Queue, func() int {
+		for i, itm := range *gq.Queue {
+			if itm.Data.(*types.Gossip).Transaction.Hash == hash {
+				return i
+			}
+		}
+		return -1
+	})
+	if itm == nil {
+		return false
+	}
+	utils.Debug("GossipQueue.Remove --> ", hash)
+	gq.ExistsMap.Delete(hash)
+	return true
+}
+
 // - Check to see if there is an item in the queue that is older than the LockTime
 func (gq GossipQueue) HasAvailable() bool {
 	timestamp := gq.Queue.Peek()
@@ -0,0 +1,31 @@
+package queue
+
+import (
+	"testing"
+)
+
+func TestGossipQueueRemove(t *testing.T) {
+	gq := NewGossipQueue()
+
+	first := newMockGossip(1, 0)
+	second := newMockGossip(2, 0)
+	third := newMockGossip(3, 0)
+	gq.Push(first)
+	gq.Push(second)
+	gq.Push(third)
+
+	if !gq.Remove(second.Transaction.Hash) {
+		t.Fatal("expected the queued gossip to be removed")
+	}
+	if gq.Exists(second.Transaction.Hash) || gq.Remove(second.Transaction.Hash) {
+		t.Fatal("expected the gossip to be removed once")
+	}
+	if len(gq.Dump()) != 2 {
+		t.Fatalf("expected two gossips left [len=%d]", len(gq.Dump()))
+	}
+	for gq.HasAvailable() {
+		if gossip := gq.Pop(); gossip.Transaction.Hash == second.Transaction.Hash {
+			t.Fatal("expected the removed gossip not to be popped")
+		}
+	}
+}
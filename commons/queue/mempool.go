@@ -0,0 +1,394 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+)
+
+// Reasons a transaction is rejected by, or removed from, the mempool
+const (
+	ReasonDuplicate    = "Duplicate"
+	ReasonPoolFull     = "PoolFull"
+	ReasonAccountLimit = "AccountLimit"
+	ReasonEvicted      = "Evicted"
+	ReasonExpired      = "Expired"
+	ReasonExecuted     = "Executed"
+	ReasonFailed       = "Failed"
+)
+
+// Consensus stages of a mempool record
+const (
+	StageReceived = "Received"
+	StageQueued   = "Queued"
+)
+
+// Errors
+var (
+	ErrDuplicate    = errors.New("transaction is already in the mempool")
+	ErrPoolFull     = errors.New("mempool is full")
+	ErrAccountLimit = errors.New("too many pending transactions for this account")
+)
+
+// MempoolStats
+type MempoolStats struct {
+	Size         int              `json:"size"`
+	Capacity     int              `json:"capacity"`
+	AccountLimit int              `json:"accountLimit"`
+	Undispatched int              `json:"undispatched"`
+	Accounts     map[string]int   `json:"accounts"`
+	Rejected     map[string]int64 `json:"rejected"`
+	Removed      map[string]int64 `json:"removed"`
+}
+
+// MempoolRecord - What is persisted for each transaction
+type MempoolRecord struct {
+	Stage  string        `json:"stage"`
+	Due    int64         `json:"due,omitempty"` // Milliseconds, when a queued transaction executes
+	Gossip *types.Gossip `json:"gossip"`
+}
+
+// mempoolEntry
+type mempoolEntry struct {
+	MempoolRecord
+	dispatched bool
+}
+
+// before - Highest Fee first, then highest Hertz, then oldest first
+func (this *mempoolEntry) before(other *mempoolEntry) bool {
+	if this.Gossip.Transaction.Fee != other.Gossip.Transaction.Fee {
+		return this.Gossip.Transaction.Fee > other.Gossip.Transaction.Fee
+	}
+	if this.Gossip.Transaction.Hertz != other.Gossip.Transaction.Hertz {
+		return this.Gossip.Transaction.Hertz > other.Gossip.Transaction.Hertz
+	}
+	if this.Gossip.Transaction.Time != other.Gossip.Transaction.Time {
+		return this.Gossip.Transaction.Time < other.Gossip.Transaction.Time
+	}
+	return this.Gossip.Transaction.Hash < other.Gossip.Transaction.Hash
+}
+
+// copyGossip - The mempool persists its own rumors, the delegate keeps merging peers' rumors into the gossip it
+// hands in and out
+func copyGossip(gossip *types.Gossip) *types.Gossip {
+	copied := *gossip
+	copied.Rumors = append([]types.Rumor(nil), gossip.Rumors...)
+	return &copied
+}
+
+// Mempool - Every transaction a delegate accepted and has not executed yet, bounded overall and per account, ordered
+// by Fee and Hertz and persisted with its consensus stage so a restarted delegate can replay where consensus stood
+type Mempool struct {
+	mutex        sync.Mutex
+	db           *services.DbService
+	capacity     int
+	accountLimit int
+	entries      map[string]*mempoolEntry
+	accounts     map[string]int
+	rejected     map[string]int64
+	removed      map[string]int64
+}
+
+// NewMempool
+func NewMempool(db *services.DbService, capacity int, accountLimit int) *Mempool {
+	return &Mempool{
+		db:           db,
+		capacity:     capacity,
+		accountLimit: accountLimit,
+		entries:      make(map[string]*mempoolEntry),
+		accounts:     make(map[string]int),
+		rejected:     make(map[string]int64),
+		removed:      make(map[string]int64),
+	}
+}
+
+// Add - Admits the gossip, evicting a lower priority transaction when the pool is full, returns the hash of the
+// evicted transaction (empty when none)
+func (this *Mempool) Add(gossip *types.Gossip) (string, error) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	hash := gossip.Transaction.Hash
+	if _, ok := this.entries[hash]; ok {
+		this.rejected[ReasonDuplicate]++
+		return "", ErrDuplicate
+	}
+	if this.accountLimit > 0 && this.accounts[gossip.Transaction.From] >= this.accountLimit {
+		this.rejected[ReasonAccountLimit]++
+		return "", ErrAccountLimit
+	}
+	evicted := ""
+	entry := &mempoolEntry{MempoolRecord: MempoolRecord{Stage: StageReceived, Gossip: copyGossip(gossip)}}
+	if this.capacity > 0 && len(this.entries) >= this.capacity {
+		lowest := this.lowest()
+		if lowest == nil || !entry.before(lowest) {
+			this.rejected[ReasonPoolFull]++
+			return "", ErrPoolFull
+		}
+		utils.Info(fmt.Sprintf("evicting transaction from mempool [hash=%s, hertz=%d]", lowest.Gossip.Transaction.Hash, lowest.Gossip.Transaction.Hertz))
+		evicted = lowest.Gossip.Transaction.Hash
+		this.remove(evicted, ReasonEvicted)
+	}
+
+	err := this.persist(&entry.MempoolRecord)
+	if err != nil {
+		return evicted, err
+	}
+	this.entries[hash] = entry
+	this.accounts[gossip.Transaction.From]++
+	return evicted, nil
+}
+
+// Update - Records the rumors collected so far
+func (this *Mempool) Update(gossip *types.Gossip) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	entry, ok := this.entries[gossip.Transaction.Hash]
+	if !ok {
+		return nil
+	}
+	entry.Gossip = copyGossip(gossip)
+	return this.persist(&entry.MempoolRecord)
+}
+
+// SetQueued - Records that the transaction reached consensus and executes at due (milliseconds)
+func (this *Mempool) SetQueued(hash string, due int64) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	entry, ok := this.entries[hash]
+	if !ok {
+		return nil
+	}
+	entry.Stage = StageQueued
+	entry.Due = due
+	return this.persist(&entry.MempoolRecord)
+}
+
+// Records - Every transaction in the mempool, highest priority first
+func (this *Mempool) Records() []MempoolRecord {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	entries := make([]*mempoolEntry, 0, len(this.entries))
+	for _, entry := range this.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].before(entries[j]) })
+	records := make([]MempoolRecord, 0, len(entries))
+	for _, entry := range entries {
+		record := entry.MempoolRecord
+		record.Gossip = copyGossip(entry.Gossip)
+		records = append(records, record)
+	}
+	return records
+}
+
+// Remove
+func (this *Mempool) Remove(hash string, reason string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.remove(hash, reason)
+}
+
+// Exists
+func (this *Mempool) Exists(hash string) bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	_, ok := this.entries[hash]
+	return ok
+}
+
+// Len
+func (this *Mempool) Len() int {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return len(this.entries)
+}
+
+// SetDispatched - Whether the gossip made it to the gossip worker, gossip that did not is handed out again by Undispatched
+func (this *Mempool) SetDispatched(hash string, dispatched bool) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if entry, ok := this.entries[hash]; ok {
+		entry.dispatched = dispatched
+	}
+}
+
+// Undispatched - Gossip not handed to the gossip worker yet, highest priority first
+func (this *Mempool) Undispatched() []*types.Gossip {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	entries := make([]*mempoolEntry, 0)
+	for _, entry := range this.entries {
+		if !entry.dispatched {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].before(entries[j]) })
+	gossips := make([]*types.Gossip, 0, len(entries))
+	for _, entry := range entries {
+		gossips = append(gossips, copyGossip(entry.Gossip))
+	}
+	return gossips
+}
+
+// Expire - Removes transactions older than the time (milliseconds), returns their hashes
+func (this *Mempool) Expire(before int64) []string {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	hashes := make([]string, 0)
+	for hash, entry := range this.entries {
+		if entry.Gossip.Transaction.Time < before {
+			hashes = append(hashes, hash)
+		}
+	}
+	for _, hash := range hashes {
+		this.remove(hash, ReasonExpired)
+	}
+	return hashes
+}
+
+// Load - Reads the persisted mempool, every loaded gossip still has to be dispatched
+func (this *Mempool) Load() error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.db.GetDb().View(func(txn *badger.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+		prefix := []byte("mempool-")
+		for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+			value, err := iterator.Item().Value()
+			if err != nil {
+				return err
+			}
+			record := MempoolRecord{}
+			err = json.Unmarshal(value, &record)
+			if err != nil || record.Gossip == nil {
+				utils.Error("invalid mempool record", err)
+				continue
+			}
+			if _, ok := this.entries[record.Gossip.Transaction.Hash]; ok {
+				continue
+			}
+			this.entries[record.Gossip.Transaction.Hash] = &mempoolEntry{MempoolRecord: record}
+			this.accounts[record.Gossip.Transaction.From]++
+		}
+		return nil
+	})
+}
+
+// Stats
+func (this *Mempool) Stats() *MempoolStats {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	stats := &MempoolStats{
+		Size:         len(this.entries),
+		Capacity:     this.capacity,
+		AccountLimit: this.accountLimit,
+		Accounts:     make(map[string]int),
+		Rejected:     make(map[string]int64),
+		Removed:      make(map[string]int64),
+	}
+	for _, entry := range this.entries {
+		if !entry.dispatched {
+			stats.Undispatched++
+		}
+	}
+	for address, count := range this.accounts {
+		stats.Accounts[address] = count
+	}
+	for reason, count := range this.rejected {
+		stats.Rejected[reason] = count
+	}
+	for reason, count := range this.removed {
+		stats.Removed[reason] = count
+	}
+	return stats
+}
+
+// String
+func (this MempoolStats) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal mempool stats", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// remove
+func (this *Mempool) remove(hash string, reason string) {
+	entry, ok := this.entries[hash]
+	if !ok {
+		return
+	}
+	delete(this.entries, hash)
+	this.accounts[entry.Gossip.Transaction.From]--
+	if this.accounts[entry.Gossip.Transaction.From] <= 0 {
+		delete(this.accounts, entry.Gossip.Transaction.From)
+	}
+	this.removed[reason]++
+
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+	err := txn.Delete([]byte(mempoolKey(hash)))
+	if err == nil {
+		err = txn.Commit(nil)
+	}
+	if err != nil {
+		utils.Error(err)
+	}
+}
+
+// lowest
+func (this *Mempool) lowest() *mempoolEntry {
+	var lowest *mempoolEntry
+	for _, entry := range this.entries {
+		if lowest == nil || lowest.before(entry) {
+			lowest = entry
+		}
+	}
+	return lowest
+}
+
+// persist
+func (this *Mempool) persist(record *MempoolRecord) error {
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+	err = txn.Set([]byte(mempoolKey(record.Gossip.Transaction.Hash)), bytes)
+	if err != nil {
+		return err
+	}
+	return txn.Commit(nil)
+}
+
+// mempoolKey - Not a "table-" key, a delegate's mempool is not copied when a peer synchronizes its DB
+func mempoolKey(hash string) string {
+	return fmt.Sprintf("mempool-%s", hash)
+}
@@ -0,0 +1,171 @@
+package queue
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+func newTestDb(t *testing.T) (*services.DbService, func()) {
+	dir, err := ioutil.TempDir("", "mempool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := services.NewDbService(dir)
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func newMockGossip(value int64, hertz int64) *types.Gossip {
+	transaction := GetMockTransaction(value)
+	transaction.Hertz = hertz
+	return types.NewGossip(*transaction)
+}
+
+func TestMempoolEvictsLowestHertz(t *testing.T) {
+	db, closeDb := newTestDb(t)
+	defer closeDb()
+	mempool := NewMempool(db, 2, 0)
+
+	low := newMockGossip(1, 1)
+	high := newMockGossip(2, 5)
+	if _, err := mempool.Add(low); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mempool.Add(high); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mempool.Add(newMockGossip(3, 0)); err != ErrPoolFull {
+		t.Fatalf("expected %v [err=%v]", ErrPoolFull, err)
+	}
+	evicted, err := mempool.Add(newMockGossip(4, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evicted != low.Transaction.Hash {
+		t.Fatalf("expected lowest hertz transaction to be returned as evicted [evicted=%s]", evicted)
+	}
+	if mempool.Exists(low.Transaction.Hash) {
+		t.Fatal("expected lowest hertz transaction to be evicted")
+	}
+
+	undispatched := mempool.Undispatched()
+	if len(undispatched) != 2 || undispatched[0].Transaction.Hash != high.Transaction.Hash {
+		t.Fatal("expected highest hertz transaction first")
+	}
+	stats := mempool.Stats()
+	if stats.Rejected[ReasonPoolFull] != 1 || stats.Removed[ReasonEvicted] != 1 || stats.Undispatched != 2 {
+		t.Fatalf("unexpected stats %s", stats.String())
+	}
+}
+
+func TestMempoolEvictsLowestFee(t *testing.T) {
+	db, closeDb := newTestDb(t)
+	defer closeDb()
+	mempool := NewMempool(db, 2, 0)
+
+	// The fee outranks the Hertz.
+	paid := newMockGossip(1, 1)
+	paid.Transaction.Fee = 10
+	unpaid := newMockGossip(2, 5)
+	if _, err := mempool.Add(paid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mempool.Add(unpaid); err != nil {
+		t.Fatal(err)
+	}
+	cheaper := newMockGossip(3, 0)
+	cheaper.Transaction.Fee = 5
+	evicted, err := mempool.Add(cheaper)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evicted != unpaid.Transaction.Hash {
+		t.Fatalf("expected the transaction without a fee to be evicted [evicted=%s]", evicted)
+	}
+
+	undispatched := mempool.Undispatched()
+	if len(undispatched) != 2 || undispatched[0].Transaction.Hash != paid.Transaction.Hash || undispatched[1].Transaction.Hash != cheaper.Transaction.Hash {
+		t.Fatal("expected highest fee transaction first")
+	}
+}
+
+func TestMempoolAccountLimit(t *testing.T) {
+	db, closeDb := newTestDb(t)
+	defer closeDb()
+	mempool := NewMempool(db, 10, 2)
+
+	first := newMockGossip(1, 0)
+	if _, err := mempool.Add(first); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mempool.Add(first); err != ErrDuplicate {
+		t.Fatalf("expected %v [err=%v]", ErrDuplicate, err)
+	}
+	if _, err := mempool.Add(newMockGossip(2, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mempool.Add(newMockGossip(3, 0)); err != ErrAccountLimit {
+		t.Fatalf("expected %v [err=%v]", ErrAccountLimit, err)
+	}
+
+	mempool.Remove(first.Transaction.Hash, ReasonExecuted)
+	if _, err := mempool.Add(newMockGossip(3, 0)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMempoolLoad(t *testing.T) {
+	db, closeDb := newTestDb(t)
+	defer closeDb()
+	mempool := NewMempool(db, 10, 0)
+
+	kept := newMockGossip(1, 0)
+	executed := newMockGossip(2, 0)
+	mempool.Add(kept)
+	mempool.Add(executed)
+	mempool.SetDispatched(kept.Transaction.Hash, true)
+	mempool.Remove(executed.Transaction.Hash, ReasonExecuted)
+
+	reloaded := NewMempool(db, 10, 0)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	undispatched := reloaded.Undispatched()
+	if len(undispatched) != 1 || undispatched[0].Transaction.Hash != kept.Transaction.Hash {
+		t.Fatalf("expected only the pending transaction after reload [len=%d]", len(undispatched))
+	}
+}
+
+func TestMempoolRecordsConsensusStage(t *testing.T) {
+	db, closeDb := newTestDb(t)
+	defer closeDb()
+	mempool := NewMempool(db, 10, 0)
+
+	gossip := newMockGossip(1, 0)
+	mempool.Add(gossip)
+	gossip.Rumors = append(gossip.Rumors, types.Rumor{Address: "a", TransactionHash: gossip.Transaction.Hash})
+	if err := mempool.Update(gossip); err != nil {
+		t.Fatal(err)
+	}
+	if err := mempool.SetQueued(gossip.Transaction.Hash, 42); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := NewMempool(db, 10, 0)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	records := reloaded.Records()
+	if len(records) != 1 {
+		t.Fatalf("expected one record [len=%d]", len(records))
+	}
+	if records[0].Stage != StageQueued || records[0].Due != 42 || len(records[0].Gossip.Rumors) != 1 {
+		t.Fatalf("unexpected record [stage=%s, due=%d, rumors=%d]", records[0].Stage, records[0].Due, len(records[0].Gossip.Rumors))
+	}
+}
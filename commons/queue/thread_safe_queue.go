@@ -16,12 +16,21 @@ type heapPushChanMsg struct {
 	x interface{}
 }
 
+// heapRemoveChanMsg - the message structure for a remove chan
+type heapRemoveChanMsg struct {
+	h      heap.Interface
+	index  func() int
+	result chan interface{}
+}
+
 var (
 	quitChan chan bool
 	// heapPushChan - push channel for pushing to a heap
 	heapPushChan = make(chan heapPushChanMsg)
 	// heapPopChan - pop channel for popping from a heap
 	heapPopChan = make(chan heapPopChanMsg)
+	// heapRemoveChan - remove channel for removing from a heap
+	heapRemoveChan = make(chan heapRemoveChanMsg)
 )
 
 // HeapPush - safely push item to a heap interface
@@ -43,6 +52,18 @@ func HeapPop(h heap.Interface) interface{} {
 	return <-result
 }
 
+// HeapRemove - safely remove the item at the index from a heap interface, the index is looked up once the other
+// operations are done (nil when it is below zero)
+func HeapRemove(h heap.Interface, index func() int) interface{} {
+	var result = make(chan interface{})
+	heapRemoveChan <- heapRemoveChanMsg{
+		h:      h,
+		index:  index,
+		result: result,
+	}
+	return <-result
+}
+
 //stopWatchHeapOps - stop watching for heap operations
 func StopWatchHeapOps() {
 	quitChan <- true
@@ -62,6 +83,13 @@ func WatchHeapOps() chan bool {
 				popMsg.result <- heap.Pop(popMsg.h)
 			case pushMsg := <-heapPushChan:
 				heap.Push(pushMsg.h, pushMsg.x)
+			case removeMsg := <-heapRemoveChan:
+				index := removeMsg.index()
+				if index < 0 {
+					removeMsg.result <- nil
+					continue
+				}
+				removeMsg.result <- heap.Remove(removeMsg.h, index)
 			}
 		}
 	}()
@@ -34,15 +34,22 @@ var dbServiceOnce sync.Once
 // GetDbService
 func GetDbService() *DbService {
 	dbServiceOnce.Do(func() {
-		dbServiceInstance = &DbService{running: false, kmutex: utils.NewKmutex(), cache: cache.New(types.CacheTTL, types.CacheTTL*2)}
-		dbServiceInstance.openDb()
+		dbServiceInstance = NewDbService("." + string(os.PathSeparator) + "db")
 	})
 	return dbServiceInstance
 }
 
+// NewDbService - Opens a DB in the directory with its own cache (eg; one per simulated node)
+func NewDbService(dir string) *DbService {
+	dbService := &DbService{running: false, dir: dir, kmutex: utils.NewKmutex(), cache: cache.New(types.CacheTTL, types.CacheTTL*2)}
+	dbService.openDb()
+	return dbService
+}
+
 // DbService
 type DbService struct {
 	running bool
+	dir     string
 	db      *badger.DB
 	kmutex  *utils.Kmutex
 	cache   *cache.Cache
@@ -66,7 +73,7 @@ func (this *DbService) Go() {
 
 // openDb
 func (this *DbService) openDb() {
-	fileName := "." + string(os.PathSeparator) + "db" + string(os.PathSeparator) + "LOCK"
+	fileName := this.dir + string(os.PathSeparator) + "LOCK"
 	if utils.Exists(fileName) {
 		err := os.Remove(fileName)
 		if err != nil {
@@ -76,8 +83,8 @@ func (this *DbService) openDb() {
 
 	utils.Info("opening DB...")
 	opts := badger.DefaultOptions
-	opts.Dir = "." + string(os.PathSeparator) + "db"
-	opts.ValueDir = "." + string(os.PathSeparator) + "db"
+	opts.Dir = this.dir
+	opts.ValueDir = this.dir
 	opts.ValueLogLoadingMode = badgerOptions.FileIO // https://github.com/dgraph-io/badger/issues/246
 	db, err := badger.Open(opts)
 	if err != nil {
@@ -86,27 +93,52 @@ func (this *DbService) openDb() {
 	this.db = db
 }
 
+// GetCache
+func (this *DbService) GetCache() *cache.Cache {
+	return this.cache
+}
+
+// GetDb
+func (this *DbService) GetDb() *badger.DB {
+	return this.db
+}
+
+// NewTxn
+func (this *DbService) NewTxn(update bool) *badger.Txn {
+	return this.db.NewTransaction(update)
+}
+
+// Lock
+func (this *DbService) Lock(key interface{}) {
+	this.kmutex.Lock(key)
+}
+
+// Unlock
+func (this *DbService) Unlock(key interface{}) {
+	this.kmutex.Unlock(key)
+}
+
 // GetCache
 func GetCache() *cache.Cache {
-	return GetDbService().cache
+	return GetDbService().GetCache()
 }
 
 // GetDb
 func GetDb() *badger.DB {
-	return GetDbService().db
+	return GetDbService().GetDb()
 }
 
 // NewTxn
 func NewTxn(update bool) *badger.Txn {
-	return GetDbService().db.NewTransaction(update)
+	return GetDbService().NewTxn(update)
 }
 
 // Lock
 func Lock(key interface{}) {
-	GetDbService().kmutex.Lock(key)
+	GetDbService().Lock(key)
 }
 
 // Unlock
 func Unlock(key interface{}) {
-	GetDbService().kmutex.Unlock(key)
+	GetDbService().Unlock(key)
 }
@@ -0,0 +1,237 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package transport contains an in-process network used in place of GRPC so that a whole
+// delegate network can run inside one process (eg; a single `go test`).
+package transport
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Errors
+var (
+	ErrUnknownPeer = errors.New("unknown peer")
+	ErrPartitioned = errors.New("peer is unreachable (partitioned)")
+	ErrDropped     = errors.New("message dropped")
+)
+
+// Network - Delivers calls between servers registered by address. Latency, drops and partitions
+// can be injected at any time.
+type Network struct {
+	mutex      sync.RWMutex
+	servers    map[string]map[string]interface{}
+	latency    time.Duration
+	jitter     time.Duration
+	dropRate   float64
+	partitions map[string]int
+	blocked    map[string]bool
+	random     *rand.Rand
+	messages   int64
+	inTransit  int64
+	serving    map[string]int
+	served     *sync.Cond
+	clock      utils.Clock
+}
+
+// NewNetwork
+func NewNetwork() *Network {
+	return NewNetworkWithClock(utils.NewSystemClock())
+}
+
+// NewNetworkWithClock - Network whose latency passes on the clock (eg; a virtual clock, so latency takes no real time)
+func NewNetworkWithClock(clock utils.Clock) *Network {
+	this := &Network{
+		clock:      clock,
+		servers:    make(map[string]map[string]interface{}),
+		partitions: make(map[string]int),
+		blocked:    make(map[string]bool),
+		serving:    make(map[string]int),
+		random:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	this.served = sync.NewCond(&this.mutex)
+	return this
+}
+
+// Register - Registers the server for a service (eg; "dapos") at the address
+func (this *Network) Register(service, address string, server interface{}) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if this.servers[service] == nil {
+		this.servers[service] = make(map[string]interface{})
+	}
+	this.servers[service][address] = server
+}
+
+// Unregister
+func (this *Network) Unregister(service, address string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if this.servers[service] != nil {
+		delete(this.servers[service], address)
+	}
+}
+
+// SetLatency - One way latency applied to every message, plus a random amount up to jitter
+func (this *Network) SetLatency(latency, jitter time.Duration) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.latency = latency
+	this.jitter = jitter
+}
+
+// SetDropRate - Probability (0 to 1) that any single message is lost
+func (this *Network) SetDropRate(dropRate float64) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.dropRate = dropRate
+}
+
+// Partition - Splits the network into the given groups. Addresses not listed share one group.
+func (this *Network) Partition(groups ...[]string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, address := range group {
+			this.partitions[address] = i + 1
+		}
+	}
+}
+
+// Block - Drops every message from one address to another (one direction only)
+func (this *Network) Block(from, to string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.blocked[linkKey(from, to)] = true
+}
+
+// Heal - Removes all partitions and blocked links
+func (this *Network) Heal() {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.partitions = make(map[string]int)
+	this.blocked = make(map[string]bool)
+}
+
+// Lookup - Returns the server registered for the service at the to address once the request
+// made it across the network
+func (this *Network) Lookup(ctx context.Context, service, from, to string) (interface{}, error) {
+	this.mutex.RLock()
+	server, ok := this.servers[service][to]
+	this.mutex.RUnlock()
+	if !ok {
+		return nil, ErrUnknownPeer
+	}
+	err := this.Transit(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// Call - Delivers the request to the server registered for the service at the to address, invokes it and carries the
+// response back. A server unregistered while the request was in transit is unknown.
+func (this *Network) Call(ctx context.Context, service, from, to string, invoke func(server interface{}) error) error {
+	err := this.Transit(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	this.mutex.Lock()
+	server, ok := this.servers[service][to]
+	if ok {
+		this.serving[to]++
+	}
+	this.mutex.Unlock()
+	if !ok {
+		return ErrUnknownPeer
+	}
+	err = invoke(server)
+	this.mutex.Lock()
+	this.serving[to]--
+	this.served.Broadcast()
+	this.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	return this.Transit(ctx, to, from)
+}
+
+// Drain - Waits for the calls the address is serving, unregister it first so no new call reaches it
+func (this *Network) Drain(address string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	for this.serving[address] > 0 {
+		this.served.Wait()
+	}
+}
+
+// Transit - Moves one message from one address to another, applying partitions, drops and latency
+func (this *Network) Transit(ctx context.Context, from, to string) error {
+	this.mutex.Lock()
+	if this.partitions[from] != this.partitions[to] || this.blocked[linkKey(from, to)] {
+		this.mutex.Unlock()
+		return ErrPartitioned
+	}
+	this.messages++
+	dropped := this.dropRate > 0 && this.random.Float64() < this.dropRate
+	delay := this.latency
+	if this.jitter > 0 {
+		delay += time.Duration(this.random.Int63n(int64(this.jitter)))
+	}
+	this.mutex.Unlock()
+
+	if dropped {
+		return ErrDropped
+	}
+	if delay <= 0 {
+		return nil
+	}
+	atomic.AddInt64(&this.inTransit, 1)
+	defer atomic.AddInt64(&this.inTransit, -1)
+	select {
+	case <-this.clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Messages - Number of messages sent over the network so far, including the ones lost
+func (this *Network) Messages() int64 {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return this.messages
+}
+
+// InTransit - Number of messages waiting for their latency to pass
+func (this *Network) InTransit() int64 {
+	return atomic.LoadInt64(&this.inTransit)
+}
+
+// linkKey
+func linkKey(from, to string) string {
+	return fmt.Sprintf("%s->%s", from, to)
+}
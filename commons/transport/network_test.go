@@ -0,0 +1,152 @@
+package transport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"golang.org/x/net/context"
+)
+
+func TestNetworkLookup(t *testing.T) {
+	network := NewNetwork()
+	network.Register("dapos", "a", "server-a")
+	server, err := network.Lookup(context.Background(), "dapos", "b", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != "server-a" {
+		t.Fatalf("wrong server [server=%v]", server)
+	}
+	_, err = network.Lookup(context.Background(), "disgover", "b", "a")
+	if err != ErrUnknownPeer {
+		t.Fatalf("expected ErrUnknownPeer [err=%v]", err)
+	}
+	network.Unregister("dapos", "a")
+	_, err = network.Lookup(context.Background(), "dapos", "b", "a")
+	if err != ErrUnknownPeer {
+		t.Fatalf("expected ErrUnknownPeer [err=%v]", err)
+	}
+}
+
+func TestNetworkPartition(t *testing.T) {
+	network := NewNetwork()
+	network.Partition([]string{"a", "b"}, []string{"c"})
+	if err := network.Transit(context.Background(), "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := network.Transit(context.Background(), "a", "c"); err != ErrPartitioned {
+		t.Fatalf("expected ErrPartitioned [err=%v]", err)
+	}
+	if err := network.Transit(context.Background(), "c", "d"); err != ErrPartitioned {
+		t.Fatalf("expected ErrPartitioned [err=%v]", err)
+	}
+	network.Block("a", "b")
+	if err := network.Transit(context.Background(), "a", "b"); err != ErrPartitioned {
+		t.Fatalf("expected ErrPartitioned [err=%v]", err)
+	}
+	if err := network.Transit(context.Background(), "b", "a"); err != nil {
+		t.Fatal(err)
+	}
+	network.Heal()
+	if err := network.Transit(context.Background(), "a", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := network.Transit(context.Background(), "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNetworkDropRate(t *testing.T) {
+	network := NewNetwork()
+	network.SetDropRate(1)
+	if err := network.Transit(context.Background(), "a", "b"); err != ErrDropped {
+		t.Fatalf("expected ErrDropped [err=%v]", err)
+	}
+	network.SetDropRate(0)
+	if err := network.Transit(context.Background(), "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNetworkLatency(t *testing.T) {
+	network := NewNetwork()
+	network.SetLatency(50*time.Millisecond, 0)
+	start := time.Now()
+	if err := network.Transit(context.Background(), "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) < 50*time.Millisecond {
+		t.Fatal("latency was not applied")
+	}
+
+	// Latency longer than the caller's deadline.
+	network.SetLatency(time.Second, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := network.Transit(ctx, "a", "b"); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded [err=%v]", err)
+	}
+}
+
+func TestNetworkLatencyOnClock(t *testing.T) {
+	clock := utils.NewVirtualClock(time.Unix(0, 0))
+	network := NewNetworkWithClock(clock)
+	network.SetLatency(time.Hour, 0)
+	done := make(chan error, 1)
+	go func() { done <- network.Transit(context.Background(), "a", "b") }()
+	for clock.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if network.InTransit() != 1 {
+		t.Fatalf("expected one message in transit [inTransit=%d]", network.InTransit())
+	}
+	select {
+	case <-done:
+		t.Fatal("message arrived before its latency passed")
+	default:
+	}
+	clock.Advance(time.Hour)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if network.InTransit() != 0 {
+		t.Fatalf("expected no message in transit [inTransit=%d]", network.InTransit())
+	}
+}
+
+func TestNetworkDrain(t *testing.T) {
+	network := NewNetwork()
+	network.Register("dapos", "a", "server-a")
+	serving := make(chan bool)
+	answer := make(chan bool)
+	done := make(chan error, 1)
+	go func() {
+		done <- network.Call(context.Background(), "dapos", "b", "a", func(server interface{}) error {
+			serving <- true
+			<-answer
+			return nil
+		})
+	}()
+	<-serving
+	network.Unregister("dapos", "a")
+	err := network.Call(context.Background(), "dapos", "b", "a", func(server interface{}) error { return nil })
+	if err != ErrUnknownPeer {
+		t.Fatalf("expected ErrUnknownPeer [err=%v]", err)
+	}
+	drained := make(chan bool)
+	go func() {
+		network.Drain("a")
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		t.Fatal("drained while a call was served")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(answer)
+	<-drained
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -33,10 +34,12 @@ import (
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/crypto"
 	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
 )
 
 var accountInstance *Account
 var accountOnce sync.Once
+var namePattern = regexp.MustCompile("^[a-z][a-z0-9_-]*$")
 
 // Account
 type Account struct {
@@ -44,7 +47,11 @@ type Account struct {
 	PrivateKey      string
 	Name            string
 	Balance         *big.Int
-	TransactionHash string // Smart contract
+	TransactionHash string   // Smart contract
+	Signers         []string // Multisig
+	Threshold       int      // Multisig, signatures required to spend
+	Locks           []Lock   // Part of the balance that cannot be spent yet
+	Stakes          []Stake  // Bonded to delegates, not part of the balance
 	Updated         time.Time
 	Created         time.Time
 
@@ -52,6 +59,8 @@ type Account struct {
 	Nonce    uint64
 	Root     crypto.HashBytes // merkle root of the storage trie
 	CodeHash []byte
+
+	balanceTime int64 // Milliseconds the locked and spendable balances are marshalled at, see ShowBalancesAt
 }
 
 // Key
@@ -59,11 +68,111 @@ func (this Account) Key() string {
 	return fmt.Sprintf("table-account-%s", this.Address)
 }
 
+// IsMultisig
+func (this Account) IsMultisig() bool {
+	return len(this.Signers) > 0
+}
+
+// LockedBalance - Tokens that cannot be spent at the time (milliseconds)
+func (this Account) LockedBalance(time int64) int64 {
+	var locked int64
+	for _, lock := range this.Locks {
+		if lock.Until > time {
+			locked += lock.Value
+		}
+	}
+	return locked
+}
+
+// ShowBalancesAt - Locked and spendable balances are marshalled as of the time (milliseconds) instead of the system time
+func (this *Account) ShowBalancesAt(time int64) {
+	this.balanceTime = time
+}
+
+// SpendableBalance - Tokens that can be spent at the time (milliseconds)
+func (this Account) SpendableBalance(time int64) int64 {
+	return this.Balance.Int64() - this.LockedBalance(time)
+}
+
+// Release - Removes the locks that ended by the time (milliseconds), returns them
+func (this *Account) Release(time int64) []Lock {
+	released := make([]Lock, 0)
+	locks := make([]Lock, 0, len(this.Locks))
+	for _, lock := range this.Locks {
+		if lock.Until <= time {
+			released = append(released, lock)
+			continue
+		}
+		locks = append(locks, lock)
+	}
+	this.Locks = locks
+	return released
+}
+
+// BondedBalance - Tokens staked with delegates
+func (this Account) BondedBalance() int64 {
+	var bonded int64
+	for _, stake := range this.Stakes {
+		bonded += stake.Value
+	}
+	return bonded
+}
+
+// StakeWith - Tokens staked with the delegate
+func (this Account) StakeWith(delegate string) int64 {
+	for _, stake := range this.Stakes {
+		if stake.Delegate == delegate {
+			return stake.Value
+		}
+	}
+	return 0
+}
+
+// Bond - Adds to the stake with the delegate, the caller takes the tokens from the balance
+func (this *Account) Bond(delegate string, value int64) {
+	for i := range this.Stakes {
+		if this.Stakes[i].Delegate == delegate {
+			this.Stakes[i].Value += value
+			return
+		}
+	}
+	this.Stakes = append(this.Stakes, Stake{Delegate: delegate, Value: value})
+}
+
+// Unbond - Takes from the stake with the delegate, the caller gives the tokens back to the balance
+func (this *Account) Unbond(delegate string, value int64) error {
+	for i := range this.Stakes {
+		if this.Stakes[i].Delegate != delegate {
+			continue
+		}
+		if this.Stakes[i].Value < value {
+			break
+		}
+		this.Stakes[i].Value -= value
+		if this.Stakes[i].Value == 0 {
+			this.Stakes = append(this.Stakes[:i], this.Stakes[i+1:]...)
+		}
+		return nil
+	}
+	return errors.Errorf("stake with delegate %s is less than %d", delegate, value)
+}
+
 // NameKey
 func (this Account) NameKey() string {
 	return fmt.Sprintf("key-account-name-%s", strings.ToLower(this.Name))
 }
 
+// ValidateName - Names are lower case so each one has a single spelling, and cannot be mistaken for an address
+func ValidateName(name string) error {
+	if len(name) < MinNameLength || len(name) > MaxNameLength {
+		return errors.Errorf("name must have between %d and %d characters", MinNameLength, MaxNameLength)
+	}
+	if !namePattern.MatchString(name) {
+		return errors.New("name must start with a letter and contain only lower case letters, digits, '-' and '_'")
+	}
+	return nil
+}
+
 //Cache
 func (this *Account) Cache(cache *cache.Cache, time_optional ...time.Duration) {
 	TTL := AccountTTL
@@ -79,6 +188,9 @@ func (this *Account) Persist(txn *badger.Txn) error {
 	if err != nil {
 		return err
 	}
+	if this.Name == "" {
+		return nil
+	}
 	err = txn.Set([]byte(this.NameKey()), []byte(this.Key()))
 	if err != nil {
 		return err
@@ -118,6 +230,36 @@ func (this *Account) UnmarshalJSON(bytes []byte) error {
 	if jsonMap["transactionHash"] != nil {
 		this.TransactionHash = jsonMap["transactionHash"].(string)
 	}
+	if jsonMap["signers"] != nil {
+		this.Signers = make([]string, 0)
+		for _, signer := range jsonMap["signers"].([]interface{}) {
+			this.Signers = append(this.Signers, signer.(string))
+		}
+	}
+	if jsonMap["threshold"] != nil {
+		this.Threshold = int(jsonMap["threshold"].(float64))
+	}
+	if jsonMap["locks"] != nil {
+		this.Locks = make([]Lock, 0)
+		for _, value := range jsonMap["locks"].([]interface{}) {
+			lock := value.(map[string]interface{})
+			this.Locks = append(this.Locks, Lock{
+				TransactionHash: lock["transactionHash"].(string),
+				Value:           int64(lock["value"].(float64)),
+				Until:           int64(lock["until"].(float64)),
+			})
+		}
+	}
+	if jsonMap["stakes"] != nil {
+		this.Stakes = make([]Stake, 0)
+		for _, value := range jsonMap["stakes"].([]interface{}) {
+			stake := value.(map[string]interface{})
+			this.Stakes = append(this.Stakes, Stake{
+				Delegate: stake["delegate"].(string),
+				Value:    int64(stake["value"].(float64)),
+			})
+		}
+	}
 	if jsonMap["updated"] != nil {
 		updated, err := time.Parse(time.RFC3339, jsonMap["updated"].(string))
 		if err != nil {
@@ -147,26 +289,53 @@ func (this *Account) UnmarshalJSON(bytes []byte) error {
 
 // MarshalJSON
 func (this Account) MarshalJSON() ([]byte, error) {
+
+	// Locked and spendable balances are shown for accounts with locks, they change as the locks end.
+	var lockedBalance, spendableBalance *int64
+	if len(this.Locks) > 0 {
+		at := this.balanceTime
+		if at == 0 {
+			at = utils.ToMilliSeconds(time.Now())
+		}
+		locked := this.LockedBalance(at)
+		spendable := this.SpendableBalance(at)
+		lockedBalance = &locked
+		spendableBalance = &spendable
+	}
 	return json.Marshal(struct {
-		Address         string    `json:"address"`
-		PrivateKey      string    `json:"privateKey,omitempty"`
-		Name            string    `json:"name"`
-		Balance         int64     `json:"balance"`
-		TransactionHash string    `json:"transactionHash,omitempty"`
-		Updated         time.Time `json:"updated"`
-		Created         time.Time `json:"created"`
-		Nonce           uint64    `json:"nonce"`
+		Address          string    `json:"address"`
+		PrivateKey       string    `json:"privateKey,omitempty"`
+		Name             string    `json:"name"`
+		Balance          int64     `json:"balance"`
+		TransactionHash  string    `json:"transactionHash,omitempty"`
+		Signers          []string  `json:"signers,omitempty"`
+		Threshold        int       `json:"threshold,omitempty"`
+		Locks            []Lock    `json:"locks,omitempty"`
+		LockedBalance    *int64    `json:"lockedBalance,omitempty"`
+		SpendableBalance *int64    `json:"spendableBalance,omitempty"`
+		Stakes           []Stake   `json:"stakes,omitempty"`
+		BondedBalance    int64     `json:"bondedBalance,omitempty"`
+		Updated          time.Time `json:"updated"`
+		Created          time.Time `json:"created"`
+		Nonce            uint64    `json:"nonce"`
 		// Root       string    `json:"root"`
 		// CodeHash   string    `json:"codehash"`
 	}{
-		Address:         this.Address,
-		PrivateKey:      this.PrivateKey,
-		Name:            this.Name,
-		Balance:         this.Balance.Int64(),
-		TransactionHash: this.TransactionHash,
-		Updated:         this.Updated,
-		Created:         this.Created,
-		Nonce:           this.Nonce,
+		Address:          this.Address,
+		PrivateKey:       this.PrivateKey,
+		Name:             this.Name,
+		Balance:          this.Balance.Int64(),
+		TransactionHash:  this.TransactionHash,
+		Signers:          this.Signers,
+		Threshold:        this.Threshold,
+		Locks:            this.Locks,
+		LockedBalance:    lockedBalance,
+		SpendableBalance: spendableBalance,
+		Stakes:           this.Stakes,
+		BondedBalance:    this.BondedBalance(),
+		Updated:          this.Updated,
+		Created:          this.Created,
+		Nonce:            this.Nonce,
 		// Root:       crypto.Encode(this.Root.Bytes()),
 		// CodeHash:   crypto.Encode(this.CodeHash),
 	})
@@ -236,9 +405,22 @@ func ToAccountByAddress(txn *badger.Txn, address string) (*Account, error) {
 	return account, err
 }
 
+// ToAccountFromKey
+func ToAccountFromKey(txn *badger.Txn, key []byte) (*Account, error) {
+	item, err := txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	return ToAccountFromJson(value)
+}
+
 // ToAccountByName
 func ToAccountByName(txn *badger.Txn, name string) (*Account, error) {
-	item, err := txn.Get([]byte(fmt.Sprintf("key-account-name-%s", name)))
+	item, err := txn.Get([]byte(Account{Name: name}.NameKey()))
 	if err != nil {
 		return nil, err
 	}
@@ -246,10 +428,15 @@ func ToAccountByName(txn *badger.Txn, name string) (*Account, error) {
 	if err != nil {
 		return nil, err
 	}
-	account, err := ToAccountByAddress(txn, string(value))
+	account, err := ToAccountFromKey(txn, value)
 	if err != nil {
 		return nil, err
 	}
+
+	// Released or transferred since?
+	if strings.ToLower(account.Name) != strings.ToLower(name) {
+		return nil, badger.ErrKeyNotFound
+	}
 	return account, err
 }
 
@@ -260,7 +447,7 @@ func ToAccountsByName(name string, txn *badger.Txn) ([]*Account, error) {
 	opts.PrefetchValues = false
 	iterator := txn.NewIterator(opts)
 	defer iterator.Close()
-	prefix := []byte(fmt.Sprintf("key-account-name-%s", name))
+	prefix := []byte(Account{Name: name}.NameKey())
 	var Accounts = make([]*Account, 0)
 	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
 		item := iterator.Item()
@@ -269,7 +456,7 @@ func ToAccountsByName(name string, txn *badger.Txn) ([]*Account, error) {
 			utils.Error(err)
 			continue
 		}
-		Account, err := ToAccountByAddress(txn, string(value))
+		Account, err := ToAccountFromKey(txn, value)
 		if err != nil {
 			utils.Error(err)
 			continue
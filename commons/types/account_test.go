@@ -19,6 +19,7 @@ package types
 import (
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 	"github.com/patrickmn/go-cache"
@@ -97,42 +98,60 @@ func TestToAccountByAddress(t *testing.T) {
 
 //TestToAccountByName
 func TestToAccountByName(t *testing.T) {
-	//TODO: currently not using names
-	t.Skip("names not in use")
-	//defer destruct()
-	//txn := db.NewTransaction(true)
-	//defer txn.Discard()
-	//account := &Account{}
-	//account.UnmarshalJSON(testAccountByte)
-	//account.Persist(txn)
-	//
-	//testAccount,err := ToAccountByName(txn, account.Address)
-	//if err != nil{
-	//	t.Error(err)
-	//}
-	//if reflect.DeepEqual(testAccount, account) == false{
-	//	t.Error("account not equal to testAccount")
-	//}
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	account := &Account{}
+	account.UnmarshalJSON(testAccountByte)
+	account.Persist(txn)
+
+	testAccount, err := ToAccountByName(txn, "Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testAccount, account) == false {
+		t.Error("account not equal to testAccount")
+	}
+
+	// Released.
+	txn.Delete([]byte(account.NameKey()))
+	account.Name = ""
+	account.Persist(txn)
+	if _, err := ToAccountByName(txn, "test"); err != badger.ErrKeyNotFound {
+		t.Errorf("found an account by a released name [err=%v]", err)
+	}
 }
 
 //TestToAccountsByName
 func TestToAccountsByName(t *testing.T) {
-	//TODO: currently not using names
-	t.Skip("names not in use")
-	//defer destruct()
-	//txn := db.NewTransaction(true)
-	//defer txn.Discard()
-	//account := &Account{}
-	//account.UnmarshalJSON(testAccountByte)
-	//account.Persist(txn)
-	//
-	//testAccount,err := ToAccountsByName(txn, account.Address)
-	//if err != nil{
-	//	t.Error(err)
-	//}
-	//if reflect.DeepEqual(testAccount, account) == false{
-	//	t.Error("account not equal to testAccount")
-	//}
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	account := &Account{}
+	account.UnmarshalJSON(testAccountByte)
+	account.Persist(txn)
+
+	testAccounts, err := ToAccountsByName("te", txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(testAccounts) != 1 || reflect.DeepEqual(testAccounts[0], account) == false {
+		t.Errorf("invalid accounts by name: %v", testAccounts)
+	}
+}
+
+//TestValidateName
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"bob", "alice_1", "dispatch-labs"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("name %s is valid: %s", name, err)
+		}
+	}
+	for _, name := range []string{"", "bo", "Bob", "1bob", "bob smith", "bob.eth", "abcdefghijklmnopqrstuvwxyzabcdefg"} {
+		if ValidateName(name) == nil {
+			t.Errorf("name %s is invalid", name)
+		}
+	}
 }
 
 //TestAccountSet
@@ -183,6 +202,126 @@ func TestAccountMarshalJSON(t *testing.T) {
 	}
 }
 
+//TestAccountLocks
+func TestAccountLocks(t *testing.T) {
+	defer destruct()
+	account := &Account{}
+	account.UnmarshalJSON(testAccountByte)
+	account.Locks = []Lock{
+		{TransactionHash: "a", Value: 100, Until: 2000},
+		{TransactionHash: "b", Value: 300, Until: 1000},
+	}
+	if account.LockedBalance(500) != 400 || account.SpendableBalance(500) != 600 {
+		t.Errorf("invalid balances before the locks end [locked=%d, spendable=%d]", account.LockedBalance(500), account.SpendableBalance(500))
+	}
+	if account.LockedBalance(1000) != 100 || account.SpendableBalance(1000) != 900 {
+		t.Errorf("invalid balances once a lock ended [locked=%d, spendable=%d]", account.LockedBalance(1000), account.SpendableBalance(1000))
+	}
+
+	testAccount, err := ToAccountFromJson([]byte(account.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testAccount.Locks, account.Locks) == false {
+		t.Errorf("locks not equal after JSON.\nGot: %v\nExpected: %v", testAccount.Locks, account.Locks)
+	}
+	account.ShowBalancesAt(500)
+	if !strings.Contains(account.String(), `"lockedBalance":400,"spendableBalance":600`) {
+		t.Errorf("balances not shown at the time: %s", account.String())
+	}
+
+	released := account.Release(1000)
+	if len(released) != 1 || released[0].TransactionHash != "b" {
+		t.Errorf("invalid released locks: %v", released)
+	}
+	if len(account.Locks) != 1 || account.Balance.Int64() != 1000 {
+		t.Errorf("release changed more than the ended locks [locks=%v, balance=%d]", account.Locks, account.Balance.Int64())
+	}
+}
+
+//TestAccountStakes
+func TestAccountStakes(t *testing.T) {
+	defer destruct()
+	account := &Account{}
+	account.UnmarshalJSON(testAccountByte)
+	account.Bond("a", 100)
+	account.Bond("b", 50)
+	account.Bond("a", 25)
+	if account.BondedBalance() != 175 || account.StakeWith("a") != 125 {
+		t.Errorf("invalid stakes after bonding [bonded=%d, a=%d]", account.BondedBalance(), account.StakeWith("a"))
+	}
+
+	testAccount, err := ToAccountFromJson([]byte(account.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testAccount.Stakes, account.Stakes) == false {
+		t.Errorf("stakes not equal after JSON.\nGot: %v\nExpected: %v", testAccount.Stakes, account.Stakes)
+	}
+
+	if account.Unbond("b", 60) == nil {
+		t.Error("unbonded more than the stake")
+	}
+	if account.Unbond("c", 1) == nil {
+		t.Error("unbonded from a delegate without stake")
+	}
+	if err := account.Unbond("b", 50); err != nil {
+		t.Fatal(err)
+	}
+	if len(account.Stakes) != 1 || account.StakeWith("b") != 0 || account.Balance.Int64() != 1000 {
+		t.Errorf("invalid stakes after unbonding [stakes=%v, balance=%d]", account.Stakes, account.Balance.Int64())
+	}
+}
+
+//TestToAddressesWithLocksEndedBy
+func TestToAddressesWithLocksEndedBy(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	Lock{TransactionHash: "a", Value: 1, Until: 9000}.Persist(txn, "99022124e110f5a9567a334a2017bdbd41c475e3")
+	Lock{TransactionHash: "b", Value: 1, Until: 12000}.Persist(txn, "99022124e110f5a9567a334a2017bdbd41c475e3")
+	Lock{TransactionHash: "c", Value: 1, Until: 11000}.Persist(txn, "d70613f93152c84050e7826c4e2b0cc02c1c3b99")
+
+	addresses, next, err := ToAddressesWithLocksEndedBy(txn, 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(addresses, []string{"99022124e110f5a9567a334a2017bdbd41c475e3"}) == false || next != 11000 {
+		t.Errorf("invalid locks ended by 10000 [addresses=%v, next=%d]", addresses, next)
+	}
+	addresses, next, err = ToAddressesWithLocksEndedBy(txn, 20000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addresses) != 2 || next != 0 {
+		t.Errorf("invalid locks ended by 20000 [addresses=%v, next=%d]", addresses, next)
+	}
+}
+
+//TestToDelegateStakes
+func TestToDelegateStakes(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	(&DelegateStake{Delegate: "a", Total: 100, Stakers: 1}).Persist(txn)
+	(&DelegateStake{Delegate: "b", Total: 300, Stakers: 2}).Persist(txn)
+
+	delegateStake, err := ToDelegateStake(txn, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delegateStake.Total != 100 || delegateStake.Stakers != 1 {
+		t.Errorf("invalid delegate stake: %v", delegateStake)
+	}
+	delegateStakes, err := ToDelegateStakes(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delegateStakes) != 2 || delegateStakes[0].Delegate != "b" {
+		t.Errorf("delegate stakes not sorted by total: %v", delegateStakes)
+	}
+}
+
 //TestReadAccountFile
 func TestReadAccountFile(t *testing.T) {
 	name := "test.json"
@@ -8,9 +8,9 @@ import (
 	"bytes"
 	"encoding/binary"
 	"github.com/dispatchlabs/disgo/commons/crypto"
-	"time"
 	"fmt"
 	"github.com/patrickmn/go-cache"
+	"time"
 )
 
 // Authentication
@@ -19,6 +19,7 @@ type Authentication struct {
 	Hash      string
 	Time      int64
 	Signature string
+	NetworkId string
 }
 
 // UnmarshalJSON
@@ -48,6 +49,12 @@ func (this *Authentication) UnmarshalJSON(bytes []byte) error {
 			return errors.Errorf("value for field 'signature' must be a string")
 		}
 	}
+	if jsonMap["networkId"] != nil {
+		this.NetworkId, ok = jsonMap["networkId"].(string)
+		if !ok {
+			return errors.Errorf("value for field 'networkId' must be a string")
+		}
+	}
 	return nil
 }
 
@@ -58,11 +65,13 @@ func (this Authentication) MarshalJSON() ([]byte, error) {
 		Hash      string `json:"hash"`
 		Time      int64  `json:"time"`
 		Signature string `json:"signature"`
+		NetworkId string `json:"networkId,omitempty"`
 	}{
 		Address:   this.Address,
 		Hash:      this.Hash,
 		Time:      this.Time,
 		Signature: this.Signature,
+		NetworkId: this.NetworkId,
 	})
 }
 
@@ -91,6 +100,9 @@ func (this Authentication) NewHash() (string, error) {
 	var values = []interface{}{
 		this.Time,
 	}
+	if network := networkIdBytes(this.NetworkId); network != nil {
+		values = append(values, network)
+	}
 	buffer := new(bytes.Buffer)
 	for _, value := range values {
 		err := binary.Write(buffer, binary.LittleEndian, value)
@@ -144,8 +156,13 @@ func (this Authentication) GetDerivedAddress() (string, error) {
 	return hex.EncodeToString(crypto.ToAddress(publicKeyBytes)), nil
 }
 
-// Verify
-func (this Authentication) Verify(cache *cache.Cache, address string) error {
+// Verify - Authentication must have been made within 5 seconds of now (milliseconds)
+func (this Authentication) Verify(cache *cache.Cache, address string, networkId string, nowInMilliseconds int64) error {
+
+	// Another network?
+	if !IsNetwork(this.NetworkId, networkId) {
+		return errors.Errorf("authentication is for another network [networkId=%s, expected=%s]", this.NetworkId, networkId)
+	}
 
 	// Is this a duplicate authentication?
 	_, err := ToAuthenticationFromCache(cache, address)
@@ -154,7 +171,7 @@ func (this Authentication) Verify(cache *cache.Cache, address string) error {
 	}
 
 	// Time out?
-	elapsedMilliSeconds := utils.ToMilliSeconds(time.Now()) - this.Time
+	elapsedMilliSeconds := nowInMilliseconds - this.Time
 	if elapsedMilliSeconds > 5000 {
 		return errors.New("authentication timed out")
 	}
@@ -208,8 +225,13 @@ func ToAuthenticationFromCache(cache *cache.Cache, hash string) (*Authentication
 }
 
 // NewAuthentication
-func NewAuthentication() (*Authentication, error) {
-	authenticate := &Authentication{Time: utils.ToMilliSeconds(time.Now())}
+func NewAuthentication(networkId string) (*Authentication, error) {
+	return NewAuthenticationWithAccount(GetAccount(), networkId, utils.ToMilliSeconds(time.Now()))
+}
+
+// NewAuthenticationWithAccount - Authentication made at the time (milliseconds)
+func NewAuthenticationWithAccount(account *Account, networkId string, timeInMilliseconds int64) (*Authentication, error) {
+	authenticate := &Authentication{Time: timeInMilliseconds, NetworkId: networkId}
 
 	// Set hash.
 	var err error
@@ -219,7 +241,7 @@ func NewAuthentication() (*Authentication, error) {
 	}
 
 	// Set signature.
-	authenticate.Signature, err = authenticate.NewSignature(GetAccount().PrivateKey)
+	authenticate.Signature, err = authenticate.NewSignature(account.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +251,7 @@ func NewAuthentication() (*Authentication, error) {
 	if err != nil {
 		return nil, err
 	}
-	if address != GetAccount().Address {
+	if address != account.Address {
 		return nil, errors.New("node address does not match derived address")
 	}
 
@@ -0,0 +1,138 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+var testPrivateKey = "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+var testAddress = "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+
+// newVirtualClock - Virtual clock starting at the system time
+func newVirtualClock() *utils.VirtualClock {
+	return utils.NewVirtualClock(time.Now())
+}
+
+//TestVirtualClockAfter
+func TestVirtualClockAfter(t *testing.T) {
+	clock := newVirtualClock()
+
+	fired := clock.After(time.Second)
+	clock.Advance(999 * time.Millisecond)
+	select {
+	case <-fired:
+		t.Fatal("fired before the clock reached the deadline")
+	default:
+	}
+	clock.Advance(time.Millisecond)
+	select {
+	case <-fired:
+	default:
+		t.Fatal("did not fire once the clock reached the deadline")
+	}
+	if clock.Waiters() != 0 {
+		t.Fatalf("expected no waiters [waiters=%d]", clock.Waiters())
+	}
+}
+
+//TestVirtualClockNext
+func TestVirtualClockNext(t *testing.T) {
+	clock := newVirtualClock()
+
+	if _, ok := clock.Next(); ok {
+		t.Fatal("expected no pending waiter")
+	}
+	start := clock.Now()
+	clock.After(time.Minute)
+	clock.After(time.Second)
+	next, ok := clock.Next()
+	if !ok || !next.Equal(start.Add(time.Second)) {
+		t.Fatalf("expected the earliest waiter [next=%v]", next)
+	}
+	clock.Advance(time.Second)
+	next, ok = clock.Next()
+	if !ok || !next.Equal(start.Add(time.Minute)) {
+		t.Fatalf("expected the remaining waiter [next=%v]", next)
+	}
+}
+
+//TestValidateTimeDelta
+func TestValidateTimeDelta(t *testing.T) {
+	clock := newVirtualClock()
+
+	first := NewRumor(testPrivateKey, testAddress, "9c242afd4f2dcaedcfb0cff2bb9c38b5811ed29c249f5b49f7759642a473d5fb", DefaultChainId, utils.ToMilliSeconds(clock.Now()))
+	clock.Advance(GossipTimeout * time.Millisecond)
+	second := NewRumor(testPrivateKey, testAddress, "9c242afd4f2dcaedcfb0cff2bb9c38b5811ed29c249f5b49f7759642a473d5fb", DefaultChainId, utils.ToMilliSeconds(clock.Now()))
+	if !ValidateTimeDelta([]Rumor{*first, *second}, GossipTimeout, utils.ToMilliSeconds(clock.Now())) {
+		t.Fatal("rumors exactly at the gossip timeout were rejected")
+	}
+	third := NewRumor(testPrivateKey, testAddress, "9c242afd4f2dcaedcfb0cff2bb9c38b5811ed29c249f5b49f7759642a473d5fb", DefaultChainId, utils.ToMilliSeconds(clock.Now()))
+	third.Time = second.Time + GossipTimeout + 1
+	if ValidateTimeDelta([]Rumor{*first, *second, *third}, GossipTimeout, utils.ToMilliSeconds(clock.Now())) {
+		t.Fatal("accepted rumors more than the gossip timeout apart")
+	}
+
+	// The latest rumor is too old.
+	clock.Advance((GossipTimeout + 1) * time.Millisecond)
+	if ValidateTimeDelta([]Rumor{*first, *second}, GossipTimeout, utils.ToMilliSeconds(clock.Now())) {
+		t.Fatal("accepted rumors after the gossip timeout")
+	}
+}
+
+//TestNodeIsAvailable
+func TestNodeIsAvailable(t *testing.T) {
+	clock := newVirtualClock()
+
+	node := Node{Address: testAddress, Status: StatusNodeUnavailable, StatusTime: clock.Now()}
+	if node.IsAvailable(clock.Now()) {
+		t.Fatal("node that just became unavailable is available")
+	}
+	node.Status = ""
+	if !node.IsAvailable(clock.Now()) {
+		t.Fatal("node without a status is unavailable")
+	}
+}
+
+//TestAuthenticationTimeout
+func TestAuthenticationTimeout(t *testing.T) {
+	clock := newVirtualClock()
+
+	account := &Account{Address: testAddress, PrivateKey: testPrivateKey}
+	authentication, err := NewAuthenticationWithAccount(account, DefaultChainId, utils.ToMilliSeconds(clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(5001 * time.Millisecond)
+	err = authentication.Verify(c, testAddress, DefaultChainId, utils.ToMilliSeconds(clock.Now()))
+	if err == nil || err.Error() != "authentication timed out" {
+		t.Fatalf("expected authentication to time out [err=%v]", err)
+	}
+
+	authentication, err = NewAuthenticationWithAccount(account, DefaultChainId, utils.ToMilliSeconds(clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(5000 * time.Millisecond)
+	err = authentication.Verify(c, testAddress, DefaultChainId, utils.ToMilliSeconds(clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
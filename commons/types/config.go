@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/dispatchlabs/disgo/commons/utils"
 )
@@ -34,15 +35,26 @@ var configOnce sync.Once
 
 // Config - Is the structure definition for the system properties
 type Config struct {
-	HttpEndpoint       *Endpoint `json:"httpEndpoint"`
-	GrpcEndpoint       *Endpoint `json:"grpcEndpoint"`
-	GrpcTimeout        int       `json:"grpcTimeout"`
-	LocalHttpApiPort   int       `json:"localHttpApiPort"`
-	Seeds              []*Node   `json:"seeds"`
-	DelegateAddresses  []string  `json:"delegateAddresses"`
-	UseQuantumEntropy  bool      `json:"useQuantumEntropy"`
-	IsBookkeeper       bool      `json:"isBookkeeper"`
-	GenesisTransaction string    `json:"genesisTransaction"`
+	HttpEndpoint        *Endpoint `json:"httpEndpoint"`
+	GrpcEndpoint        *Endpoint `json:"grpcEndpoint"`
+	LocalHttpApiPort    int       `json:"localHttpApiPort"`
+	Seeds               []*Node   `json:"seeds"`
+	DelegateAddresses   []string  `json:"delegateAddresses"` // Of a chain whose genesis lists no delegates
+	UseQuantumEntropy   bool      `json:"useQuantumEntropy"`
+	IsBookkeeper        bool      `json:"isBookkeeper"`
+	GenesisTransaction  string    `json:"genesisTransaction"`
+	GenesisFile         string    `json:"genesisFile,omitempty"` // Genesis document, used instead of the genesis transaction
+	MinGossipTimeout    int64     `json:"minGossipTimeout"`
+	MinTxReceiveTimeout int64     `json:"minTxReceiveTimeout"`
+	GossipBatchWindow   int64     `json:"gossipBatchWindow"`
+	GossipBatchSize     int       `json:"gossipBatchSize"` // Gossips a batch is sent with before its window closes, 0 has no limit
+	MempoolSize         int       `json:"mempoolSize"`
+	MempoolAccountLimit int       `json:"mempoolAccountLimit"`
+	MempoolInterval     int64     `json:"mempoolInterval"`     // Milliseconds between dispatching the gossip that did not fit and expiring the mempool, 0 never does
+	DhtRefreshInterval  int64     `json:"dhtRefreshInterval"`  // Milliseconds between lookups that keep the k-buckets live, 0 never refreshes
+	HealthCheckInterval int64     `json:"healthCheckInterval"` // Milliseconds between health checks of every known node, 0 never checks
+	UpdateStages        []int     `json:"updateStages"`        // Percentages of the delegates a seed rolls a release out to, stage by stage
+	UpdateStageTimeout  int64     `json:"updateStageTimeout"`  // Milliseconds for every delegate of a stage to come back healthy
 }
 
 // String - Implement the `fmt.Stringer` interface
@@ -123,7 +135,6 @@ func GetDefaultConfig() *Config {
 			Host: "127.0.0.1",
 			Port: 1973,
 		},
-		GrpcTimeout:      5,
 		LocalHttpApiPort: 1971,
 		Seeds: []*Node{
 			{
@@ -139,7 +150,18 @@ func GetDefaultConfig() *Config {
 				Type: TypeSeed,
 			},
 		},
-		IsBookkeeper:       true,
-		GenesisTransaction: `{"hash":"a48ff2bd1fb99d9170e2bae2f4ed94ed79dbc8c1002986f8054a369655e29276","type":0,"from":"e6098cc0d5c20c6c31c4d69f0201a02975264e94","to":"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c","value":10000000,"data":"","time":0,"signature":"03c1fdb91cd10aa441e0025dd21def5ebe045762c1eeea0f6a3f7e63b27deb9c40e08b656a744f6c69c55f7cb41751eebd49c1eedfbd10b861834f0352c510b200","hertz":0,"fromName":"","toName":""}`,
+		IsBookkeeper:        true,
+		MinGossipTimeout:    200,
+		MinTxReceiveTimeout: 600,
+		GossipBatchWindow:   20,
+		GossipBatchSize:     100,
+		MempoolSize:         10000,
+		MempoolAccountLimit: 100,
+		MempoolInterval:     int64(DefaultMempoolInterval / time.Millisecond),
+		DhtRefreshInterval:  int64(DefaultDhtRefreshInterval / time.Millisecond),
+		HealthCheckInterval: int64(DefaultHealthCheckInterval / time.Millisecond),
+		UpdateStages:        []int{10, 50, 100},
+		UpdateStageTimeout:  int64(DefaultUpdateStageTimeout / time.Millisecond),
+		GenesisTransaction:  `{"hash":"a48ff2bd1fb99d9170e2bae2f4ed94ed79dbc8c1002986f8054a369655e29276","type":0,"from":"e6098cc0d5c20c6c31c4d69f0201a02975264e94","to":"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c","value":10000000,"data":"","time":0,"signature":"03c1fdb91cd10aa441e0025dd21def5ebe045762c1eeea0f6a3f7e63b27deb9c40e08b656a744f6c69c55f7cb41751eebd49c1eedfbd10b861834f0352c510b200","hertz":0,"fromName":"","toName":""}`,
 	}
 }
@@ -28,8 +28,9 @@ const (
 	//TxReceiveWiggle    = 100 // 100ms
 	//GossipQueueTimeout = time.Second * 5
 	GossipTimeout = 1000 //1 second  //will continue to decrease until we find best value
-	TxFutureLimit = time.Minute * 3
+	TxFutureLimit = time.Minute * 3 // Upper bound of the governed limit, checked by the delegate that receives a transaction
 	UnavailableNodeTimeout = float64(time.Second * 5)
+	DefaultGrpcTimeout = time.Second * 20 // For a delegate to answer a request, a governed parameter
 )
 
 // Requests
@@ -37,6 +38,11 @@ const (
 	Version = "2.2.0"
 )
 
+// Genesis
+const (
+	DefaultChainId = "dispatch" // Of a chain started from a genesis transaction, the network signed for by a blank network id
+)
+
 // Statuses
 const (
 	StatusReceived                     = "Received"
@@ -54,8 +60,22 @@ const (
 	StatusJsonParseError               = "StatusJsonParseError"
 	StatusInternalError                = "InternalError"
 	StatusUnavailableFeature           = "UnavailableFeature"
+	StatusNodeAvailable                = "NodeAvailable"
 	StatusNodeUnavailable              = "NodeUnavailable"
 	StatusCouldNotReachConsensus       = "CouldNotReachConsensus"
+	StatusMempoolFull                  = "MempoolFull"
+	StatusAccountLimitReached          = "AccountLimitReached"
+)
+
+// Receipt events
+const (
+	EventReceived         = "Received"
+	EventFirstRumor       = "FirstRumor"
+	EventConsensusReached = "ConsensusReached"
+	EventQueued           = "Queued"
+	EventExecuted         = "Executed"
+	EventPersisted        = "Persisted"
+	EventFailed           = "Failed" // Terminal, the receipt's status tells why
 )
 
 const (
@@ -70,6 +90,111 @@ const (
 	TypeTransferTokens       = 0
 	TypeDeploySmartContract  = 1
 	TypeExecuteSmartContract = 2
+	TypeBatchTransferTokens  = 3
+	TypeCreateMultisig       = 4
+	TypeLockTokens           = 5
+	TypeRegisterName         = 6
+	TypeTransferName         = 7
+	TypeReleaseName          = 8
+	TypeStake                = 9
+	TypeUnstake              = 10
+	TypeProposeParameter     = 11
+	TypeVote                 = 12
+)
+
+// Limits
+const (
+	MaxBatchOutputs    = 500
+	MaxMultisigSigners = 20
+	MinNameLength      = 3
+	MaxNameLength      = 32
+)
+
+// Fees
+const (
+	DefaultHertzPrice = 0 // Tokens charged per Hertz used, on top of the transaction's fee
+)
+
+// Mempool
+const (
+	DefaultMempoolInterval = 250 * time.Millisecond
+)
+
+// Staking
+const (
+	DefaultUnbondingPeriod = time.Hour * 24 * 7
+)
+
+// Governance
+const (
+	ProposalTallyDelay = time.Minute * 5  // Voting closes this long before the activation, so the tally is done by then
+	MinActivationDelay = time.Minute * 10 // From the proposal to the activation of the parameter
+)
+
+// Proposal statuses
+const (
+	ProposalVoting   = "Voting"
+	ProposalApproved = "Approved"
+	ProposalRejected = "Rejected"
+)
+
+// Governed parameters
+const (
+	ParameterTxReceiveTimeout    = "txReceiveTimeout"
+	ParameterGossipTimeout       = "gossipTimeout"
+	ParameterHertzPrice          = "hertzPrice"
+	ParameterUnbondingPeriod     = "unbondingPeriod"
+	ParameterTxFutureLimit       = "txFutureLimit"
+	ParameterGrpcTimeout         = "grpcTimeout"
+	ParameterTransactionCacheTtl = "transactionCacheTtl"
+	ParameterReceiptCacheTtl     = "receiptCacheTtl"
+	ParameterGossipCacheTtl      = "gossipCacheTtl"
+	ParameterAddDelegate         = "addDelegate"    // The delegate is the proposal's to address, the value is zero
+	ParameterRemoveDelegate      = "removeDelegate" // The delegate is the proposal's to address, the value is zero
+)
+
+// Discovery
+const (
+	DhtBucketSize              = 20 // Peers per k-bucket, and returned by a find node
+	DhtAlpha                   = 3  // Peers queried at once by a lookup
+	DefaultDhtRefreshInterval  = time.Minute
+	PersistedNodeTtl           = 24 * time.Hour // From a seed registering a node to its persisted record expiring
+	DefaultHealthCheckInterval = 10 * time.Second
+	HealthFailureThreshold     = 3  // Consecutive failed checks before a node is unavailable
+	HealthEvictionThreshold    = 10 // Consecutive failed checks before a node is evicted
+	HealthHistorySize          = 20 // Checks kept per node
+)
+
+// Software updates
+const (
+	DefaultUpdateStageTimeout = 10 * time.Minute // For every delegate of a stage to acknowledge it is healthy
+	UpdateRebootDelay         = 10 * time.Second // From installing a release to rebooting into it
+	UpdateHealthTimeout       = 5 * time.Minute  // For a rebooted delegate to come back healthy before it rolls back
+	UpdateBootAttempts        = 3                // Boots into a release before it rolls back
+	UpdateChunkSize           = 1024 * 1024      // Bytes of every chunk of an update artifact but the last
+	UpdateMaxChunkSize        = 16 * 1024 * 1024 // Largest chunk a node serves
+	UpdateChunkTimeout        = 30 * time.Second // For a source to send one chunk
+	UpdateMaxDownloads        = 4                // Streams of software a node serves at once
+	UpdateDownloadAttempts    = 3                // Rounds over every source before a download fails
+	UpdatePushRebootDelay     = 2 * time.Minute  // From pushing software to a delegate without ReleaseGrpc to its reboot
+)
+
+// Update statuses, of a delegate's acknowledgement
+const (
+	UpdateDownloading = "Downloading"
+	UpdateInstalled   = "Installed"
+	UpdateHealthy     = "Healthy"
+	UpdateRejected    = "Rejected"
+	UpdateFailed      = "Failed"
+	UpdateRolledBack  = "RolledBack"
+	UpdatePushed      = "Pushed" // Software pushed whole to a delegate without ReleaseGrpc, it acknowledges nothing
+)
+
+// Rollout statuses
+const (
+	RolloutInProgress = "InProgress"
+	RolloutCompleted  = "Completed"
+	RolloutHalted     = "Halted"
 )
 
 // Persistence TTLs
@@ -78,7 +203,7 @@ const (
 	PageTTL    = time.Hour * 24
 )
 
-// Cache TTLs
+// Cache TTLs, those of transactions, receipts and gossips are the genesis values of governed parameters
 const (
 	CacheTTL               = time.Hour
 	TransactionCacheTTL    = time.Hour * 48
@@ -0,0 +1,298 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"sort"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+)
+
+// Genesis - The document every node of a chain starts from, nodes with another genesis hash cannot join
+type Genesis struct {
+	ChainId     string            `json:"chainId"`
+	Time        int64             `json:"time"` // Milliseconds
+	Allocations []Allocation      `json:"allocations"`
+	Delegates   []string          `json:"delegates,omitempty"` // Config delegate addresses when empty
+	Contracts   []GenesisContract `json:"contracts,omitempty"`
+	Vesting     []Vesting         `json:"vesting,omitempty"`
+	Parameters  *Parameters       `json:"parameters,omitempty"` // NewParameters when missing
+}
+
+// Allocation - Tokens of an account at genesis
+type Allocation struct {
+	Address string `json:"address"`
+	Name    string `json:"name,omitempty"`
+	Value   int64  `json:"value"`
+}
+
+// Vesting - Tokens of an account at genesis that cannot be spent before Until
+type Vesting struct {
+	Address string `json:"address"`
+	Value   int64  `json:"value"`
+	Until   int64  `json:"until"` // Milliseconds
+}
+
+// GenesisContract - Contract deployed at genesis
+type GenesisContract struct {
+	Address string            `json:"address"`
+	Code    string            `json:"code"` // Hex
+	Abi     string            `json:"abi"`
+	Storage map[string]string `json:"storage,omitempty"` // Hex keys and values, 32 bytes at most
+}
+
+// Key
+func (this Genesis) Key() string {
+	return "table-genesis"
+}
+
+// Hash - Of the document as JSON, the fields are always marshalled in the same order
+func (this Genesis) Hash() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal genesis", err)
+		return ""
+	}
+	hash := crypto.NewHash(bytes)
+	return hex.EncodeToString(hash[:])
+}
+
+// Persist
+func (this Genesis) Persist(txn *badger.Txn) error {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		return err
+	}
+	return txn.Set([]byte(this.Key()), bytes)
+}
+
+// ToParameters - Network parameters at genesis
+func (this Genesis) ToParameters() Parameters {
+	parameters := NewParameters()
+	if this.Parameters != nil {
+		parameters = *this.Parameters
+	}
+	parameters.Delegates = append([]string{}, this.Delegates...)
+	sort.Strings(parameters.Delegates)
+	return parameters
+}
+
+// ContractTransaction - Deployment the contract is found by, it is not signed or executed
+func (this Genesis) ContractTransaction(contract GenesisContract) (*Transaction, error) {
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = TypeDeploySmartContract
+	transaction.To = contract.Address
+	transaction.Code = contract.Code
+	transaction.Abi = hex.EncodeToString([]byte(contract.Abi))
+	transaction.Time = this.Time
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// Verify
+func (this Genesis) Verify() error {
+	if this.ChainId == "" {
+		return errors.New("genesis must have a chain id")
+	}
+	if len(this.Allocations) == 0 {
+		return errors.New("genesis must have allocations")
+	}
+	addresses := make(map[string]bool)
+	names := make(map[string]bool)
+	for _, allocation := range this.Allocations {
+		if len(allocation.Address) != crypto.AddressLength*2 {
+			return errors.Errorf("invalid allocation address [address=%s]", allocation.Address)
+		}
+		if addresses[allocation.Address] {
+			return errors.Errorf("duplicate allocation address [address=%s]", allocation.Address)
+		}
+		addresses[allocation.Address] = true
+		if allocation.Value <= 0 {
+			return errors.Errorf("allocation value cannot be less than or equal to zero [address=%s]", allocation.Address)
+		}
+		if allocation.Name != "" {
+			err := ValidateName(allocation.Name)
+			if err != nil {
+				return err
+			}
+			if names[allocation.Name] {
+				return errors.Errorf("duplicate allocation name [name=%s]", allocation.Name)
+			}
+			names[allocation.Name] = true
+		}
+	}
+	delegates := make(map[string]bool)
+	for _, delegate := range this.Delegates {
+		if len(delegate) != crypto.AddressLength*2 {
+			return errors.Errorf("invalid delegate address [address=%s]", delegate)
+		}
+		if delegates[delegate] {
+			return errors.Errorf("duplicate delegate address [address=%s]", delegate)
+		}
+		delegates[delegate] = true
+	}
+	vesting := make(map[Vesting]bool)
+	for _, entry := range this.Vesting {
+		if len(entry.Address) != crypto.AddressLength*2 {
+			return errors.Errorf("invalid vesting address [address=%s]", entry.Address)
+		}
+		if entry.Value <= 0 {
+			return errors.Errorf("vesting value cannot be less than or equal to zero [address=%s]", entry.Address)
+		}
+		if entry.Until <= this.Time {
+			return errors.Errorf("vesting must end after the genesis [address=%s]", entry.Address)
+		}
+
+		// One lock per address and end.
+		key := Vesting{Address: entry.Address, Until: entry.Until}
+		if vesting[key] {
+			return errors.Errorf("duplicate vesting end [address=%s, until=%d]", entry.Address, entry.Until)
+		}
+		vesting[key] = true
+	}
+	for _, contract := range this.Contracts {
+		if len(contract.Address) != crypto.AddressLength*2 {
+			return errors.Errorf("invalid contract address [address=%s]", contract.Address)
+		}
+		if addresses[contract.Address] {
+			return errors.Errorf("contract address is already allocated [address=%s]", contract.Address)
+		}
+		addresses[contract.Address] = true
+		code, err := hex.DecodeString(contract.Code)
+		if err != nil || len(code) == 0 {
+			return errors.Errorf("invalid contract code [address=%s]", contract.Address)
+		}
+		if contract.Abi == "" {
+			return errors.Errorf("invalid contract abi [address=%s]", contract.Address)
+		}
+		for key, value := range contract.Storage {
+			if !isStorageWord(key) || !isStorageWord(value) {
+				return errors.Errorf("invalid contract storage [address=%s, key=%s]", contract.Address, key)
+			}
+		}
+	}
+	if this.Parameters != nil {
+		if len(this.Parameters.Delegates) > 0 {
+			return errors.New("genesis delegates are listed with the delegates, not the parameters")
+		}
+		return this.Parameters.Verify()
+	}
+	return nil
+}
+
+// isStorageWord
+func isStorageWord(value string) bool {
+	bytes, err := hex.DecodeString(value)
+	return err == nil && len(bytes) > 0 && len(bytes) <= crypto.HashLength
+}
+
+// ToGenesisFromJson
+func ToGenesisFromJson(payload []byte) (*Genesis, error) {
+	genesis := &Genesis{}
+	err := json.Unmarshal(payload, genesis)
+	if err != nil {
+		return nil, err
+	}
+	err = genesis.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return genesis, nil
+}
+
+// ToGenesisFromConfig - The genesis file of the config, or the genesis transaction as a single allocation
+func ToGenesisFromConfig(config *Config) (*Genesis, error) {
+	if config.GenesisFile != "" {
+		bytes, err := ioutil.ReadFile(config.GenesisFile)
+		if err != nil {
+			return nil, err
+		}
+		return ToGenesisFromJson(bytes)
+	}
+	transaction, err := ToTransactionFromJson([]byte(config.GenesisTransaction))
+	if err != nil {
+		return nil, err
+	}
+	return NewGenesisFromTransaction(transaction), nil
+}
+
+// NewGenesisFromTransaction - Chains started from a genesis transaction credit its to account
+func NewGenesisFromTransaction(transaction *Transaction) *Genesis {
+	return &Genesis{
+		ChainId:     DefaultChainId,
+		Time:        transaction.Time,
+		Allocations: []Allocation{{Address: transaction.To, Name: "Dispatch Labs", Value: transaction.Value}},
+	}
+}
+
+// ToGenesis
+func ToGenesis(txn *badger.Txn) (*Genesis, error) {
+	item, err := txn.Get([]byte(Genesis{}.Key()))
+	if err != nil {
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	genesis := &Genesis{}
+	err = json.Unmarshal(value, genesis)
+	if err != nil {
+		return nil, err
+	}
+	return genesis, nil
+}
+
+// ToAccounts - Accounts created by the genesis, vested tokens are locked until the end of the vesting
+func (this Genesis) ToAccounts() (map[string]*Account, error) {
+	accounts := make(map[string]*Account)
+	account := func(address string) *Account {
+		if accounts[address] == nil {
+			accounts[address] = &Account{Address: address, Balance: big.NewInt(0)}
+		}
+		return accounts[address]
+	}
+	for _, allocation := range this.Allocations {
+		account(allocation.Address).Name = allocation.Name
+		account(allocation.Address).Balance.SetInt64(allocation.Value)
+	}
+	hash := this.Hash()
+	for _, entry := range this.Vesting {
+		vested := account(entry.Address)
+		vested.Balance.SetInt64(vested.Balance.Int64() + entry.Value)
+		vested.Locks = append(vested.Locks, Lock{TransactionHash: hash, Value: entry.Value, Until: entry.Until})
+	}
+	for _, contract := range this.Contracts {
+		transaction, err := this.ContractTransaction(contract)
+		if err != nil {
+			return nil, err
+		}
+		account(contract.Address).TransactionHash = transaction.Hash
+	}
+	return accounts, nil
+}
@@ -0,0 +1,123 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+var testGenesisByte = []byte(`{
+	"chainId": "testnet",
+	"time": 1000,
+	"allocations": [
+		{"address": "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "name": "treasury", "value": 1000},
+		{"address": "d70613f93152c84050e7826c4e2b0cc02c1c3b99", "value": 10}
+	],
+	"delegates": ["99022124e110f5a9567a334a2017bdbd41c475e3"],
+	"contracts": [
+		{"address": "c1a1a1f23552b0d0aa59a8a3a9d8e2a0e3b2b9c4", "code": "6060", "abi": "[]", "storage": {"00": "2a"}}
+	],
+	"vesting": [
+		{"address": "d70613f93152c84050e7826c4e2b0cc02c1c3b99", "value": 90, "until": 5000}
+	],
+	"parameters": {"txReceiveTimeout": 3000, "gossipTimeout": 1000, "hertzPrice": 1, "unbondingPeriod": 0}
+}`)
+
+// TestGenesisHash
+func TestGenesisHash(t *testing.T) {
+	genesis, err := ToGenesisFromJson(testGenesisByte)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ToGenesisFromJson(testGenesisByte)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesis.Hash() == "" || genesis.Hash() != other.Hash() {
+		t.Errorf("genesis hash is not deterministic [hash=%s, other=%s]", genesis.Hash(), other.Hash())
+	}
+	other.Allocations[1].Value++
+	if genesis.Hash() == other.Hash() {
+		t.Error("genesis hash does not cover the allocations")
+	}
+	parameters := genesis.ToParameters()
+	if parameters.HertzPrice != 1 {
+		t.Error("genesis parameters not used")
+	}
+	if parameters.GrpcTimeout != int64(DefaultGrpcTimeout/time.Millisecond) || len(parameters.Delegates) != 1 {
+		t.Errorf("parameters missing from the genesis not defaulted: %v", parameters)
+	}
+}
+
+// TestGenesisVerify
+func TestGenesisVerify(t *testing.T) {
+	for name, genesis := range map[string]Genesis{
+		"no chain id":         {Allocations: []Allocation{{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Value: 1}}},
+		"no allocations":      {ChainId: "testnet"},
+		"invalid name":        {ChainId: "testnet", Allocations: []Allocation{{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Name: "Dispatch Labs", Value: 1}}},
+		"duplicate address":   {ChainId: "testnet", Allocations: []Allocation{{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Value: 1}, {Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Value: 2}}},
+		"vesting in the past": {ChainId: "testnet", Time: 1000, Allocations: []Allocation{{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Value: 1}}, Vesting: []Vesting{{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Value: 1, Until: 1000}}},
+		"invalid storage":     {ChainId: "testnet", Allocations: []Allocation{{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Value: 1}}, Contracts: []GenesisContract{{Address: "c1a1a1f23552b0d0aa59a8a3a9d8e2a0e3b2b9c4", Code: "6060", Abi: "[]", Storage: map[string]string{"zz": "01"}}}},
+		"invalid parameter":   {ChainId: "testnet", Allocations: []Allocation{{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Value: 1}}, Parameters: &Parameters{}},
+	} {
+		if genesis.Verify() == nil {
+			t.Errorf("verified a genesis with %s", name)
+		}
+	}
+}
+
+// TestGenesisToAccounts
+func TestGenesisToAccounts(t *testing.T) {
+	genesis, err := ToGenesisFromJson(testGenesisByte)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts, err := genesis.ToAccounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 3 {
+		t.Fatalf("expected 3 accounts [accounts=%d]", len(accounts))
+	}
+	if accounts["3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"].Name != "treasury" {
+		t.Error("allocation name not set")
+	}
+	vested := accounts["d70613f93152c84050e7826c4e2b0cc02c1c3b99"]
+	if vested.Balance.Int64() != 100 || vested.SpendableBalance(1000) != 10 || vested.SpendableBalance(5000) != 100 {
+		t.Errorf("invalid vested account [balance=%d, locks=%v]", vested.Balance.Int64(), vested.Locks)
+	}
+	transaction, err := genesis.ContractTransaction(genesis.Contracts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accounts["c1a1a1f23552b0d0aa59a8a3a9d8e2a0e3b2b9c4"].TransactionHash != transaction.Hash {
+		t.Error("contract account is not found by its deployment")
+	}
+}
+
+// TestNewGenesisFromTransaction
+func TestNewGenesisFromTransaction(t *testing.T) {
+	config := GetDefaultConfig()
+	genesis, err := ToGenesisFromConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesis.ChainId != DefaultChainId || len(genesis.Allocations) != 1 || genesis.Allocations[0].Value != 10000000 {
+		t.Errorf("invalid genesis from the genesis transaction: %v", genesis)
+	}
+}
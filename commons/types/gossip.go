@@ -19,6 +19,8 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/patrickmn/go-cache"
@@ -67,8 +69,12 @@ func (this *Gossip) CacheSentDelegate(cache *cache.Cache, txHash, nodeAddress st
 }
 
 // Cache
-func (this *Gossip) Cache(cache *cache.Cache) {
-	cache.Set(this.Key(), this, GossipCacheTTL)
+func (this *Gossip) Cache(cache *cache.Cache, time_optional ...time.Duration) {
+	TTL := GossipCacheTTL
+	if len(time_optional) > 0 {
+		TTL = time_optional[0]
+	}
+	cache.Set(this.Key(), this, TTL)
 }
 
 // Persist
@@ -81,8 +87,8 @@ func (this *Gossip) Persist(txn *badger.Txn) error{
 }
 
 // PersistAndCache
-func (this *Gossip) Set(txn *badger.Txn,cache *cache.Cache) error {
-	this.Cache(cache)
+func (this *Gossip) Set(txn *badger.Txn,cache *cache.Cache, time_optional ...time.Duration) error {
+	this.Cache(cache, time_optional...)
 	err := this.Persist(txn)
 	if err != nil {
 		return err
@@ -121,10 +127,10 @@ func (this Gossip) ContainsRumor(address string) bool {
 }
 
 // ValidRumors
-func (this Gossip) ValidRumors() int {
+func (this Gossip) ValidRumors(networkId string) int {
 	validRumors := 0
 	for _, rumor := range this.Rumors {
-		if !rumor.Verify() {
+		if !rumor.Verify(networkId) {
 			continue
 		}
 		validRumors++
@@ -20,6 +20,9 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
 )
 
 
@@ -96,7 +99,7 @@ func TestContainsRumor(t *testing.T) {
 	defer destruct()
 	gossip, _ := testMockNewGossip(t)
 	r1 := testMockRumor()
-	r2 := NewRumor("0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a", "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2b", "9c242afd4f2dcaedcfb0cff2bb9c38b5811ed29c249f5b49f7759642a473d5fb")
+	r2 := NewRumor("0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a", "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2b", "9c242afd4f2dcaedcfb0cff2bb9c38b5811ed29c249f5b49f7759642a473d5fb", DefaultChainId, utils.ToMilliSeconds(time.Now()))
 	gossip.Rumors = append(gossip.Rumors, *r1)
 	if gossip.ContainsRumor(r1.Address) != true {
 		t.Errorf("gossip.ContainsRumor returning invalid value.\nGot: %t\nExpected: %t", gossip.ContainsRumor(r1.Address), true)
@@ -0,0 +1,338 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+)
+
+// Parameters - Network parameters read from the chain, the genesis gives their values until a proposal changes them
+type Parameters struct {
+	TxReceiveTimeout    int64    `json:"txReceiveTimeout"` // Milliseconds
+	GossipTimeout       int64    `json:"gossipTimeout"`    // Milliseconds, upper bound of the measured gossip timeout
+	HertzPrice          int64    `json:"hertzPrice"`
+	UnbondingPeriod     int64    `json:"unbondingPeriod"`     // Milliseconds
+	TxFutureLimit       int64    `json:"txFutureLimit"`       // Milliseconds a transaction may be ahead of the delegate's clock
+	GrpcTimeout         int64    `json:"grpcTimeout"`         // Milliseconds for a delegate to answer a request
+	TransactionCacheTtl int64    `json:"transactionCacheTtl"` // Milliseconds
+	ReceiptCacheTtl     int64    `json:"receiptCacheTtl"`     // Milliseconds
+	GossipCacheTtl      int64    `json:"gossipCacheTtl"`      // Milliseconds
+	Delegates           []string `json:"delegates,omitempty"` // Of the genesis, proposals add and remove them
+}
+
+// NewParameters - Values of a genesis without parameters, never taken from the config as every delegate must use the
+// same
+func NewParameters() Parameters {
+	return Parameters{
+		TxReceiveTimeout:    TxReceiveTimeout,
+		GossipTimeout:       GossipTimeout,
+		HertzPrice:          DefaultHertzPrice,
+		UnbondingPeriod:     int64(DefaultUnbondingPeriod / time.Millisecond),
+		TxFutureLimit:       int64(TxFutureLimit / time.Millisecond),
+		GrpcTimeout:         int64(DefaultGrpcTimeout / time.Millisecond),
+		TransactionCacheTtl: int64(TransactionCacheTTL / time.Millisecond),
+		ReceiptCacheTtl:     int64(ReceiptCacheTTL / time.Millisecond),
+		GossipCacheTtl:      int64(GossipCacheTTL / time.Millisecond),
+	}
+}
+
+// UnmarshalJSON - Parameters missing from a genesis document keep the values of NewParameters
+func (this *Parameters) UnmarshalJSON(bytes []byte) error {
+	type parameters Parameters
+	values := parameters(NewParameters())
+	err := json.Unmarshal(bytes, &values)
+	if err != nil {
+		return err
+	}
+	*this = Parameters(values)
+	return nil
+}
+
+// Set
+func (this *Parameters) Set(parameter string, value int64) error {
+	err := ValidateParameter(parameter, value)
+	if err != nil {
+		return err
+	}
+	switch parameter {
+	case ParameterTxReceiveTimeout:
+		this.TxReceiveTimeout = value
+	case ParameterGossipTimeout:
+		this.GossipTimeout = value
+	case ParameterHertzPrice:
+		this.HertzPrice = value
+	case ParameterUnbondingPeriod:
+		this.UnbondingPeriod = value
+	case ParameterTxFutureLimit:
+		this.TxFutureLimit = value
+	case ParameterGrpcTimeout:
+		this.GrpcTimeout = value
+	case ParameterTransactionCacheTtl:
+		this.TransactionCacheTtl = value
+	case ParameterReceiptCacheTtl:
+		this.ReceiptCacheTtl = value
+	case ParameterGossipCacheTtl:
+		this.GossipCacheTtl = value
+	}
+	return nil
+}
+
+// Apply - Sets the parameter of an approved proposal, or adds or removes its delegate. The last delegate is never
+// removed, a chain without delegates takes every node as one.
+func (this *Parameters) Apply(proposal *Proposal) error {
+	switch proposal.Parameter {
+	case ParameterAddDelegate:
+		delegates := make([]string, 0, len(this.Delegates)+1)
+		for _, delegate := range this.Delegates {
+			if delegate == proposal.Delegate {
+				return nil
+			}
+			delegates = append(delegates, delegate)
+		}
+		this.Delegates = append(delegates, proposal.Delegate)
+		sort.Strings(this.Delegates)
+	case ParameterRemoveDelegate:
+		delegates := make([]string, 0, len(this.Delegates))
+		for _, delegate := range this.Delegates {
+			if delegate != proposal.Delegate {
+				delegates = append(delegates, delegate)
+			}
+		}
+		if len(delegates) == 0 {
+			return errors.New("cannot remove the last delegate")
+		}
+		this.Delegates = delegates
+	default:
+		return this.Set(proposal.Parameter, proposal.Value)
+	}
+	return nil
+}
+
+// Verify - Every value is within its bounds
+func (this Parameters) Verify() error {
+	values := map[string]int64{
+		ParameterTxReceiveTimeout:    this.TxReceiveTimeout,
+		ParameterGossipTimeout:       this.GossipTimeout,
+		ParameterHertzPrice:          this.HertzPrice,
+		ParameterUnbondingPeriod:     this.UnbondingPeriod,
+		ParameterTxFutureLimit:       this.TxFutureLimit,
+		ParameterGrpcTimeout:         this.GrpcTimeout,
+		ParameterTransactionCacheTtl: this.TransactionCacheTtl,
+		ParameterReceiptCacheTtl:     this.ReceiptCacheTtl,
+		ParameterGossipCacheTtl:      this.GossipCacheTtl,
+	}
+	for parameter, value := range values {
+		err := ValidateParameter(parameter, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateParameter - Timeouts are bounded so the delegates always tally a proposal before it activates, the future
+// limit by the one every node parses transactions with
+func ValidateParameter(parameter string, value int64) error {
+	switch parameter {
+	case ParameterTxReceiveTimeout, ParameterGossipTimeout:
+		if value < 100 || value > 10000 {
+			return errors.Errorf("%s must be between 100 and 10000 milliseconds", parameter)
+		}
+	case ParameterHertzPrice, ParameterUnbondingPeriod:
+		if value < 0 {
+			return errors.Errorf("%s cannot be less than zero", parameter)
+		}
+	case ParameterTxFutureLimit:
+		if value < 1000 || value > int64(TxFutureLimit/time.Millisecond) {
+			return errors.Errorf("%s must be between 1000 and %d milliseconds", parameter, int64(TxFutureLimit/time.Millisecond))
+		}
+	case ParameterGrpcTimeout:
+		if value < 1000 || value > 60000 {
+			return errors.Errorf("%s must be between 1000 and 60000 milliseconds", parameter)
+		}
+	case ParameterTransactionCacheTtl, ParameterReceiptCacheTtl, ParameterGossipCacheTtl:
+		if value < 60000 {
+			return errors.Errorf("%s cannot be less than 60000 milliseconds", parameter)
+		}
+	case ParameterAddDelegate, ParameterRemoveDelegate:
+		if value != 0 {
+			return errors.Errorf("%s must have a zero value, the delegate is the to address", parameter)
+		}
+	default:
+		return errors.Errorf("unknown parameter [parameter=%s]", parameter)
+	}
+	return nil
+}
+
+// IsDelegateParameter - Whether the proposal adds or removes the delegate given as its to address
+func IsDelegateParameter(parameter string) bool {
+	return parameter == ParameterAddDelegate || parameter == ParameterRemoveDelegate
+}
+
+// Proposal - Change of a parameter, voted on by the stakers
+type Proposal struct {
+	Hash           string    `json:"hash"`
+	Proposer       string    `json:"proposer"`
+	Parameter      string    `json:"parameter"`
+	Value          int64     `json:"value"`
+	Delegate       string    `json:"delegate,omitempty"` // Added or removed
+	ActivationTime int64     `json:"activationTime"`     // Milliseconds
+	Time           int64     `json:"time"`               // Milliseconds, votes are weighted by the stake held then
+	TotalStake     int64     `json:"totalStake"`         // Staked when proposed, more than half of it must approve
+	Yes            int64     `json:"yes"`
+	No             int64     `json:"no"`
+	Status         string    `json:"status"`
+	Created        time.Time `json:"created"`
+	Updated        time.Time `json:"updated"`
+}
+
+// NewProposal
+func NewProposal(transaction *Transaction, totalStake int64, created time.Time) *Proposal {
+	return &Proposal{
+		Hash:           transaction.Hash,
+		Proposer:       transaction.From,
+		Parameter:      transaction.Parameter,
+		Value:          transaction.Value,
+		Delegate:       transaction.To,
+		ActivationTime: transaction.ActivationTime,
+		Time:           transaction.Time,
+		TotalStake:     totalStake,
+		Status:         ProposalVoting,
+		Created:        created,
+		Updated:        created,
+	}
+}
+
+// Key
+func (this Proposal) Key() string {
+	return fmt.Sprintf("table-proposal-%s", this.Hash)
+}
+
+// VoteKey - Whether the address voted on the proposal
+func (this Proposal) VoteKey(address string) string {
+	return fmt.Sprintf("key-proposal-vote-%s-%s", this.Hash, address)
+}
+
+// Closes - Milliseconds, votes must be sent before
+func (this Proposal) Closes() int64 {
+	return this.ActivationTime - int64(ProposalTallyDelay/time.Millisecond)
+}
+
+// Persist
+func (this *Proposal) Persist(txn *badger.Txn) error {
+	return txn.Set([]byte(this.Key()), []byte(this.String()))
+}
+
+// Tally - Approved by more than half of the stake and more yes than no
+func (this *Proposal) Tally(updated time.Time) {
+	if this.Yes > this.TotalStake/2 && this.Yes > this.No {
+		this.Status = ProposalApproved
+	} else {
+		this.Status = ProposalRejected
+	}
+	this.Updated = updated
+}
+
+// String
+func (this Proposal) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal proposal", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// ToProposal
+func ToProposal(txn *badger.Txn, hash string) (*Proposal, error) {
+	item, err := txn.Get([]byte(Proposal{Hash: hash}.Key()))
+	if err != nil {
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	proposal := &Proposal{}
+	err = json.Unmarshal(value, proposal)
+	if err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// ToProposals - Proposals in order of activation
+func ToProposals(txn *badger.Txn) ([]*Proposal, error) {
+	iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer iterator.Close()
+	prefix := []byte("table-proposal-")
+	proposals := make([]*Proposal, 0)
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		value, err := iterator.Item().Value()
+		if err != nil {
+			return nil, err
+		}
+		proposal := &Proposal{}
+		err = json.Unmarshal(value, proposal)
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, proposal)
+	}
+	SortProposals(proposals)
+	return proposals, nil
+}
+
+// ToParametersAt - Values in use at the time (milliseconds), those of the genesis changed by the approved proposals
+// activated by then
+func ToParametersAt(txn *badger.Txn, time int64) (Parameters, error) {
+	genesis, err := ToGenesis(txn)
+	if err != nil {
+		return Parameters{}, err
+	}
+	parameters := genesis.ToParameters()
+	proposals, err := ToProposals(txn)
+	if err != nil {
+		return Parameters{}, err
+	}
+	for _, proposal := range proposals {
+		if proposal.ActivationTime > time {
+			break
+		}
+		if proposal.Status == ProposalApproved {
+			parameters.Apply(proposal)
+		}
+	}
+	return parameters, nil
+}
+
+// SortProposals - By activation, proposals activating together are applied in hash order
+func SortProposals(proposals []*Proposal) {
+	sort.SliceStable(proposals, func(i, j int) bool {
+		if proposals[i].ActivationTime != proposals[j].ActivationTime {
+			return proposals[i].ActivationTime < proposals[j].ActivationTime
+		}
+		return proposals[i].Hash < proposals[j].Hash
+	})
+}
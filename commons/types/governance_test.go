@@ -0,0 +1,146 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+//TestParametersSet
+func TestParametersSet(t *testing.T) {
+	parameters := NewParameters()
+	if parameters.TxReceiveTimeout != TxReceiveTimeout || parameters.GossipTimeout != GossipTimeout || parameters.UnbondingPeriod != int64(DefaultUnbondingPeriod/time.Millisecond) {
+		t.Errorf("parameters do not default to the constants: %v", parameters)
+	}
+	if err := parameters.Set(ParameterHertzPrice, 3); err != nil {
+		t.Fatal(err)
+	}
+	if parameters.HertzPrice != 3 {
+		t.Errorf("hertz price not set [hertzPrice=%d]", parameters.HertzPrice)
+	}
+	if parameters.Set(ParameterTxReceiveTimeout, 60000) == nil {
+		t.Error("set a receive timeout longer than the bound")
+	}
+	if parameters.Set("delegateCount", 1) == nil {
+		t.Error("set an unknown parameter")
+	}
+	if parameters.Set(ParameterTxFutureLimit, int64(TxFutureLimit/time.Millisecond)+1) == nil {
+		t.Error("set a future limit longer than transactions are parsed with")
+	}
+	if parameters.Set(ParameterGossipCacheTtl, 1000) == nil {
+		t.Error("set a gossip cache ttl shorter than the bound")
+	}
+}
+
+//TestParametersApply
+func TestParametersApply(t *testing.T) {
+	parameters := NewParameters()
+	parameters.Delegates = []string{"b"}
+	if err := parameters.Apply(&Proposal{Parameter: ParameterAddDelegate, Delegate: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := parameters.Apply(&Proposal{Parameter: ParameterGrpcTimeout, Value: 5000}); err != nil {
+		t.Fatal(err)
+	}
+	if len(parameters.Delegates) != 2 || parameters.Delegates[0] != "a" || parameters.GrpcTimeout != 5000 {
+		t.Errorf("proposals not applied: %v", parameters)
+	}
+	if err := parameters.Apply(&Proposal{Parameter: ParameterRemoveDelegate, Delegate: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if parameters.Apply(&Proposal{Parameter: ParameterRemoveDelegate, Delegate: "a"}) == nil {
+		t.Error("removed the last delegate")
+	}
+	if len(parameters.Delegates) != 1 || parameters.Delegates[0] != "a" {
+		t.Errorf("wrong delegates: %v", parameters.Delegates)
+	}
+}
+
+//TestProposalTally
+func TestProposalTally(t *testing.T) {
+	now := time.Now()
+	proposal := &Proposal{Hash: "b", TotalStake: 1000, Yes: 500, Status: ProposalVoting}
+	proposal.Tally(now)
+	if proposal.Status != ProposalRejected {
+		t.Errorf("approved with half of the stake [status=%s]", proposal.Status)
+	}
+	proposal = &Proposal{Hash: "b", TotalStake: 1000, Yes: 501, No: 400, Status: ProposalVoting}
+	proposal.Tally(now)
+	if proposal.Status != ProposalApproved {
+		t.Errorf("rejected with a majority of the stake [status=%s]", proposal.Status)
+	}
+
+	proposals := []*Proposal{
+		{Hash: "c", ActivationTime: 2000},
+		{Hash: "b", ActivationTime: 1000},
+		{Hash: "a", ActivationTime: 2000},
+	}
+	SortProposals(proposals)
+	if proposals[0].Hash != "b" || proposals[1].Hash != "a" || proposals[2].Hash != "c" {
+		t.Errorf("proposals not sorted by activation then hash: %v %v %v", proposals[0].Hash, proposals[1].Hash, proposals[2].Hash)
+	}
+}
+
+//TestToProposals
+func TestToProposals(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	(&Proposal{Hash: "b", Parameter: ParameterHertzPrice, ActivationTime: 2000}).Persist(txn)
+	(&Proposal{Hash: "a", Parameter: ParameterHertzPrice, ActivationTime: 3000}).Persist(txn)
+
+	proposal, err := ToProposal(txn, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proposal.Parameter != ParameterHertzPrice || proposal.Closes() != 2000-int64(ProposalTallyDelay/time.Millisecond) {
+		t.Errorf("invalid proposal: %v", proposal)
+	}
+	proposals, err := ToProposals(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposals) != 2 || proposals[0].Hash != "b" {
+		t.Errorf("proposals not sorted by activation: %v", proposals)
+	}
+}
+
+//TestToBondedAt
+func TestToBondedAt(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	account := Account{Address: "staker"}
+	account.Bond("delegate", 100)
+	account.PersistBonded(txn, 1000)
+	account.Bond("delegate", 50)
+	account.PersistBonded(txn, 3000)
+
+	for time, expected := range map[int64]int64{999: 0, 1000: 100, 2999: 100, 3000: 150, 5000: 150} {
+		bonded, err := ToBondedAt(txn, "staker", time)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bonded != expected {
+			t.Errorf("wrong stake [time=%d, bonded=%d, expected=%d]", time, bonded, expected)
+		}
+	}
+	if bonded, _ := ToBondedAt(txn, "other", 5000); bonded != 0 {
+		t.Errorf("stake of another account [bonded=%d]", bonded)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"time"
+)
+
+// HealthCheck - One check of a node, latency is in milliseconds
+type HealthCheck struct {
+	Time    time.Time `json:"time"`
+	Ok      bool      `json:"ok"`
+	Latency int64     `json:"latency,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// NodeHealth - What the checks of a node found, the most recent check is last in the history
+type NodeHealth struct {
+	Node                *Node         `json:"node"`
+	Available           bool          `json:"available"`
+	Evicted             bool          `json:"evicted"`
+	Latency             int64         `json:"latency"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"`
+	LastSeen            time.Time     `json:"lastSeen,omitempty"`
+	History             []HealthCheck `json:"history"`
+}
+
+// NewNodeHealth - A node is available until its checks fail
+func NewNodeHealth(node *Node) *NodeHealth {
+	return &NodeHealth{Node: node, Available: true, History: make([]HealthCheck, 0)}
+}
+
+// Record - Adds the check, returns true when the node became available or unavailable
+func (this *NodeHealth) Record(check HealthCheck) bool {
+	this.History = append(this.History, check)
+	if len(this.History) > HealthHistorySize {
+		this.History = this.History[len(this.History)-HealthHistorySize:]
+	}
+	if check.Ok {
+		this.Latency = check.Latency
+		this.ConsecutiveFailures = 0
+		this.LastSeen = check.Time
+		if !this.Available {
+			this.Available = true
+			return true
+		}
+		return false
+	}
+	this.ConsecutiveFailures++
+	if this.Available && this.ConsecutiveFailures >= HealthFailureThreshold {
+		this.Available = false
+		return true
+	}
+	return false
+}
+
+// ShouldEvict - Failed enough checks in a row to be dropped
+func (this *NodeHealth) ShouldEvict() bool {
+	return !this.Evicted && this.ConsecutiveFailures >= HealthEvictionThreshold
+}
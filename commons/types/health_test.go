@@ -0,0 +1,74 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+//TestNodeHealthAvailability
+func TestNodeHealthAvailability(t *testing.T) {
+	health := NewNodeHealth(&Node{Address: testAddress})
+	start := time.Unix(0, 0)
+	for i := 1; i < HealthFailureThreshold; i++ {
+		if health.Record(HealthCheck{Time: start, Ok: false, Error: "unknown peer"}) {
+			t.Fatalf("expected no transition before %d failures [failures=%d]", HealthFailureThreshold, i)
+		}
+	}
+	if !health.Record(HealthCheck{Time: start, Ok: false}) || health.Available {
+		t.Fatal("expected the node to become unavailable")
+	}
+	seen := start.Add(time.Second)
+	if !health.Record(HealthCheck{Time: seen, Ok: true, Latency: 12}) || !health.Available {
+		t.Fatal("expected the node to become available")
+	}
+	if health.ConsecutiveFailures != 0 || health.Latency != 12 || !health.LastSeen.Equal(seen) {
+		t.Fatalf("expected the check to be recorded [health=%+v]", health)
+	}
+	if health.Record(HealthCheck{Time: seen, Ok: true}) {
+		t.Fatal("expected no transition")
+	}
+}
+
+//TestNodeHealthEviction
+func TestNodeHealthEviction(t *testing.T) {
+	health := NewNodeHealth(&Node{Address: testAddress})
+	for i := 0; i < HealthEvictionThreshold-1; i++ {
+		health.Record(HealthCheck{Ok: false})
+	}
+	if health.ShouldEvict() {
+		t.Fatal("expected no eviction")
+	}
+	health.Record(HealthCheck{Ok: false})
+	if !health.ShouldEvict() {
+		t.Fatal("expected eviction")
+	}
+	health.Evicted = true
+	if health.ShouldEvict() {
+		t.Fatal("expected an evicted node not to be evicted again")
+	}
+	if len(health.History) != HealthEvictionThreshold {
+		t.Fatalf("expected %d checks [history=%d]", HealthEvictionThreshold, len(health.History))
+	}
+	for i := 0; i < HealthHistorySize; i++ {
+		health.Record(HealthCheck{Ok: true})
+	}
+	if len(health.History) != HealthHistorySize || health.ConsecutiveFailures != 0 {
+		t.Fatalf("expected the history to be capped at %d [history=%d]", HealthHistorySize, len(health.History))
+	}
+}
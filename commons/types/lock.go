@@ -0,0 +1,77 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/badger"
+)
+
+// Lock - Tokens credited to an account that cannot be spent before Until
+type Lock struct {
+	TransactionHash string `json:"transactionHash"`
+	Value           int64  `json:"value"`
+	Until           int64  `json:"until"` // Milliseconds
+}
+
+// Key - Locks are indexed by time, the release stops at the first lock that has not ended
+func (this Lock) Key(address string) string {
+	return fmt.Sprintf("key-lock-%020d-%s-%s", this.Until, address, this.TransactionHash)
+}
+
+// Persist - Indexes the lock of the account
+func (this Lock) Persist(txn *badger.Txn, address string) error {
+	return txn.Set([]byte(this.Key(address)), []byte(address))
+}
+
+// Unset
+func (this Lock) Unset(txn *badger.Txn, address string) error {
+	return txn.Delete([]byte(this.Key(address)))
+}
+
+// ToAddressesWithLocksEndedBy - Addresses of the accounts holding a lock that ended by the time (milliseconds), and when
+// the next lock ends (zero when there is none)
+func ToAddressesWithLocksEndedBy(txn *badger.Txn, time int64) ([]string, int64, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+	prefix := []byte("key-lock-")
+	addresses := make([]string, 0)
+	seen := make(map[string]bool)
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		parts := strings.Split(string(iterator.Item().Key()), "-")
+		if len(parts) != 5 {
+			continue
+		}
+		until, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		if until > time {
+			return addresses, until, nil
+		}
+		if !seen[parts[3]] {
+			seen[parts[3]] = true
+			addresses = append(addresses, parts[3])
+		}
+	}
+	return addresses, 0, nil
+}
@@ -0,0 +1,38 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+// IsNetwork - Was it signed for the network? A blank network id is the default network, signed before network ids
+func IsNetwork(signedFor, networkId string) bool {
+	return toNetworkId(signedFor) == toNetworkId(networkId)
+}
+
+// toNetworkId
+func toNetworkId(networkId string) string {
+	if networkId == "" {
+		return DefaultChainId
+	}
+	return networkId
+}
+
+// networkIdBytes - Hashed for any but the default network, so the hashes signed before network ids are unchanged
+func networkIdBytes(networkId string) []byte {
+	if toNetworkId(networkId) == DefaultChainId {
+		return nil
+	}
+	return []byte(networkId)
+}
@@ -0,0 +1,112 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/patrickmn/go-cache"
+)
+
+//TestIsNetwork
+func TestIsNetwork(t *testing.T) {
+	if !IsNetwork("", DefaultChainId) || !IsNetwork(DefaultChainId, "") {
+		t.Error("a blank network id is not the default network")
+	}
+	if IsNetwork("", "testnet") || IsNetwork("testnet", DefaultChainId) {
+		t.Error("another network matched")
+	}
+}
+
+//TestTransactionNetworkId
+func TestTransactionNetworkId(t *testing.T) {
+	d, _ := time.Parse(time.RFC3339, "2018-07-09T15:04:05Z")
+	tx, err := NewTransferTokensTransaction(testPrivateKey, testAddress, "d70613f93152c84050e7826c4e2b0cc02c1c3b99", 10, 0, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := tx.Hash
+
+	// The default network, same hash as before network ids existed.
+	if err := tx.SetNetworkId(DefaultChainId, testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash != hash {
+		t.Error("a transaction for the default network changed hash")
+	}
+
+	// Another network is covered by the hash.
+	if err := tx.SetNetworkId("testnet", testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash == hash {
+		t.Error("network id is not covered by the hash")
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify transaction with a network id", err)
+	}
+	testTx, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testTx.NetworkId != "testnet" {
+		t.Errorf("invalid network id after JSON [networkId=%s]", testTx.NetworkId)
+	}
+
+	// Replayed with the network id stripped.
+	testTx.NetworkId = ""
+	if testTx.Verify() == nil {
+		t.Error("verified a transaction with its network id stripped")
+	}
+}
+
+//TestRumorNetworkId
+func TestRumorNetworkId(t *testing.T) {
+	rumor := NewRumor(testPrivateKey, testAddress, "9c242afd4f2dcaedcfb0cff2bb9c38b5811ed29c249f5b49f7759642a473d5fb", "testnet", utils.ToMilliSeconds(time.Now()))
+	if !rumor.Verify("testnet") {
+		t.Fatal("cannot verify rumor with a network id")
+	}
+	if rumor.Verify(DefaultChainId) {
+		t.Error("verified a rumor of another network")
+	}
+	rumor.NetworkId = DefaultChainId
+	if rumor.Verify(DefaultChainId) {
+		t.Error("verified a rumor with a changed network id")
+	}
+}
+
+//TestAuthenticationNetworkId
+func TestAuthenticationNetworkId(t *testing.T) {
+	account := &Account{Address: testAddress, PrivateKey: testPrivateKey}
+	authentication, err := NewAuthenticationWithAccount(account, "testnet", utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authentication.Verify(cache.New(CacheTTL, CacheTTL), testAddress, DefaultChainId, utils.ToMilliSeconds(time.Now())) == nil {
+		t.Error("verified an authentication of another network")
+	}
+	authentication.NetworkId = DefaultChainId
+	if authentication.Verify(cache.New(CacheTTL, CacheTTL), testAddress, DefaultChainId, utils.ToMilliSeconds(time.Now())) == nil {
+		t.Error("verified an authentication with a changed network id")
+	}
+	authentication.NetworkId = "testnet"
+	if err := authentication.Verify(cache.New(CacheTTL, CacheTTL), testAddress, "testnet", utils.ToMilliSeconds(time.Now())); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -32,14 +32,23 @@ type Node struct {
 	GrpcEndpoint *Endpoint `json:"grpcEndpoint"`
 	HttpEndpoint *Endpoint `json:"httpEndpoint"`
 	Type         string    `json:"type,omitempty"`
+	GenesisHash  string    `json:"genesisHash,omitempty"`
+	NetworkId    string    `json:"networkId,omitempty"`
+	Registered   int64     `json:"registered,omitempty"` // Milliseconds, when a seed last registered the node
 	Status       string    `json:"status,omitempty"`
 	StatusTime   time.Time `json:"statusTime,omitempty"`
 }
 
-func (this Node) IsAvailable() bool {
+// IsExpired - A node no seed registered for PersistedNodeTtl is not trusted to be at its endpoints anymore
+func (this Node) IsExpired(now time.Time) bool {
+	return this.Registered < utils.ToMilliSeconds(now.Add(-PersistedNodeTtl))
+}
+
+// IsAvailable - An unavailable node is tried again once UnavailableNodeTimeout seconds passed by now
+func (this Node) IsAvailable(now time.Time) bool {
 	result := true
 	if this.Status == StatusNodeUnavailable {
-		delta := time.Now().Sub(this.StatusTime)
+		delta := now.Sub(this.StatusTime)
 		if delta.Seconds() < UnavailableNodeTimeout {
 			result = false
 		}
@@ -19,6 +19,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/dgraph-io/badger"
@@ -33,7 +34,26 @@ type Receipt struct {
 	HumanReadableStatus string
 	ContractAddress     string
 	ContractResult      []interface{}
+	HertzUsed           int64
+	Fee                 int64    // Charged to the from account
+	Rewards             []Reward // Fee shares paid to the delegates
 	Created             time.Time
+	Updated             time.Time
+	Timeline            []ReceiptEvent
+	indexKey            string // Status key the receipt was last persisted under
+}
+
+// Reward - Share of a transaction's fee paid to a delegate
+type Reward struct {
+	Delegate string `json:"delegate"`
+	Value    int64  `json:"value"`
+}
+
+// ReceiptEvent - A step of the transaction's lifecycle and the delegate it happened on
+type ReceiptEvent struct {
+	Event    string    `json:"event"`
+	Time     time.Time `json:"time"`
+	Delegate string    `json:"delegate"`
 }
 
 // Key
@@ -41,6 +61,33 @@ func (this Receipt) Key() string {
 	return fmt.Sprintf("table-receipt-%s", this.TransactionHash)
 }
 
+// StatusKey - Ordered most recently updated first
+func (this Receipt) StatusKey() string {
+	age := int64(math.MaxInt64) - this.Updated.UnixNano()
+	if age < 0 {
+		age = 0
+	}
+	return fmt.Sprintf("key-receipt-status-%s-%020d-%s", this.Status, age, this.TransactionHash)
+}
+
+// AddEvent - Records the lifecycle event, only its first occurrence is kept
+func (this *Receipt) AddEvent(event string, delegate string, time time.Time) {
+	if this.HasEvent(event) {
+		return
+	}
+	this.Timeline = append(this.Timeline, ReceiptEvent{Event: event, Time: time, Delegate: delegate})
+}
+
+// HasEvent
+func (this Receipt) HasEvent(event string) bool {
+	for _, receiptEvent := range this.Timeline {
+		if receiptEvent.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
 // Cache
 func (this *Receipt) Cache(cache *cache.Cache, time_optional ...time.Duration) {
 	TTL := ReceiptCacheTTL
@@ -50,18 +97,31 @@ func (this *Receipt) Cache(cache *cache.Cache, time_optional ...time.Duration) {
 	cache.Set(this.Key(), this, TTL)
 }
 
-// Persist
+// Persist - Receipts are kept permanently and indexed by status, the index entry the receipt was loaded
+// or last persisted with is deleted without being read back (that would make the receipt a conflict of the executing transaction)
 func (this *Receipt) Persist(txn *badger.Txn) error {
 	err := txn.Set([]byte(this.Key()), []byte(this.String()))
 	if err != nil {
 		return err
 	}
+	statusKey := this.StatusKey()
+	if this.indexKey != "" && this.indexKey != statusKey {
+		err = txn.Delete([]byte(this.indexKey))
+		if err != nil {
+			return err
+		}
+	}
+	err = txn.Set([]byte(statusKey), []byte(this.Key()))
+	if err != nil {
+		return err
+	}
+	this.indexKey = statusKey
 	return nil
 }
 
 // Set
-func (this *Receipt) Set(txn *badger.Txn, cache *cache.Cache) error {
-	this.Cache(cache)
+func (this *Receipt) Set(txn *badger.Txn, cache *cache.Cache, time_optional ...time.Duration) error {
+	this.Cache(cache, time_optional...)
 
 	err := this.Persist(txn)
 	if err != nil {
@@ -77,6 +137,17 @@ func (this *Receipt) Unset(txn *badger.Txn, cache *cache.Cache) error {
 	if err != nil {
 		return err
 	}
+	err = txn.Delete([]byte(this.StatusKey()))
+	if err != nil {
+		return err
+	}
+	if this.indexKey != "" && this.indexKey != this.StatusKey() {
+		err = txn.Delete([]byte(this.indexKey))
+		if err != nil {
+			return err
+		}
+	}
+	this.indexKey = ""
 	return nil
 }
 
@@ -103,6 +174,22 @@ func (this *Receipt) UnmarshalJSON(bytes []byte) error {
 		var contractResult = jsonMap["contractResult"]
 		this.ContractResult = contractResult.([]interface{})
 	}
+	if jsonMap["hertzUsed"] != nil {
+		this.HertzUsed = int64(jsonMap["hertzUsed"].(float64))
+	}
+	if jsonMap["fee"] != nil {
+		this.Fee = int64(jsonMap["fee"].(float64))
+	}
+	if jsonMap["rewards"] != nil {
+		bytes, err := json.Marshal(jsonMap["rewards"])
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(bytes, &this.Rewards)
+		if err != nil {
+			return err
+		}
+	}
 	if jsonMap["created"] != nil {
 		created, err := time.Parse(time.RFC3339, jsonMap["created"].(string))
 		if err != nil {
@@ -110,25 +197,52 @@ func (this *Receipt) UnmarshalJSON(bytes []byte) error {
 		}
 		this.Created = created
 	}
+	if jsonMap["updated"] != nil {
+		updated, err := time.Parse(time.RFC3339, jsonMap["updated"].(string))
+		if err != nil {
+			return err
+		}
+		this.Updated = updated
+	}
+	if jsonMap["timeline"] != nil {
+		bytes, err := json.Marshal(jsonMap["timeline"])
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(bytes, &this.Timeline)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // MarshalJSON
 func (this Receipt) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		TransactionHash     string        `json:"transactionHash"`
-		Status              string        `json:"status"`
-		HumanReadableStatus string        `json:"humanReadableStatus,omitempty"`
-		ContractAddress     string        `json:"contractAddress,omitempty"`
-		ContractResult      []interface{} `json:"contractResult,omitempty"`
-		Created             time.Time     `json:"created"`
+		TransactionHash     string         `json:"transactionHash"`
+		Status              string         `json:"status"`
+		HumanReadableStatus string         `json:"humanReadableStatus,omitempty"`
+		ContractAddress     string         `json:"contractAddress,omitempty"`
+		ContractResult      []interface{}  `json:"contractResult,omitempty"`
+		HertzUsed           int64          `json:"hertzUsed,omitempty"`
+		Fee                 int64          `json:"fee,omitempty"`
+		Rewards             []Reward       `json:"rewards,omitempty"`
+		Created             time.Time      `json:"created"`
+		Updated             time.Time      `json:"updated"`
+		Timeline            []ReceiptEvent `json:"timeline,omitempty"`
 	}{
 		TransactionHash:     this.TransactionHash,
 		Status:              this.Status,
 		HumanReadableStatus: this.HumanReadableStatus,
 		ContractAddress:     this.ContractAddress,
 		ContractResult:      this.ContractResult,
+		HertzUsed:           this.HertzUsed,
+		Fee:                 this.Fee,
+		Rewards:             this.Rewards,
 		Created:             this.Created,
+		Updated:             this.Updated,
+		Timeline:            this.Timeline,
 	})
 }
 
@@ -151,30 +265,28 @@ func (this Receipt) ToPrettyJson() string {
 	return string(bytes)
 }
 
-// SetInternalErrorWithNewTransaction
-func (this *Receipt) SetInternalErrorWithNewTransaction(db *badger.DB, err error) {
-	txn := db.NewTransaction(true)
-	defer txn.Discard()
+// SetInternalErrorWithNewTransaction - Updated at the time
+func (this *Receipt) SetInternalErrorWithNewTransaction(db *badger.DB, err error, updated time.Time) {
 	this.Status = StatusInternalError
 	this.HumanReadableStatus = err.Error()
-	err = txn.SetWithTTL([]byte(this.Key()), []byte(this.String()), ReceiptCacheTTL)
-	if err != nil {
-		utils.Error(err)
-	}
-	err = txn.Commit(nil)
-	if err != nil {
-		utils.Error(err)
-	}
+	this.persistWithNewTransaction(db, updated)
+}
+
+// SetStatusWithNewTransaction - Updated at the time
+func (this *Receipt) SetStatusWithNewTransaction(db *badger.DB, status string, updated time.Time) {
+	this.Status = status
+	this.persistWithNewTransaction(db, updated)
 }
 
-// SetStatusWithNewTransaction
-func (this *Receipt) SetStatusWithNewTransaction(db *badger.DB, status string) {
+// persistWithNewTransaction
+func (this *Receipt) persistWithNewTransaction(db *badger.DB, updated time.Time) {
 	txn := db.NewTransaction(true)
 	defer txn.Discard()
-	this.Status = status
-	err := txn.SetWithTTL([]byte(this.Key()), []byte(this.String()), ReceiptCacheTTL)
+	this.Updated = updated
+	err := this.Persist(txn)
 	if err != nil {
 		utils.Error(err)
+		return
 	}
 	err = txn.Commit(nil)
 	if err != nil {
@@ -182,19 +294,19 @@ func (this *Receipt) SetStatusWithNewTransaction(db *badger.DB, status string) {
 	}
 }
 
-// NewReceipt
-func NewReceipt(transactionHash string) *Receipt {
-	return &Receipt{TransactionHash: transactionHash, Status: StatusPending, Created: time.Now()}
+// NewReceipt - Pending receipt created at the time
+func NewReceipt(transactionHash string, created time.Time) *Receipt {
+	return &Receipt{TransactionHash: transactionHash, Status: StatusPending, Created: created, Updated: created}
 }
 
-// NewReceiptWithStatus
-func NewReceiptWithStatus(transactionHash string, status string, humanReadableStatus string) *Receipt {
-	return &Receipt{TransactionHash: transactionHash, Status: status, HumanReadableStatus: humanReadableStatus, Created: time.Now()}
+// NewReceiptWithStatus - Receipt with the status created at the time
+func NewReceiptWithStatus(transactionHash string, status string, humanReadableStatus string, created time.Time) *Receipt {
+	return &Receipt{TransactionHash: transactionHash, Status: status, HumanReadableStatus: humanReadableStatus, Created: created, Updated: created}
 }
 
-// NewReceiptWithError
-func NewReceiptWithError(transactionHash string, err error) *Receipt {
-	return &Receipt{TransactionHash: transactionHash, Status: StatusInternalError, HumanReadableStatus: err.Error(), Created: time.Now()}
+// NewReceiptWithError - Internal error receipt created at the time
+func NewReceiptWithError(transactionHash string, err error, created time.Time) *Receipt {
+	return &Receipt{TransactionHash: transactionHash, Status: StatusInternalError, HumanReadableStatus: err.Error(), Created: created, Updated: created}
 }
 
 // ToReceiptFromJson
@@ -217,6 +329,44 @@ func ToReceiptFromCache(cache *cache.Cache, transactionHash string) (*Receipt, e
 	return receipt, nil
 }
 
+// ToReceiptsByStatus - Most recently updated first
+func ToReceiptsByStatus(txn *badger.Txn, status string, page, pageSize int) ([]*Receipt, *PagingResult, error) {
+	if pageSize <= 0 || pageSize > 100 {
+		return nil, nil, ErrInvalidRequestPageSize
+	}
+	if page <= 0 {
+		return nil, nil, ErrInvalidRequestPage
+	}
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+	prefix := []byte(fmt.Sprintf("key-receipt-status-%s-", status))
+	first := (page - 1) * pageSize
+	receipts := make([]*Receipt, 0)
+	total := 0
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		total++
+		if total <= first || len(receipts) >= pageSize {
+			continue
+		}
+		value, err := iterator.Item().Value()
+		if err != nil {
+			return nil, nil, err
+		}
+		receipt, err := ToReceiptFromKey(txn, value)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("could not find receipt key: %s", value), err)
+			continue
+		}
+		if receipt.Status != status {
+			continue
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, &PagingResult{total, ""}, nil
+}
+
 // ToReceiptFromTransactionKey
 func ToReceiptFromKey(txn *badger.Txn, key []byte) (*Receipt, error) {
 	item, err := txn.Get(key)
@@ -231,5 +381,6 @@ func ToReceiptFromKey(txn *badger.Txn, key []byte) (*Receipt, error) {
 	if err != nil {
 		return nil, err
 	}
+	receipt.indexKey = receipt.StatusKey()
 	return receipt, err
 }
@@ -30,7 +30,7 @@ var testReceiptByte = []byte("{\"transactionHash\":\"test\",\"status\":\"Pending
 
 //TestReceiptCache
 func TestReceiptCache(t *testing.T) {
-	receipt := NewReceipt("test")
+	receipt := NewReceipt("test", time.Now())
 	receipt.Cache(c, time.Second * 5)
 	testReceipt, err := ToReceiptFromCache(c, receipt.TransactionHash)
 	if err != nil {
@@ -43,7 +43,7 @@ func TestReceiptCache(t *testing.T) {
 
 //TestNewReceipt
 func TestNewReceipt(t *testing.T) {
-	receipt := NewReceipt("test")
+	receipt := NewReceipt("test", time.Now())
 	if receipt.Status != "Pending" {
 		t.Errorf("NewReceipt returning invalid %s value: %s", "Status", receipt.Status)
 	}
@@ -58,30 +58,32 @@ func TestNewReceipt(t *testing.T) {
 
 //TestNewReceiptWithStatus
 func TestNewReceiptWithStatus(t *testing.T) {
-	receipt := NewReceiptWithStatus("test", "Pending", "Pending")
+	created := time.Unix(1531148645, 0)
+	receipt := NewReceiptWithStatus("test", "Pending", "Pending", created)
 	if receipt.Status != "Pending" {
 		t.Errorf("NewReceiptWithStatus returning invalid %s value: %s", "Status", receipt.Status)
 	}
 	if receipt.HumanReadableStatus != "Pending" {
 		t.Errorf("NewReceiptWithStatus returning invalid %s value: %s", "HumanReadableStatus", receipt.HumanReadableStatus)
 	}
-	if time.Time.IsZero(receipt.Created) {
-		t.Error("NewReceiptWithStatus receipt.Created is empty")
+	if !receipt.Created.Equal(created) || !receipt.Updated.Equal(created) {
+		t.Errorf("NewReceiptWithStatus not created at the time given: %v", receipt.Created)
 	}
 }
 
 //TestNewReceiptWithError
 func TestNewReceiptWithError(t *testing.T) {
 	err := errors.New("test error")
-	receipt := NewReceiptWithError("test", err)
+	created := time.Unix(1531148645, 0)
+	receipt := NewReceiptWithError("test", err, created)
 	if receipt.Status != "InternalError" {
 		t.Errorf("NewReceiptWithStatus returning invalid %s value: %s", "Status", receipt.Status)
 	}
 	if receipt.HumanReadableStatus != "test error" {
 		t.Errorf("NewReceiptWithStatus returning invalid %s value: %s", "HumanReadableStatus", receipt.HumanReadableStatus)
 	}
-	if time.Time.IsZero(receipt.Created) {
-		t.Error("NewReceiptWithStatus receipt.Created is empty")
+	if !receipt.Created.Equal(created) || !receipt.Updated.Equal(created) {
+		t.Errorf("NewReceiptWithError not created at the time given: %v", receipt.Created)
 	}
 }
 
@@ -115,6 +117,32 @@ func TestReceiptMarshalJSON(t *testing.T) {
 	}
 }
 
+//TestReceiptTimeline
+func TestReceiptTimeline(t *testing.T) {
+	receipt := NewReceipt("timeline", time.Now())
+	created := receipt.Created.UTC()
+	receipt.AddEvent(EventReceived, "delegate-1", created)
+	receipt.AddEvent(EventFirstRumor, "delegate-2", created.Add(time.Millisecond))
+	receipt.AddEvent(EventReceived, "delegate-3", created.Add(time.Second))
+	if len(receipt.Timeline) != 2 {
+		t.Fatalf("receipt.AddEvent kept a repeated event: %v", receipt.Timeline)
+	}
+	if receipt.Timeline[0].Delegate != "delegate-1" {
+		t.Errorf("receipt.AddEvent replaced the first occurrence: %v", receipt.Timeline[0])
+	}
+
+	testReceipt, err := ToReceiptFromJson([]byte(receipt.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testReceipt.Timeline, receipt.Timeline) == false {
+		t.Errorf("receipt timeline not equal after JSON.\nGot: %v\nExpected: %v", testReceipt.Timeline, receipt.Timeline)
+	}
+	if !testReceipt.HasEvent(EventFirstRumor) || testReceipt.HasEvent(EventExecuted) {
+		t.Error("receipt.HasEvent returning invalid value")
+	}
+}
+
 //TestToReceiptFromKey
 func TestToReceiptFromKey(t *testing.T) {
 	defer destruct()
@@ -162,14 +190,112 @@ func TestReceiptSet(t *testing.T) {
 
 //TestReceiptSetInternalErrorWithNewTransaction
 func TestReceiptSetInternalErrorWithNewTransaction(t *testing.T) {
-	// TODO: receipt.SetInternalErrorWithNewTransaction()
-	t.Skip("Need a Badger DB mock")
+	defer destruct()
+	receipt := NewReceipt("internal", time.Now())
+	receipt.SetInternalErrorWithNewTransaction(db, errors.New("test error"), time.Now())
+
+	txn := db.NewTransaction(false)
+	defer txn.Discard()
+	testReceipt, err := ToReceiptFromKey(txn, []byte(receipt.Key()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testReceipt.Status != StatusInternalError || testReceipt.HumanReadableStatus != "test error" {
+		t.Errorf("receipt not persisted with its status [status=%s, humanReadableStatus=%s]", testReceipt.Status, testReceipt.HumanReadableStatus)
+	}
+	item, err := txn.Get([]byte(receipt.StatusKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.ExpiresAt() != 0 {
+		t.Error("receipt persisted with a TTL")
+	}
 }
 
 //TestReceiptSetStatusWithNewTransaction
 func TestReceiptSetStatusWithNewTransaction(t *testing.T) {
-	// TODO: receipt.SetStatusWithNewTransaction()
-	t.Skip("Need a Badger DB mock")
+	defer destruct()
+	created := time.Unix(1531148645, 0)
+	updated := created.Add(time.Second)
+	receipt := NewReceipt("status", created)
+	receipt.SetStatusWithNewTransaction(db, StatusInsufficientTokens, updated)
+
+	txn := db.NewTransaction(false)
+	defer txn.Discard()
+	item, err := txn.Get([]byte(receipt.Key()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.ExpiresAt() != 0 {
+		t.Error("receipt persisted with a TTL")
+	}
+	testReceipt, err := ToReceiptFromKey(txn, []byte(receipt.Key()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testReceipt.Status != StatusInsufficientTokens {
+		t.Errorf("receipt not persisted with its status [status=%s]", testReceipt.Status)
+	}
+	if !testReceipt.Updated.Equal(updated) {
+		t.Errorf("receipt not updated at the time given [updated=%v]", testReceipt.Updated)
+	}
+}
+
+//TestToReceiptsByStatus
+func TestToReceiptsByStatus(t *testing.T) {
+	defer destruct()
+	for i, hash := range []string{"first", "second", "third"} {
+		txn := db.NewTransaction(true)
+		receipt := NewReceipt(hash, time.Now())
+		receipt.Persist(txn)
+		err := txn.Commit(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Updated as loaded, its pending index entry is deleted.
+		txn = db.NewTransaction(true)
+		receipt, err = ToReceiptFromKey(txn, []byte(receipt.Key()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		receipt.Status = StatusOk
+		receipt.Updated = receipt.Created.Add(time.Duration(i) * time.Second)
+		receipt.Persist(txn)
+		err = txn.Commit(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	txn := db.NewTransaction(false)
+	defer txn.Discard()
+	receipts, paging, err := ToReceiptsByStatus(txn, StatusOk, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paging.Count != 3 {
+		t.Errorf("ToReceiptsByStatus returning invalid count: %d", paging.Count)
+	}
+	if len(receipts) != 2 || receipts[0].TransactionHash != "third" || receipts[1].TransactionHash != "second" {
+		t.Errorf("ToReceiptsByStatus returning invalid page: %v", receipts)
+	}
+	receipts, _, err = ToReceiptsByStatus(txn, StatusOk, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(receipts) != 1 || receipts[0].TransactionHash != "first" {
+		t.Errorf("ToReceiptsByStatus returning invalid page: %v", receipts)
+	}
+
+	// No longer pending.
+	receipts, paging, err = ToReceiptsByStatus(txn, StatusPending, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(receipts) != 0 || paging.Count != 0 {
+		t.Errorf("ToReceiptsByStatus returning receipts of a previous status: %v", receipts)
+	}
 }
 
 //testReceiptStruct
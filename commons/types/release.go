@@ -0,0 +1,322 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+)
+
+// Manifest - A release signed by a seed, the software it describes is found by its hash
+type Manifest struct {
+	Version        string   `json:"version"`
+	Platform       string   `json:"platform"` // GOOS/GOARCH the software runs on
+	FileName       string   `json:"fileName"`
+	SoftwareHash   string   `json:"softwareHash"`
+	MinimumVersion string   `json:"minimumVersion,omitempty"` // Oldest version that may update to this release
+	Size           int64    `json:"size"`                     // Bytes of software
+	ChunkSize      int64    `json:"chunkSize"`
+	ChunkHashes    []string `json:"chunkHashes"` // So a chunk from any node can be verified on its own
+	Time           int64    `json:"time"`        // Milliseconds
+	Hash           string   `json:"hash"`
+	Signature      string   `json:"signature"`
+}
+
+// UpdateAck - A delegate's acknowledgement of a release, version is the one it runs. Signed by the delegate so nobody
+// else can report its status.
+type UpdateAck struct {
+	ManifestHash string `json:"manifestHash"`
+	Address      string `json:"address"`
+	Status       string `json:"status"`
+	Version      string `json:"version"`
+	Error        string `json:"error,omitempty"`
+	Time         int64  `json:"time"` // Milliseconds
+	Hash         string `json:"hash,omitempty"`
+	Signature    string `json:"signature,omitempty"`
+}
+
+// Transfer - Progress of downloading a release's software chunk by chunk
+type Transfer struct {
+	SoftwareHash string   `json:"softwareHash"`
+	FileName     string   `json:"fileName"`
+	Chunks       int      `json:"chunks"`
+	Received     int      `json:"received"`    // Chunks verified and saved
+	ResumedFrom  int      `json:"resumedFrom"` // Chunks already saved when the download started
+	Sources      []string `json:"sources"`     // Nodes chunks came from, in order
+	Failures     int      `json:"failures"`    // Sources that failed before sending every chunk
+	Complete     bool     `json:"complete"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// Rollout - A release rolled out to a growing percentage of the delegates, a stage starts once every delegate of the
+// stage before acknowledged it is healthy
+type Rollout struct {
+	Manifest Manifest             `json:"manifest"`
+	Stages   []int                `json:"stages"` // Percentages of the delegates
+	Stage    int                  `json:"stage"`  // Index of the stage rolling out
+	Status   string               `json:"status"`
+	Reason   string               `json:"reason,omitempty"` // Why it halted
+	Acks     map[string]UpdateAck `json:"acks"`             // Latest of every delegate, by address
+}
+
+// NewManifest - Describes and signs the software, published at the time (milliseconds)
+func NewManifest(privateKey, version, platform, fileName, minimumVersion string, software []byte, timeInMilliseconds int64) (*Manifest, error) {
+	softwareHash := crypto.NewHash(software)
+	manifest := &Manifest{
+		Version:        version,
+		Platform:       platform,
+		FileName:       fileName,
+		SoftwareHash:   hex.EncodeToString(softwareHash[:]),
+		MinimumVersion: minimumVersion,
+		Size:           int64(len(software)),
+		ChunkSize:      UpdateChunkSize,
+		ChunkHashes:    make([]string, 0),
+		Time:           timeInMilliseconds,
+	}
+	for offset := 0; offset < len(software); offset += UpdateChunkSize {
+		end := offset + UpdateChunkSize
+		if end > len(software) {
+			end = len(software)
+		}
+		chunkHash := crypto.NewHash(software[offset:end])
+		manifest.ChunkHashes = append(manifest.ChunkHashes, hex.EncodeToString(chunkHash[:]))
+	}
+	manifest.Hash = manifest.NewHash()
+	hashBytes, err := hex.DecodeString(manifest.Hash)
+	if err != nil {
+		return nil, err
+	}
+	privateKeyBytes, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signatureBytes, err := crypto.NewSignature(privateKeyBytes, hashBytes)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Signature = hex.EncodeToString(signatureBytes)
+	return manifest, nil
+}
+
+// NewHash - Of every field but the hash and signature
+func (this Manifest) NewHash() string {
+	this.Hash = ""
+	this.Signature = ""
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal manifest", err)
+		return ""
+	}
+	hash := crypto.NewHash(bytes)
+	return hex.EncodeToString(hash[:])
+}
+
+// Signer - Address that signed the manifest
+func (this Manifest) Signer() (string, error) {
+	if this.Hash != this.NewHash() {
+		return "", errors.New("invalid manifest hash")
+	}
+	hashBytes, err := hex.DecodeString(this.Hash)
+	if err != nil {
+		return "", errors.New("unable to decode manifest hash")
+	}
+	signatureBytes, err := hex.DecodeString(this.Signature)
+	if err != nil {
+		return "", errors.New("unable to decode manifest signature")
+	}
+	publicKeyBytes, err := crypto.ToPublicKey(hashBytes, signatureBytes)
+	if err != nil {
+		return "", errors.New("unable to generate public key from manifest hash and signature")
+	}
+	if !crypto.VerifySignature(publicKeyBytes, hashBytes, signatureBytes) {
+		return "", errors.New("invalid manifest signature")
+	}
+	return hex.EncodeToString(crypto.ToAddress(publicKeyBytes)), nil
+}
+
+// Verify - The software is the one the manifest describes
+func (this Manifest) Verify(software []byte) error {
+	if int64(len(software)) != this.Size {
+		return errors.Errorf("software has the wrong size [size=%d, expected=%d]", len(software), this.Size)
+	}
+	softwareHash := crypto.NewHash(software)
+	if hex.EncodeToString(softwareHash[:]) != this.SoftwareHash {
+		return errors.New("software does not match the manifest hash")
+	}
+	return nil
+}
+
+// Chunks - Number of chunks the software is sent in
+func (this Manifest) Chunks() int {
+	return len(this.ChunkHashes)
+}
+
+// VerifyChunks - The manifest describes the software in chunks of a size nodes serve, one hash for each
+func (this Manifest) VerifyChunks() error {
+	if this.ChunkSize <= 0 || this.ChunkSize > UpdateMaxChunkSize {
+		return errors.Errorf("invalid chunk size [chunkSize=%d]", this.ChunkSize)
+	}
+	if this.Size < 0 || int64(len(this.ChunkHashes)) != (this.Size+this.ChunkSize-1)/this.ChunkSize {
+		return errors.Errorf("chunk hashes do not match the software size [size=%d, chunks=%d]", this.Size, len(this.ChunkHashes))
+	}
+	return nil
+}
+
+// VerifyChunk - The chunk is the one the manifest describes at the index
+func (this Manifest) VerifyChunk(index int, chunk []byte) error {
+	if index < 0 || index >= len(this.ChunkHashes) {
+		return errors.Errorf("chunk is out of range [index=%d, chunks=%d]", index, len(this.ChunkHashes))
+	}
+	if int64(len(chunk)) > this.ChunkSize || (index < len(this.ChunkHashes)-1 && int64(len(chunk)) != this.ChunkSize) {
+		return errors.Errorf("chunk has the wrong size [index=%d, size=%d]", index, len(chunk))
+	}
+	chunkHash := crypto.NewHash(chunk)
+	if hex.EncodeToString(chunkHash[:]) != this.ChunkHashes[index] {
+		return errors.Errorf("chunk does not match the manifest hash [index=%d]", index)
+	}
+	return nil
+}
+
+// Applies - A node running the version on the platform may update to this release
+func (this Manifest) Applies(version, platform string) error {
+	if this.Platform != platform {
+		return errors.Errorf("release is for another platform [platform=%s, expected=%s]", this.Platform, platform)
+	}
+	if CompareVersions(this.Version, version) <= 0 {
+		return errors.Errorf("release is not newer [version=%s, running=%s]", this.Version, version)
+	}
+	if this.MinimumVersion != "" && CompareVersions(version, this.MinimumVersion) < 0 {
+		return errors.Errorf("running version is too old for the release [running=%s, minimumVersion=%s]", version, this.MinimumVersion)
+	}
+	return nil
+}
+
+// Installs - Whether the software replaces the disgo binary, other artifacts are only saved
+func (this Manifest) Installs() bool {
+	return this.FileName == "disgo"
+}
+
+// String
+func (this Manifest) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal manifest", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// NewHash - Of every field but the hash and signature
+func (this UpdateAck) NewHash() string {
+	this.Hash = ""
+	this.Signature = ""
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal update acknowledgement", err)
+		return ""
+	}
+	hash := crypto.NewHash(bytes)
+	return hex.EncodeToString(hash[:])
+}
+
+// Sign - Hashes and signs the acknowledgement with the delegate's key
+func (this *UpdateAck) Sign(privateKey string) error {
+	this.Hash = this.NewHash()
+	hashBytes, err := hex.DecodeString(this.Hash)
+	if err != nil {
+		return err
+	}
+	privateKeyBytes, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return err
+	}
+	signatureBytes, err := crypto.NewSignature(privateKeyBytes, hashBytes)
+	if err != nil {
+		return err
+	}
+	this.Signature = hex.EncodeToString(signatureBytes)
+	return nil
+}
+
+// Verify - The acknowledgement is signed by the delegate of its address
+func (this UpdateAck) Verify() error {
+	if this.Hash != this.NewHash() {
+		return errors.New("invalid acknowledgement hash")
+	}
+	hashBytes, err := hex.DecodeString(this.Hash)
+	if err != nil {
+		return errors.New("unable to decode acknowledgement hash")
+	}
+	signatureBytes, err := hex.DecodeString(this.Signature)
+	if err != nil {
+		return errors.New("unable to decode acknowledgement signature")
+	}
+	publicKeyBytes, err := crypto.ToPublicKey(hashBytes, signatureBytes)
+	if err != nil {
+		return errors.New("unable to generate public key from acknowledgement hash and signature")
+	}
+	if !crypto.VerifySignature(publicKeyBytes, hashBytes, signatureBytes) || hex.EncodeToString(crypto.ToAddress(publicKeyBytes)) != this.Address {
+		return errors.New("acknowledgement is not signed by its delegate")
+	}
+	return nil
+}
+
+// CompareVersions - Compares dotted versions number by number, -1 when a is older, 1 when newer, 0 when the same
+func CompareVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bPart, _ = strconv.Atoi(bParts[i])
+		}
+		if aPart < bPart {
+			return -1
+		}
+		if aPart > bPart {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Platform - GOOS/GOARCH of this node
+func Platform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+// StageSize - Delegates a stage rolls out to, at least one
+func StageSize(percent, delegates int) int {
+	size := (percent*delegates + 99) / 100
+	if size < 1 {
+		size = 1
+	}
+	if size > delegates {
+		size = delegates
+	}
+	return size
+}
@@ -0,0 +1,180 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+//TestManifest
+func TestManifest(t *testing.T) {
+	software := []byte("disgo")
+	manifest, err := NewManifest(testPrivateKey, "2.3.0", "linux/amd64", "disgo", "2.1.0", software, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := manifest.Signer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer != testAddress {
+		t.Fatalf("expected the manifest to be signed by %s [signer=%s]", testAddress, signer)
+	}
+	if err := manifest.Verify(software); err != nil {
+		t.Fatal(err)
+	}
+	if err := manifest.Verify([]byte("tampered")); err == nil {
+		t.Fatal("expected tampered software to fail")
+	}
+
+	// Changing a field breaks the signature.
+	tampered := *manifest
+	tampered.MinimumVersion = ""
+	if _, err := tampered.Signer(); err == nil {
+		t.Fatal("expected a tampered manifest to fail")
+	}
+}
+
+//TestUpdateAck
+func TestUpdateAck(t *testing.T) {
+	ack := &UpdateAck{ManifestHash: "hash", Address: testAddress, Status: UpdateInstalled, Version: "2.3.0", Time: 1}
+	if err := ack.Sign(testPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := ack.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nobody can change the status, or claim the acknowledgement of another delegate.
+	tampered := *ack
+	tampered.Status = UpdateHealthy
+	if err := tampered.Verify(); err == nil {
+		t.Fatal("expected a tampered acknowledgement to fail")
+	}
+	other := *ack
+	other.Address = "d70613f93152c84050e7826c4e2b0cc02c1c3b99"
+	other.Hash = other.NewHash()
+	if err := other.Verify(); err == nil {
+		t.Fatal("expected an acknowledgement signed by another delegate to fail")
+	}
+}
+
+//TestManifestChunks
+func TestManifestChunks(t *testing.T) {
+	software := bytes.Repeat([]byte("disgo"), UpdateChunkSize/2)
+	manifest, err := NewManifest(testPrivateKey, "2.3.0", "linux/amd64", "disgo", "", software, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Chunks() != 3 || manifest.ChunkSize != UpdateChunkSize {
+		t.Fatalf("expected 3 chunks [chunks=%d, chunkSize=%d]", manifest.Chunks(), manifest.ChunkSize)
+	}
+	for index := 0; index < manifest.Chunks(); index++ {
+		end := (index + 1) * UpdateChunkSize
+		if end > len(software) {
+			end = len(software)
+		}
+		if err := manifest.VerifyChunk(index, software[index*UpdateChunkSize:end]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := manifest.VerifyChunk(0, software[UpdateChunkSize:2*UpdateChunkSize-1]); err == nil {
+		t.Fatal("expected a short chunk to fail")
+	}
+	if err := manifest.VerifyChunk(1, software[:UpdateChunkSize]); err == nil {
+		t.Fatal("expected another chunk to fail")
+	}
+	if err := manifest.VerifyChunk(3, software[:1]); err == nil {
+		t.Fatal("expected a chunk out of range to fail")
+	}
+
+	// The chunk size and hashes must describe the software.
+	if err := manifest.VerifyChunks(); err != nil {
+		t.Fatal(err)
+	}
+	invalid := *manifest
+	invalid.ChunkSize = 0
+	if err := invalid.VerifyChunks(); err == nil {
+		t.Fatal("expected a zero chunk size to fail")
+	}
+	invalid = *manifest
+	invalid.ChunkHashes = manifest.ChunkHashes[:2]
+	if err := invalid.VerifyChunks(); err == nil {
+		t.Fatal("expected a missing chunk hash to fail")
+	}
+	if err := manifest.Verify(software[:len(software)-1]); err == nil {
+		t.Fatal("expected software of the wrong size to fail")
+	}
+
+	// The chunk hashes are signed with the rest of the manifest.
+	tampered := *manifest
+	tampered.ChunkHashes = append([]string{}, manifest.ChunkHashes...)
+	tampered.ChunkHashes[2] = manifest.ChunkHashes[0]
+	if _, err := tampered.Signer(); err == nil {
+		t.Fatal("expected a tampered manifest to fail")
+	}
+}
+
+//TestManifestApplies
+func TestManifestApplies(t *testing.T) {
+	manifest := Manifest{Version: "2.3.0", Platform: "linux/amd64", MinimumVersion: "2.1.0"}
+	if err := manifest.Applies("2.2.0", "linux/amd64"); err != nil {
+		t.Fatal(err)
+	}
+	for _, running := range []struct {
+		version  string
+		platform string
+	}{
+		{"2.2.0", "darwin/amd64"}, // Another platform
+		{"2.3.0", "linux/amd64"},  // Not newer
+		{"2.4", "linux/amd64"},    // Newer
+		{"2.0.9", "linux/amd64"},  // Below the minimum
+	} {
+		if err := manifest.Applies(running.version, running.platform); err == nil {
+			t.Fatalf("expected the release not to apply [version=%s, platform=%s]", running.version, running.platform)
+		}
+	}
+}
+
+//TestCompareVersions
+func TestCompareVersions(t *testing.T) {
+	for _, compare := range []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"2.2.0", "2.2.0", 0},
+		{"2.2", "2.2.0", 0},
+		{"2.10.0", "2.9.0", 1},
+		{"1.9.9", "2.0.0", -1},
+	} {
+		if result := CompareVersions(compare.a, compare.b); result != compare.expected {
+			t.Fatalf("expected %d comparing %s to %s [result=%d]", compare.expected, compare.a, compare.b, result)
+		}
+	}
+}
+
+//TestStageSize
+func TestStageSize(t *testing.T) {
+	if StageSize(10, 4) != 1 || StageSize(50, 4) != 2 || StageSize(100, 4) != 4 || StageSize(0, 4) != 1 || StageSize(200, 4) != 4 {
+		t.Fatal("expected stages to round up to whole delegates")
+	}
+}
@@ -23,18 +23,18 @@ import (
 	"encoding/json"
 	"github.com/dispatchlabs/disgo/commons/crypto"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"time"
 	"sort"
 	"fmt"
 )
 
 // Rumor
 type Rumor struct {
-	Hash            string // Hash = (Address + TransactionHash + Time)
+	Hash            string // Hash = (Address + TransactionHash + Time + NetworkId)
 	Address         string
 	TransactionHash string
 	Time            int64
 	Signature       string
+	NetworkId       string
 }
 
 // UnmarshalJSON
@@ -59,6 +59,9 @@ func (this *Rumor) UnmarshalJSON(bytes []byte) error {
 	if jsonMap["signature"] != nil {
 		this.Signature = jsonMap["signature"].(string)
 	}
+	if jsonMap["networkId"] != nil {
+		this.NetworkId = jsonMap["networkId"].(string)
+	}
 	return nil
 }
 
@@ -70,12 +73,14 @@ func (this Rumor) MarshalJSON() ([]byte, error) {
 		TransactionHash string `json:"transactionHash"`
 		Time            int64  `json:"time"`
 		Signature       string `json:"signature"`
+		NetworkId       string `json:"networkId,omitempty"`
 	}{
 		Hash:            this.Hash,
 		Address:         this.Address,
 		TransactionHash: this.TransactionHash,
 		Time:            this.Time,
 		Signature:       this.Signature,
+		NetworkId:       this.NetworkId,
 	})
 }
 
@@ -106,6 +111,9 @@ func (this Rumor) NewHash() string {
 		transactionHashBytes,
 		this.Time,
 	}
+	if network := networkIdBytes(this.NetworkId); network != nil {
+		values = append(values, network)
+	}
 	buffer := new(bytes.Buffer)
 	for _, value := range values {
 		err := binary.Write(buffer, binary.LittleEndian, value)
@@ -118,8 +126,11 @@ func (this Rumor) NewHash() string {
 	return hex.EncodeToString(delegateHash[:])
 }
 
-// Verify
-func (this Rumor) Verify() bool {
+// Verify - A rumor signed for another network is not valid
+func (this Rumor) Verify(networkId string) bool {
+	if !IsNetwork(this.NetworkId, networkId) {
+		return false
+	}
 	if len(this.Hash) != crypto.HashLength*2 {
 		return false
 	}
@@ -189,12 +200,13 @@ func ToRumorsFromJson(payload []byte) ([]*Rumor, error) {
 	return rumors, nil
 }
 
-// NewRumor -
-func NewRumor(privateKey string, address string, transactionHash string) *Rumor {
+// NewRumor - Rumor made at the time (milliseconds)
+func NewRumor(privateKey string, address string, transactionHash string, networkId string, timeInMilliseconds int64) *Rumor {
 	rumor := &Rumor{}
 	rumor.Address = address
 	rumor.TransactionHash = transactionHash
-	rumor.Time = utils.ToMilliSeconds(time.Now())
+	rumor.Time = timeInMilliseconds
+	rumor.NetworkId = networkId
 	rumor.Hash = rumor.NewHash()
 	privateKeyBytes, err := hex.DecodeString(privateKey)
 	if err != nil {
@@ -236,18 +248,18 @@ func (this RumorsSorter) Less(i, j int) bool {
 	return this.Rumors[i].Time < this.Rumors[j].Time
 }
 
-func ValidateTimeDelta(rumors []Rumor) bool {
+// ValidateTimeDelta - Rumors must follow each other, and the latest must be by now (milliseconds), within gossipTimeout milliseconds
+func ValidateTimeDelta(rumors []Rumor, gossipTimeout int64, nowInMilliseconds int64) bool {
 	result := true
 	rumorSorter := RumorsSorter{rumors}
 	sort.Sort(rumorSorter)
 	len := rumorSorter.Len()
 
 	timing := make([]int64, 0)
-	now := utils.ToMilliSeconds(time.Now())
-	initialTime := now - rumorSorter.Rumors[len-1].Time
+	initialTime := nowInMilliseconds - rumorSorter.Rumors[len-1].Time
 	timing = append(timing, initialTime)
 
-	if  now - rumorSorter.Rumors[len-1].Time > GossipTimeout {
+	if  nowInMilliseconds - rumorSorter.Rumors[len-1].Time > gossipTimeout {
 		msg := fmt.Sprintf("gossip for [hash=%s] to local delegate [adresss=%s] took [time=%v]", rumorSorter.Rumors[len-1].TransactionHash, rumorSorter.Rumors[len-1].Address, initialTime)
 		utils.Info(msg)
 		result = false
@@ -256,7 +268,7 @@ func ValidateTimeDelta(rumors []Rumor) bool {
 		for i := 1; i < len; i++ {
 			gossipTime := rumorSorter.Rumors[i].Time - rumorSorter.Rumors[i-1].Time
 			timing = append(timing, gossipTime)
-			if gossipTime > GossipTimeout {
+			if gossipTime > gossipTimeout {
 				msg := fmt.Sprintf("gossip for [hash=%s] between delegate [adresss=%s] and delegage [adresss=%s] took [time=%v]", rumorSorter.Rumors[i].TransactionHash, rumorSorter.Rumors[i].Address, rumorSorter.Rumors[i-1].Address, gossipTime)
 				utils.Warn(msg)
 				result = false
@@ -16,16 +16,21 @@
  */
 package types
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
 
 func testMockRumor() *Rumor {
-	return NewRumor("0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a", "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "9c242afd4f2dcaedcfb0cff2bb9c38b5811ed29c249f5b49f7759642a473d5fb")
+	return NewRumor("0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a", "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "9c242afd4f2dcaedcfb0cff2bb9c38b5811ed29c249f5b49f7759642a473d5fb", DefaultChainId, utils.ToMilliSeconds(time.Now()))
 }
 
 // RomorVerify
 func TestRumorVerify(t *testing.T) {
 	rumor := testMockRumor()
-	if rumor.Verify() {
+	if rumor.Verify(DefaultChainId) {
 		t.Log("rumor verified")
 	} else {
 		t.Error("cannot verify rumor")
@@ -0,0 +1,175 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/patrickmn/go-cache"
+)
+
+// Stake - Tokens an account bonded to a delegate
+type Stake struct {
+	Delegate string `json:"delegate"`
+	Value    int64  `json:"value"`
+}
+
+// DelegateStake - Total of the tokens bonded to a delegate
+type DelegateStake struct {
+	Delegate string    `json:"delegate"`
+	Total    int64     `json:"total"`
+	Stakers  int       `json:"stakers"`
+	Updated  time.Time `json:"updated"`
+}
+
+// Key
+func (this DelegateStake) Key() string {
+	return fmt.Sprintf("table-delegate-stake-%s", this.Delegate)
+}
+
+// Cache
+func (this *DelegateStake) Cache(cache *cache.Cache) {
+	cache.Set(this.Key(), this, CacheTTL)
+}
+
+// Persist
+func (this *DelegateStake) Persist(txn *badger.Txn) error {
+	return txn.Set([]byte(this.Key()), []byte(this.String()))
+}
+
+// Set
+func (this *DelegateStake) Set(txn *badger.Txn, cache *cache.Cache) error {
+	this.Cache(cache)
+	return this.Persist(txn)
+}
+
+// String
+func (this DelegateStake) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal delegate stake", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// ToDelegateStakeFromCache
+func ToDelegateStakeFromCache(cache *cache.Cache, delegate string) (*DelegateStake, error) {
+	value, ok := cache.Get(DelegateStake{Delegate: delegate}.Key())
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return value.(*DelegateStake), nil
+}
+
+// ToDelegateStake
+func ToDelegateStake(txn *badger.Txn, delegate string) (*DelegateStake, error) {
+	item, err := txn.Get([]byte(DelegateStake{Delegate: delegate}.Key()))
+	if err != nil {
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	delegateStake := &DelegateStake{}
+	err = json.Unmarshal(value, delegateStake)
+	if err != nil {
+		return nil, err
+	}
+	return delegateStake, nil
+}
+
+// ToDelegateStakes - Delegates with stake, most staked first
+func ToDelegateStakes(txn *badger.Txn) ([]*DelegateStake, error) {
+	iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer iterator.Close()
+	prefix := []byte("table-delegate-stake-")
+	delegateStakes := make([]*DelegateStake, 0)
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		value, err := iterator.Item().Value()
+		if err != nil {
+			return nil, err
+		}
+		delegateStake := &DelegateStake{}
+		err = json.Unmarshal(value, delegateStake)
+		if err != nil {
+			return nil, err
+		}
+		delegateStakes = append(delegateStakes, delegateStake)
+	}
+	sort.SliceStable(delegateStakes, func(i, j int) bool { return delegateStakes[i].Total > delegateStakes[j].Total })
+	return delegateStakes, nil
+}
+
+// bondedKey - Of the tokens the address had bonded from the time (milliseconds) on
+func bondedKey(address string, time int64) string {
+	return fmt.Sprintf("key-bonded-%s-%020d", address, time)
+}
+
+// PersistBonded - Records the tokens the account has bonded from the time (milliseconds) on, see ToBondedAt
+func (this Account) PersistBonded(txn *badger.Txn, time int64) error {
+	return txn.Set([]byte(bondedKey(this.Address, time)), []byte(strconv.FormatInt(this.BondedBalance(), 10)))
+}
+
+// ToBondedAt - Tokens the address had bonded at the time (milliseconds), so a vote is weighted by the stake held when
+// the proposal was made
+func ToBondedAt(txn *badger.Txn, address string, time int64) (int64, error) {
+	options := badger.DefaultIteratorOptions
+	options.Reverse = true
+	iterator := txn.NewIterator(options)
+	defer iterator.Close()
+	iterator.Seek([]byte(bondedKey(address, time)))
+	if !iterator.ValidForPrefix([]byte(fmt.Sprintf("key-bonded-%s-", address))) {
+		return 0, nil
+	}
+	value, err := iterator.Item().Value()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+// ToDelegateAddresses - Delegates according to the chain at the time (milliseconds), those of the genesis added and
+// removed by approved proposals, in address order. Unlike the nodes in the cache it is the same on every delegate
+// executing at the same point.
+func ToDelegateAddresses(txn *badger.Txn, time int64) ([]string, error) {
+	parameters, err := ToParametersAt(txn, time)
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	return parameters.Delegates, nil
+}
+
+// IsDelegate - Whether the chain knows the address as a delegate at the time (milliseconds), see ToDelegateAddresses
+func IsDelegate(txn *badger.Txn, address string, time int64) (bool, error) {
+	delegates, err := ToDelegateAddresses(txn, time)
+	if err != nil {
+		return false, err
+	}
+	index := sort.SearchStrings(delegates, address)
+	return index < len(delegates) && delegates[index] == address, nil
+}
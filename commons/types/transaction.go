@@ -36,22 +36,40 @@ import (
 
 // Transaction - The transaction info
 type Transaction struct {
-	Hash      string // Hash = (Type + From + To + Value + Code + Abi + Method + Params + Time)
-	Type      byte
-	From      string
-	To        string
-	Value     int64
-	Code      string
-	Abi       string
-	Method    string
-	Params    []interface{}
-	Time      int64 // Milliseconds
-	Signature string
-	Hertz     int64   //our version of Gas
-	Receipt   Receipt // Transient
-	Gossip    []Rumor // Transient
-	FromName  string  // Transient
-	ToName    string  // Transient
+	Hash           string // Hash = (Type + From + To + Value + Code + Abi + Method + Params + Time)
+	Type           byte
+	From           string
+	To             string
+	Value          int64
+	Code           string
+	Abi            string
+	Method         string
+	Params         []interface{}
+	Time           int64 // Milliseconds
+	Signature      string
+	Hertz          int64    //our version of Gas
+	Outputs        []Output // Batch transfer recipients
+	Signers        []string // Multisig account creation
+	Threshold      int      // Multisig account creation
+	Signatures     []string // Cosignatures, spending from a multisig account
+	UnlockTime     int64    // Milliseconds, locked transfer
+	Name           string   // Name registered, transferred or released, or recipient of a transfer without to address
+	Fee            int64    // Offered to the delegates, charged on execution with the Hertz used
+	Parameter      string   // Parameter a proposal sets to the value
+	ActivationTime int64    // Milliseconds, when the proposed value is used from
+	Proposal       string   // Hash of the proposal voted on
+	Approve        bool     // Vote for or against the proposal
+	NetworkId      string   // Network signed for, it cannot be replayed on another
+	Receipt        Receipt  // Transient
+	Gossip         []Rumor  // Transient
+	FromName       string   // Transient
+	ToName         string   // Transient
+}
+
+// Output - One recipient of a batch transfer
+type Output struct {
+	To    string `json:"to"`
+	Value int64  `json:"value"`
 }
 
 // Key
@@ -76,12 +94,42 @@ func (this Transaction) FromKey() string {
 
 // ToKey
 func (this Transaction) ToKey() string {
-	return fmt.Sprintf("key-transaction-to-%s-%d", this.To, this.Time)
+	return this.ToKeyFor(this.To)
+}
+
+// ToKeyFor - The to key of one recipient, a batch transfer has one per output
+func (this Transaction) ToKeyFor(address string) string {
+	return fmt.Sprintf("key-transaction-to-%s-%d", address, this.Time)
+}
+
+// MultisigAddress - Address of the account a multisig creation creates, derived from the transaction hash
+func (this Transaction) MultisigAddress() string {
+	hashBytes, err := hex.DecodeString(this.Hash)
+	if err != nil || len(hashBytes) != crypto.HashLength {
+		return ""
+	}
+	return hex.EncodeToString(hashBytes[crypto.HashLength-crypto.AddressLength:])
+}
+
+// TotalValue - Value of a transfer, or the sum of a batch transfer's outputs
+func (this Transaction) TotalValue() int64 {
+	if this.Type != TypeBatchTransferTokens {
+		return this.Value
+	}
+	var total int64
+	for _, output := range this.Outputs {
+		total += output.Value
+	}
+	return total
 }
 
 //Cache
-func (this *Transaction) Cache(cache *cache.Cache) {
-	cache.Set(this.Key(), this, TransactionCacheTTL)
+func (this *Transaction) Cache(cache *cache.Cache, time_optional ...time.Duration) {
+	TTL := TransactionCacheTTL
+	if len(time_optional) > 0 {
+		TTL = time_optional[0]
+	}
+	cache.Set(this.Key(), this, TTL)
 }
 
 // Persist
@@ -102,6 +150,20 @@ func (this *Transaction) Persist(txn *badger.Txn) error {
 	if err != nil {
 		return err
 	}
+	if this.Type == TypeBatchTransferTokens {
+		for _, output := range this.Outputs {
+			err = txn.Set([]byte(this.ToKeyFor(output.To)), []byte(this.Key()))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// No to address? Eg; a transfer to a name, executing it indexes the account the name resolved to.
+	if this.To == "" {
+		return nil
+	}
 	err = txn.Set([]byte(this.ToKey()), []byte(this.Key()))
 	if err != nil {
 		return err
@@ -110,8 +172,8 @@ func (this *Transaction) Persist(txn *badger.Txn) error {
 }
 
 // PersistAndCache
-func (this *Transaction) Set(txn *badger.Txn, cache *cache.Cache) error {
-	this.Cache(cache)
+func (this *Transaction) Set(txn *badger.Txn, cache *cache.Cache, time_optional ...time.Duration) error {
+	this.Cache(cache, time_optional...)
 
 	err := this.Persist(txn)
 	if err != nil {
@@ -388,7 +450,7 @@ func ToTransactionsByToAddress(txn *badger.Txn, address, startingHash string, pa
 		if err != nil {
 			return nil, ErrInvalidRequestHash
 		}
-		item = []byte(thing.ToKey())
+		item = []byte(thing.ToKeyFor(address))
 	} else {
 		item = prefix
 	}
@@ -578,6 +640,260 @@ func NewTransferTokensTransaction(privateKey string, from, to string, value int6
 	return transaction, nil
 }
 
+// NewBatchTransferTokensTransaction -
+func NewBatchTransferTokensTransaction(privateKey string, from string, outputs []Output, hertz int64, timeInMiliseconds int64) (*Transaction, error) {
+	if len(outputs) == 0 {
+		return nil, errors.Errorf("cannot have empty outputs")
+	}
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = TypeBatchTransferTokens
+	transaction.From = from
+	transaction.Outputs = outputs
+	transaction.Hertz = hertz
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	transaction.Signature, err = transaction.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// NewCreateMultisigTransaction - The created account's address is the transaction's MultisigAddress
+func NewCreateMultisigTransaction(privateKey string, from string, signers []string, threshold int, timeInMiliseconds int64) (*Transaction, error) {
+	if len(signers) == 0 {
+		return nil, errors.Errorf("cannot have empty signers")
+	}
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = TypeCreateMultisig
+	transaction.From = from
+	transaction.Signers = signers
+	transaction.Threshold = threshold
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	transaction.Signature, err = transaction.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// NewLockTokensTransaction - The tokens are credited to the to account but cannot be spent before the unlock time,
+// which unlike the transaction time can be any time in the future
+func NewLockTokensTransaction(privateKey string, from, to string, value int64, hertz int64, timeInMiliseconds int64, unlockTimeInMiliseconds int64) (*Transaction, error) {
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = TypeLockTokens
+	transaction.From = from
+	transaction.To = to
+	transaction.Value = value
+	transaction.Hertz = hertz
+	transaction.UnlockTime = unlockTimeInMiliseconds
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.UnlockTime <= transaction.Time {
+		return nil, errors.Errorf("unlock time must be after the transaction time")
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	transaction.Signature, err = transaction.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// NewTransferTokensToNameTransaction - The name is resolved to the address that holds it when the transaction executes
+func NewTransferTokensToNameTransaction(privateKey string, from, name string, value int64, hertz int64, timeInMiliseconds int64) (*Transaction, error) {
+	return newNameTransaction(privateKey, TypeTransferTokens, from, "", name, value, hertz, timeInMiliseconds)
+}
+
+// NewRegisterNameTransaction - Claims the name for the from account, an account holds one name at most
+func NewRegisterNameTransaction(privateKey string, from, name string, timeInMiliseconds int64) (*Transaction, error) {
+	return newNameTransaction(privateKey, TypeRegisterName, from, "", name, 0, 0, timeInMiliseconds)
+}
+
+// NewTransferNameTransaction - Hands the from account's name to the to account
+func NewTransferNameTransaction(privateKey string, from, to, name string, timeInMiliseconds int64) (*Transaction, error) {
+	return newNameTransaction(privateKey, TypeTransferName, from, to, name, 0, 0, timeInMiliseconds)
+}
+
+// NewReleaseNameTransaction - Gives up the from account's name, anyone can register it afterwards
+func NewReleaseNameTransaction(privateKey string, from, name string, timeInMiliseconds int64) (*Transaction, error) {
+	return newNameTransaction(privateKey, TypeReleaseName, from, "", name, 0, 0, timeInMiliseconds)
+}
+
+// newNameTransaction
+func newNameTransaction(privateKey string, tipe byte, from, to, name string, value int64, hertz int64, timeInMiliseconds int64) (*Transaction, error) {
+	err := ValidateName(name)
+	if err != nil {
+		return nil, err
+	}
+	transaction := &Transaction{}
+	transaction.Type = tipe
+	transaction.From = from
+	transaction.To = to
+	transaction.Name = name
+	transaction.Value = value
+	transaction.Hertz = hertz
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	transaction.Signature, err = transaction.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// NewStakeTransaction - Bonds tokens of the from account to the delegate, a delegate can stake with itself
+func NewStakeTransaction(privateKey string, from, delegate string, value int64, timeInMiliseconds int64) (*Transaction, error) {
+	return newStakeTransaction(privateKey, TypeStake, from, delegate, value, timeInMiliseconds)
+}
+
+// NewUnstakeTransaction - Unbonds tokens from the delegate, they can be spent once the unbonding period is over
+func NewUnstakeTransaction(privateKey string, from, delegate string, value int64, timeInMiliseconds int64) (*Transaction, error) {
+	return newStakeTransaction(privateKey, TypeUnstake, from, delegate, value, timeInMiliseconds)
+}
+
+// newStakeTransaction
+func newStakeTransaction(privateKey string, tipe byte, from, delegate string, value int64, timeInMiliseconds int64) (*Transaction, error) {
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = tipe
+	transaction.From = from
+	transaction.To = delegate
+	transaction.Value = value
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	transaction.Signature, err = transaction.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// NewProposeParameterTransaction - Proposes the value for the parameter from the activation time on, if approved by
+// the stakers' votes
+func NewProposeParameterTransaction(privateKey string, from, parameter string, value int64, activationTimeInMiliseconds int64, timeInMiliseconds int64) (*Transaction, error) {
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = TypeProposeParameter
+	transaction.From = from
+	transaction.Parameter = parameter
+	transaction.Value = value
+	transaction.ActivationTime = activationTimeInMiliseconds
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	transaction.Signature, err = transaction.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// NewProposeDelegateTransaction - Proposes adding or removing (see ParameterAddDelegate and ParameterRemoveDelegate) the
+// delegate from the activation time on, if approved by the stakers' votes
+func NewProposeDelegateTransaction(privateKey string, from, parameter string, delegate string, activationTimeInMiliseconds int64, timeInMiliseconds int64) (*Transaction, error) {
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = TypeProposeParameter
+	transaction.From = from
+	transaction.To = delegate
+	transaction.Parameter = parameter
+	transaction.ActivationTime = activationTimeInMiliseconds
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	transaction.Signature, err = transaction.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// NewVoteTransaction - Votes for or against the proposal with the tokens the from account staked
+func NewVoteTransaction(privateKey string, from, proposal string, approve bool, timeInMiliseconds int64) (*Transaction, error) {
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = TypeVote
+	transaction.From = from
+	transaction.Proposal = proposal
+	transaction.Approve = approve
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	transaction.Signature, err = transaction.NewSignature(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// NewUnsignedTransferTokensTransaction - For a multisig account, the signers add their signatures with Cosign
+func NewUnsignedTransferTokensTransaction(from, to string, value int64, hertz int64, timeInMiliseconds int64) (*Transaction, error) {
+	var err error
+	transaction := &Transaction{}
+	transaction.Type = TypeTransferTokens
+	transaction.From = from
+	transaction.To = to
+	transaction.Value = value
+	transaction.Time, err = checkTime(timeInMiliseconds)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Hash, err = transaction.NewHash()
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
 // NewDeployContractTransaction -
 func NewDeployContractTransaction(privateKey string, from string, code string, abi string, timeInMiliseconds int64) (*Transaction, error) {
 	if abi == "" {
@@ -663,6 +979,7 @@ func (this Transaction) NewHash() (string, error) {
 		// TODO: this.Params,
 		this.Time,
 	}
+
 	buffer := new(bytes.Buffer)
 	for _, value := range values {
 		err := binary.Write(buffer, binary.LittleEndian, value)
@@ -671,10 +988,88 @@ func (this Transaction) NewHash() (string, error) {
 			return "", err
 		}
 	}
+
+	// Optional fields are only hashed when present so the hash of other transactions is unchanged, each with its tag
+	// and length so no two sets of fields write the same bytes.
+	for _, output := range this.Outputs {
+		outputToBytes, err := hex.DecodeString(output.To)
+		if err != nil {
+			utils.Error("unable decode output to", err)
+			return "", err
+		}
+		writeHashField(buffer, hashFieldOutput, outputToBytes, int64Bytes(output.Value))
+	}
+	for _, signer := range this.Signers {
+		signerBytes, err := hex.DecodeString(signer)
+		if err != nil {
+			utils.Error("unable decode signer", err)
+			return "", err
+		}
+		writeHashField(buffer, hashFieldSigner, signerBytes)
+	}
+	if this.Threshold != 0 {
+		writeHashField(buffer, hashFieldThreshold, int64Bytes(int64(this.Threshold)))
+	}
+	if this.UnlockTime != 0 {
+		writeHashField(buffer, hashFieldUnlockTime, int64Bytes(this.UnlockTime))
+	}
+	if this.Name != "" {
+		writeHashField(buffer, hashFieldName, []byte(this.Name))
+	}
+	if this.Fee != 0 {
+		writeHashField(buffer, hashFieldFee, int64Bytes(this.Fee))
+	}
+	if this.Parameter != "" {
+		writeHashField(buffer, hashFieldParameter, []byte(this.Parameter), int64Bytes(this.ActivationTime))
+	}
+	if this.Proposal != "" {
+		proposalBytes, err := hex.DecodeString(this.Proposal)
+		if err != nil {
+			utils.Error("unable decode proposal", err)
+			return "", err
+		}
+		approve := []byte{0}
+		if this.Approve {
+			approve[0] = 1
+		}
+		writeHashField(buffer, hashFieldProposal, proposalBytes, approve)
+	}
+	if network := networkIdBytes(this.NetworkId); network != nil {
+		writeHashField(buffer, hashFieldNetworkId, network)
+	}
 	hash := crypto.NewHash(buffer.Bytes())
 	return hex.EncodeToString(hash[:]), nil
 }
 
+// Tags of the optional fields in the hash.
+const (
+	hashFieldOutput byte = iota + 1
+	hashFieldSigner
+	hashFieldThreshold
+	hashFieldUnlockTime
+	hashFieldName
+	hashFieldFee
+	hashFieldParameter
+	hashFieldProposal
+	hashFieldNetworkId
+)
+
+// writeHashField - Tag, then each part prefixed with its length
+func writeHashField(buffer *bytes.Buffer, tag byte, parts ...[]byte) {
+	buffer.WriteByte(tag)
+	for _, part := range parts {
+		binary.Write(buffer, binary.LittleEndian, uint32(len(part)))
+		buffer.Write(part)
+	}
+}
+
+// int64Bytes
+func int64Bytes(value int64) []byte {
+	result := make([]byte, 8)
+	binary.LittleEndian.PutUint64(result, uint64(value))
+	return result
+}
+
 // NewSignature
 func (this Transaction) NewSignature(privateKey string) (string, error) {
 	hashBytes, err := hex.DecodeString(this.Hash)
@@ -694,6 +1089,78 @@ func (this Transaction) NewSignature(privateKey string) (string, error) {
 	return hex.EncodeToString(signatureBytes), nil
 }
 
+// SetFee - Changes the fee, which is part of the hash. The transaction is signed again with the private key, or left
+// for the signers to Cosign when the private key is empty.
+func (this *Transaction) SetFee(fee int64, privateKey string) error {
+	this.Fee = fee
+	return this.resign(privateKey)
+}
+
+// SetNetworkId - Changes the network the transaction is signed for, which is part of the hash. The transaction is signed
+// again with the private key, or left for the signers to Cosign when the private key is empty.
+func (this *Transaction) SetNetworkId(networkId string, privateKey string) error {
+	this.NetworkId = networkId
+	return this.resign(privateKey)
+}
+
+// resign - Hashes the transaction again after a change, signed with the private key when not empty
+func (this *Transaction) resign(privateKey string) error {
+	var err error
+	this.Hash, err = this.NewHash()
+	if err != nil {
+		return err
+	}
+	this.Signature = ""
+	this.Signatures = nil
+	if privateKey == "" {
+		return nil
+	}
+	this.Signature, err = this.NewSignature(privateKey)
+	return err
+}
+
+// TotalFee - The fee and the price of the Hertz used, charged on execution
+func (this Transaction) TotalFee(hertzUsed int64, hertzPrice int64) int64 {
+	return this.Fee + hertzUsed*hertzPrice
+}
+
+// Cosign - Adds the signer's signature, can be done offline by each signer of a multisig account
+func (this *Transaction) Cosign(privateKey string) error {
+	signature, err := this.NewSignature(privateKey)
+	if err != nil {
+		return err
+	}
+	for _, other := range this.Signatures {
+		if other == signature {
+			return nil
+		}
+	}
+	this.Signatures = append(this.Signatures, signature)
+	return nil
+}
+
+// VerifySignatures - Enough of the signers signed? The signatures are verified by Verify
+func (this Transaction) VerifySignatures(signers []string, threshold int) error {
+	signed := make(map[string]bool)
+	for _, signature := range this.Signatures {
+		address, err := this.recoverSigner(signature)
+		if err != nil {
+			return err
+		}
+		signed[address] = true
+	}
+	count := 0
+	for _, signer := range signers {
+		if signed[signer] {
+			count++
+		}
+	}
+	if count < threshold {
+		return errors.Errorf("transaction has %d of the %d signatures required", count, threshold)
+	}
+	return nil
+}
+
 // Verify
 func (this Transaction) Verify() error {
 	if len(this.Hash) != crypto.HashLength*2 {
@@ -702,17 +1169,56 @@ func (this Transaction) Verify() error {
 	if len(this.From) != crypto.AddressLength*2 {
 		return errors.New("invalid from address")
 	}
-	if len(this.Signature) != crypto.SignatureLength*2 {
+	if len(this.Signatures) > 0 {
+		if this.Signature != "" {
+			return errors.New("transaction cannot have both a signature and cosignatures")
+		}
+		for _, signature := range this.Signatures {
+			if len(signature) != crypto.SignatureLength*2 {
+				return errors.New("invalid cosignature")
+			}
+		}
+	} else if len(this.Signature) != crypto.SignatureLength*2 {
 		return errors.New("invalid signature")
 	}
-	if this.From == this.To {
+	if this.Type != TypeCreateMultisig && (len(this.Signers) > 0 || this.Threshold != 0) {
+		return errors.New("only a multisig creation can have signers")
+	}
+	if this.Fee < 0 {
+		return errors.New("fee cannot be less than zero")
+	}
+	if this.Type != TypeLockTokens && this.UnlockTime != 0 {
+		return errors.New("only a locked transfer can have an unlock time")
+	}
+	if this.Name != "" {
+		switch this.Type {
+		case TypeTransferTokens, TypeLockTokens:
+			if len(this.To) != 0 {
+				return errors.New("to address must be blank for a transfer to a name")
+			}
+		case TypeRegisterName, TypeTransferName, TypeReleaseName:
+		default:
+			return errors.New("only a transfer or a name registry transaction can have a name")
+		}
+		err := ValidateName(this.Name)
+		if err != nil {
+			return err
+		}
+	}
+	if this.Type != TypeProposeParameter && (this.Parameter != "" || this.ActivationTime != 0) {
+		return errors.New("only a proposal can have a parameter")
+	}
+	if this.Type != TypeVote && (this.Proposal != "" || this.Approve) {
+		return errors.New("only a vote can have a proposal")
+	}
+	if this.From == this.To && this.Type != TypeStake && this.Type != TypeUnstake && this.Type != TypeProposeParameter {
 		return errors.New("from address cannot equal to address")
 	}
 
 	// Type?
 	switch this.Type {
 	case TypeTransferTokens:
-		if len(this.To) != crypto.AddressLength*2 {
+		if len(this.To) != crypto.AddressLength*2 && this.Name == "" {
 			return errors.New("invalid to address")
 		}
 		if this.Value <= 0 {
@@ -737,6 +1243,129 @@ func (this Transaction) Verify() error {
 
 		// TODO: Should we check method?
 		break
+	case TypeBatchTransferTokens:
+		if len(this.To) != 0 {
+			return errors.New("to address must be blank for a batch transfer, recipients are outputs")
+		}
+		if this.Value != 0 {
+			return errors.New("value must be zero for a batch transfer, values are outputs")
+		}
+		if len(this.Outputs) == 0 {
+			return errors.New("batch transfer must have outputs")
+		}
+		if len(this.Outputs) > MaxBatchOutputs {
+			return errors.Errorf("batch transfer cannot have more than %d outputs", MaxBatchOutputs)
+		}
+		recipients := make(map[string]bool)
+		var total int64
+		for _, output := range this.Outputs {
+			if len(output.To) != crypto.AddressLength*2 {
+				return errors.Errorf("invalid output to address [to=%s]", output.To)
+			}
+			if output.To == this.From {
+				return errors.New("from address cannot equal an output to address")
+			}
+			if recipients[output.To] {
+				return errors.Errorf("duplicate output to address [to=%s]", output.To)
+			}
+			recipients[output.To] = true
+			if output.Value <= 0 {
+				return errors.New("output value cannot be less than or equal to zero")
+			}
+			if total+output.Value < total {
+				return errors.New("total value of outputs overflows")
+			}
+			total += output.Value
+		}
+		break
+	case TypeCreateMultisig:
+		if len(this.To) != 0 {
+			return errors.New("to address must be blank for a multisig creation")
+		}
+		if this.Value != 0 {
+			return errors.New("value must be zero for a multisig creation")
+		}
+		if len(this.Signers) == 0 || len(this.Signers) > MaxMultisigSigners {
+			return errors.Errorf("multisig must have between 1 and %d signers", MaxMultisigSigners)
+		}
+		if this.Threshold <= 0 || this.Threshold > len(this.Signers) {
+			return errors.New("threshold must be between 1 and the number of signers")
+		}
+		signers := make(map[string]bool)
+		for _, signer := range this.Signers {
+			if len(signer) != crypto.AddressLength*2 {
+				return errors.Errorf("invalid signer address [signer=%s]", signer)
+			}
+			if signers[signer] {
+				return errors.Errorf("duplicate signer address [signer=%s]", signer)
+			}
+			signers[signer] = true
+		}
+		if len(this.Signatures) > 0 {
+			return errors.New("a multisig creation is signed by its creator")
+		}
+		break
+	case TypeLockTokens:
+		if len(this.To) != crypto.AddressLength*2 && this.Name == "" {
+			return errors.New("invalid to address")
+		}
+		if this.Value <= 0 {
+			return errors.New("value cannot be less than or equal to zero")
+		}
+		if this.UnlockTime <= this.Time {
+			return errors.New("unlock time must be after the transaction time")
+		}
+		break
+	case TypeRegisterName, TypeTransferName, TypeReleaseName:
+		if this.Type == TypeTransferName {
+			if len(this.To) != crypto.AddressLength*2 {
+				return errors.New("invalid to address")
+			}
+		} else if len(this.To) != 0 {
+			return errors.New("to address must be blank for a name registration or release")
+		}
+		if this.Value != 0 {
+			return errors.New("value must be zero for a name registry transaction")
+		}
+		if this.Name == "" {
+			return errors.New("name registry transaction must have a name")
+		}
+		break
+	case TypeStake, TypeUnstake:
+		if len(this.To) != crypto.AddressLength*2 {
+			return errors.New("invalid delegate address")
+		}
+		if this.Value <= 0 {
+			return errors.New("value cannot be less than or equal to zero")
+		}
+		break
+	case TypeProposeParameter:
+		if IsDelegateParameter(this.Parameter) {
+			if len(this.To) != crypto.AddressLength*2 {
+				return errors.New("invalid delegate address")
+			}
+		} else if len(this.To) != 0 {
+			return errors.New("to address must be blank for a proposal")
+		}
+		err := ValidateParameter(this.Parameter, this.Value)
+		if err != nil {
+			return err
+		}
+		if this.ActivationTime < this.Time+int64(MinActivationDelay/time.Millisecond) {
+			return errors.Errorf("activation must be at least %v after the proposal", MinActivationDelay)
+		}
+		break
+	case TypeVote:
+		if len(this.To) != 0 {
+			return errors.New("to address must be blank for a vote")
+		}
+		if this.Value != 0 {
+			return errors.New("value must be zero for a vote, it is weighted by the stake")
+		}
+		if len(this.Proposal) != crypto.HashLength*2 {
+			return errors.New("invalid proposal hash")
+		}
+		break
 	}
 
 	// Hash ok?
@@ -748,32 +1377,55 @@ func (this Transaction) Verify() error {
 		return errors.New("invalid hash")
 	}
 
+	// Cosigned? Which signers are required depends on the from account, see VerifySignatures.
+	if len(this.Signatures) > 0 {
+		signers := make(map[string]bool)
+		for _, signature := range this.Signatures {
+			address, err := this.recoverSigner(signature)
+			if err != nil {
+				return err
+			}
+			if signers[address] {
+				return errors.New("duplicate cosignature")
+			}
+			signers[address] = true
+		}
+		return nil
+	}
+
+	// Derived address from signature match from?
+	address, err := this.recoverSigner(this.Signature)
+	if err != nil {
+		return err
+	}
+	if address != this.From {
+		return errors.New("from address does not match the computed address from hash and signature")
+	}
+
+	return nil
+}
+
+// recoverSigner - Address that signed the hash
+func (this Transaction) recoverSigner(signature string) (string, error) {
 	hashBytes, err := hex.DecodeString(this.Hash)
 	if err != nil {
 		utils.Error("unable to decode hash", err)
-		return errors.New("unable to decode hash")
+		return "", errors.New("unable to decode hash")
 	}
-	signatureBytes, err := hex.DecodeString(this.Signature)
+	signatureBytes, err := hex.DecodeString(signature)
 	if err != nil {
 		utils.Error("unable to decode signature", err)
-		return errors.New("unable to decode signature")
+		return "", errors.New("unable to decode signature")
 	}
 	publicKeyBytes, err := crypto.ToPublicKey(hashBytes, signatureBytes)
 	if err != nil {
 		utils.Error("unable to generate public key from hash and signature", err)
-		return errors.New("unable to generate public key from hash and signature")
-	}
-
-	// Derived address from publicKeyBytes match from?
-	address := hex.EncodeToString(crypto.ToAddress(publicKeyBytes))
-	if address != this.From {
-		return errors.New("from address does not match the computed address from hash and signature")
+		return "", errors.New("unable to generate public key from hash and signature")
 	}
 	if !crypto.VerifySignature(publicKeyBytes, hashBytes, signatureBytes) {
-		return errors.New("invalid signature")
+		return "", errors.New("invalid signature")
 	}
-
-	return nil
+	return hex.EncodeToString(crypto.ToAddress(publicKeyBytes)), nil
 }
 
 // String
@@ -891,6 +1543,114 @@ func (this *Transaction) UnmarshalJSON(bytes []byte) error {
 		}
 		this.Hertz = int64(hertz)
 	}
+	if jsonMap["outputs"] != nil {
+		outputs, ok := jsonMap["outputs"].([]interface{})
+		if !ok {
+			return errors.Errorf("value for field 'outputs' must be an array")
+		}
+		this.Outputs = make([]Output, 0, len(outputs))
+		for _, value := range outputs {
+			output, ok := value.(map[string]interface{})
+			if !ok {
+				return errors.Errorf("value for field 'outputs' must be an array of objects")
+			}
+			to, ok := output["to"].(string)
+			if !ok {
+				return errors.Errorf("value for field 'outputs.to' must be a string")
+			}
+			outputValue, ok := output["value"].(float64)
+			if !ok {
+				return errors.Errorf("value for field 'outputs.value' must be a number")
+			}
+			this.Outputs = append(this.Outputs, Output{To: to, Value: int64(outputValue)})
+		}
+	}
+	if jsonMap["signers"] != nil {
+		signers, ok := jsonMap["signers"].([]interface{})
+		if !ok {
+			return errors.Errorf("value for field 'signers' must be an array")
+		}
+		this.Signers = make([]string, 0, len(signers))
+		for _, value := range signers {
+			signer, ok := value.(string)
+			if !ok {
+				return errors.Errorf("value for field 'signers' must be an array of strings")
+			}
+			this.Signers = append(this.Signers, signer)
+		}
+	}
+	if jsonMap["threshold"] != nil {
+		threshold, ok := jsonMap["threshold"].(float64)
+		if !ok {
+			return errors.Errorf("value for field 'threshold' must be a number")
+		}
+		this.Threshold = int(threshold)
+	}
+	if jsonMap["signatures"] != nil {
+		signatures, ok := jsonMap["signatures"].([]interface{})
+		if !ok {
+			return errors.Errorf("value for field 'signatures' must be an array")
+		}
+		this.Signatures = make([]string, 0, len(signatures))
+		for _, value := range signatures {
+			signature, ok := value.(string)
+			if !ok {
+				return errors.Errorf("value for field 'signatures' must be an array of strings")
+			}
+			this.Signatures = append(this.Signatures, signature)
+		}
+	}
+	if jsonMap["unlockTime"] != nil {
+		unlockTime, ok := jsonMap["unlockTime"].(float64)
+		if !ok {
+			return errors.Errorf("value for field 'unlockTime' must be a number")
+		}
+		this.UnlockTime = int64(unlockTime)
+	}
+	if jsonMap["name"] != nil {
+		this.Name, ok = jsonMap["name"].(string)
+		if !ok {
+			return errors.Errorf("value for field 'name' must be a string")
+		}
+	}
+	if jsonMap["fee"] != nil {
+		fee, ok := jsonMap["fee"].(float64)
+		if !ok {
+			return errors.Errorf("value for field 'fee' must be a number")
+		}
+		this.Fee = int64(fee)
+	}
+	if jsonMap["parameter"] != nil {
+		this.Parameter, ok = jsonMap["parameter"].(string)
+		if !ok {
+			return errors.Errorf("value for field 'parameter' must be a string")
+		}
+	}
+	if jsonMap["activationTime"] != nil {
+		activationTime, ok := jsonMap["activationTime"].(float64)
+		if !ok {
+			return errors.Errorf("value for field 'activationTime' must be a number")
+		}
+		this.ActivationTime = int64(activationTime)
+	}
+	if jsonMap["proposal"] != nil {
+		this.Proposal, ok = jsonMap["proposal"].(string)
+		if !ok {
+			return errors.Errorf("value for field 'proposal' must be a string")
+		}
+	}
+	if jsonMap["approve"] != nil {
+		this.Approve, ok = jsonMap["approve"].(bool)
+		if !ok {
+			return errors.Errorf("value for field 'approve' must be a boolean")
+		}
+	}
+	if jsonMap["networkId"] != nil {
+		this.NetworkId, ok = jsonMap["networkId"].(string)
+		if !ok {
+			return errors.Errorf("value for field 'networkId' must be a string")
+		}
+	}
 	if jsonMap["receipt"] != nil {
 		var receipt Receipt
 		b, err := json.Marshal(jsonMap["receipt"])
@@ -906,39 +1666,63 @@ func (this *Transaction) UnmarshalJSON(bytes []byte) error {
 // MarshalJSON
 func (this Transaction) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
-		Hash      string        `json:"hash"`
-		Type      byte          `json:"type"`
-		From      string        `json:"from"`
-		To        string        `json:"to,omitempty"`
-		Value     int64         `json:"value,omitempty"`
-		Code      string        `json:"code,omitempty"`
-		Abi       string        `json:"abi,omitempty"`
-		Method    string        `json:"method,omitempty"`
-		Params    []interface{} `json:"params,omitempty"`
-		Time      int64         `json:"time"`
-		Signature string        `json:"signature"`
-		Hertz     int64         `json:"hertz"`
-		Receipt   Receipt       `json:"receipt,omitempty"`
-		Gossip    []Rumor       `json:"gossip,omitempty"`
-		FromName  string        `json:"fromName,omitempty"`
-		ToName    string        `json:"toName,omitempty"`
+		Hash           string        `json:"hash"`
+		Type           byte          `json:"type"`
+		From           string        `json:"from"`
+		To             string        `json:"to,omitempty"`
+		Value          int64         `json:"value,omitempty"`
+		Code           string        `json:"code,omitempty"`
+		Abi            string        `json:"abi,omitempty"`
+		Method         string        `json:"method,omitempty"`
+		Params         []interface{} `json:"params,omitempty"`
+		Time           int64         `json:"time"`
+		Signature      string        `json:"signature"`
+		Hertz          int64         `json:"hertz"`
+		Outputs        []Output      `json:"outputs,omitempty"`
+		Signers        []string      `json:"signers,omitempty"`
+		Threshold      int           `json:"threshold,omitempty"`
+		Signatures     []string      `json:"signatures,omitempty"`
+		UnlockTime     int64         `json:"unlockTime,omitempty"`
+		Name           string        `json:"name,omitempty"`
+		Fee            int64         `json:"fee,omitempty"`
+		Parameter      string        `json:"parameter,omitempty"`
+		ActivationTime int64         `json:"activationTime,omitempty"`
+		Proposal       string        `json:"proposal,omitempty"`
+		Approve        bool          `json:"approve,omitempty"`
+		NetworkId      string        `json:"networkId,omitempty"`
+		Receipt        Receipt       `json:"receipt,omitempty"`
+		Gossip         []Rumor       `json:"gossip,omitempty"`
+		FromName       string        `json:"fromName,omitempty"`
+		ToName         string        `json:"toName,omitempty"`
 	}{
-		Hash:      this.Hash,
-		Type:      this.Type,
-		From:      this.From,
-		To:        this.To,
-		Value:     this.Value,
-		Code:      this.Code,
-		Abi:       this.Abi,
-		Method:    this.Method,
-		Params:    this.Params,
-		Time:      this.Time,
-		Signature: this.Signature,
-		Hertz:     this.Hertz,
-		Receipt:   this.Receipt,
-		Gossip:    this.Gossip,
-		FromName:  this.FromName,
-		ToName:    this.ToName,
+		Hash:           this.Hash,
+		Type:           this.Type,
+		From:           this.From,
+		To:             this.To,
+		Value:          this.Value,
+		Code:           this.Code,
+		Abi:            this.Abi,
+		Method:         this.Method,
+		Params:         this.Params,
+		Time:           this.Time,
+		Signature:      this.Signature,
+		Hertz:          this.Hertz,
+		Outputs:        this.Outputs,
+		Signers:        this.Signers,
+		Threshold:      this.Threshold,
+		Signatures:     this.Signatures,
+		UnlockTime:     this.UnlockTime,
+		Name:           this.Name,
+		Fee:            this.Fee,
+		Parameter:      this.Parameter,
+		ActivationTime: this.ActivationTime,
+		Proposal:       this.Proposal,
+		Approve:        this.Approve,
+		NetworkId:      this.NetworkId,
+		Receipt:        this.Receipt,
+		Gossip:         this.Gossip,
+		FromName:       this.FromName,
+		ToName:         this.ToName,
 	})
 }
 
@@ -947,16 +1731,9 @@ func (this Transaction) Equals(other string) bool {
 	return this.Hash == other
 }
 
+// checkTime - How far a transaction may be ahead is up to the delegate that receives it, by its own clock
 func checkTime(txTime int64) (int64, error) {
-	// Adding "future times managed by the constant"
-	now := time.Now()
-	futureTime := now.Add(TxFutureLimit)
-	nowWithFutureLimit := utils.ToMilliSeconds(futureTime)
-
-	if nowWithFutureLimit < txTime {
-		delta := time.Millisecond * time.Duration(txTime-utils.ToMilliSeconds(now))
-		return txTime, errors.Errorf(fmt.Sprintf("transaction time cannot be this far in the future (Ahead by: %v )", delta))
-	} else if txTime < 0 {
+	if txTime < 0 {
 		return txTime, errors.Errorf("transaction time cannot be negative")
 	}
 	//TODO: need to have a limit check here that it is not older than some value whether that is static at startup or relative to current time.
@@ -974,6 +1751,8 @@ func (this *Transaction) setTransients(txn *badger.Txn) {
 	toAccount, err := ToAccountByAddress(txn, this.To)
 	if err == nil {
 		this.ToName = toAccount.Name
+	} else if this.To == "" && (this.Type == TypeTransferTokens || this.Type == TypeLockTokens) {
+		this.ToName = this.Name
 	}
 	receipt, err := ToReceiptFromKey(txn, []byte(fmt.Sprintf("table-receipt-"+this.Hash)))
 	if err == nil {
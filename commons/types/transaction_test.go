@@ -18,6 +18,7 @@ package types
 
 import (
 	"fmt"
+	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"testing"
 	"time"
@@ -99,7 +100,280 @@ func TestNewHash(t *testing.T) {
 	}
 }
 
+//TestBatchTransferTransaction
+func TestBatchTransferTransaction(t *testing.T) {
+	d, _ := time.Parse(time.RFC3339, "2018-07-09T15:04:05Z")
+	outputs := []Output{
+		{To: "d70613f93152c84050e7826c4e2b0cc02c1c3b99", Value: 10},
+		{To: "a491fce401f84ecadd8fd3ac58d78fd7576c5a4b", Value: 20},
+	}
+	tx, err := NewBatchTransferTokensTransaction("0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a", "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", outputs, 0, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify batch transaction", err)
+	}
+	if tx.TotalValue() != 30 {
+		t.Errorf("TotalValue returning invalid value: %d", tx.TotalValue())
+	}
+
+	testTx, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(testTx.Outputs, tx.Outputs) == false {
+		t.Errorf("outputs not equal after JSON.\nGot: %v\nExpected: %v", testTx.Outputs, tx.Outputs)
+	}
+	if err := testTx.Verify(); err != nil {
+		t.Error("cannot verify batch transaction after JSON", err)
+	}
+
+	// Outputs are covered by the hash.
+	testTx.Outputs[1].Value = 2000
+	if testTx.Verify() == nil {
+		t.Error("verified a batch transaction with a changed output")
+	}
+
+	// Duplicate recipients.
+	tx, err = NewBatchTransferTokensTransaction("0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a", "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", []Output{outputs[0], outputs[0]}, 0, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Verify() == nil {
+		t.Error("verified a batch transaction with duplicate outputs")
+	}
+}
+
+func TestLockTokensTransaction(t *testing.T) {
+	d, _ := time.Parse(time.RFC3339, "2018-07-09T15:04:05Z")
+	unlock := utils.ToMilliSeconds(d.Add(24 * time.Hour * 365))
+	tx, err := NewLockTokensTransaction("0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a", "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "d70613f93152c84050e7826c4e2b0cc02c1c3b99", 10, 0, utils.ToMilliSeconds(d), unlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify lock transaction", err)
+	}
+
+	testTx, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testTx.UnlockTime != unlock {
+		t.Errorf("unlock time not equal after JSON [got=%d, expected=%d]", testTx.UnlockTime, unlock)
+	}
+
+	// Unlock time is covered by the hash.
+	testTx.UnlockTime = utils.ToMilliSeconds(d)
+	if testTx.Verify() == nil {
+		t.Error("verified a lock transaction with a changed unlock time")
+	}
+
+	// Unlock time in the past.
+	_, err = NewLockTokensTransaction("0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a", "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "d70613f93152c84050e7826c4e2b0cc02c1c3b99", 10, 0, utils.ToMilliSeconds(d), utils.ToMilliSeconds(d))
+	if err == nil {
+		t.Error("created a lock transaction ending at its own time")
+	}
+}
+
+func TestNameTransactions(t *testing.T) {
+	defer destruct()
+	d, _ := time.Parse(time.RFC3339, "2018-07-09T15:04:05Z")
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	tx, err := NewRegisterNameTransaction(privateKey, from, "alice", utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify name registration", err)
+	}
+	testTx, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testTx.Name != "alice" {
+		t.Errorf("name not equal after JSON [got=%s]", testTx.Name)
+	}
+
+	// Name is covered by the hash.
+	testTx.Name = "carol"
+	if testTx.Verify() == nil {
+		t.Error("verified a name registration with a changed name")
+	}
+
+	tx, err = NewTransferTokensToNameTransaction(privateKey, from, "alice", 10, 5, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify transfer to a name", err)
+	}
+	if tx.Hertz != 5 {
+		t.Errorf("hertz not set on transfer to a name [hertz=%d]", tx.Hertz)
+	}
+
+	// No to key until the name resolves.
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	if err := tx.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := txn.Get([]byte(tx.ToKey())); err != badger.ErrKeyNotFound {
+		t.Errorf("persisted a to key without to address [err=%v]", err)
+	}
+
+	if _, err := NewRegisterNameTransaction(privateKey, from, "Alice", utils.ToMilliSeconds(d)); err == nil {
+		t.Error("created a name registration with an invalid name")
+	}
+	tx, err = NewTransferNameTransaction(privateKey, from, "", "alice", utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Verify() == nil {
+		t.Error("verified a name transfer without to address")
+	}
+}
+
+func TestTransactionFee(t *testing.T) {
+	d, _ := time.Parse(time.RFC3339, "2018-07-09T15:04:05Z")
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	tx, err := NewTransferTokensTransaction(privateKey, "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "d70613f93152c84050e7826c4e2b0cc02c1c3b99", 10, 0, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := tx.Hash
+	if err := tx.SetFee(5, privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash == hash {
+		t.Error("fee is not covered by the hash")
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify transaction with a fee", err)
+	}
+	testTx, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testTx.Fee != 5 || testTx.TotalFee(1000, 2) != 2005 {
+		t.Errorf("invalid fee after JSON [fee=%d]", testTx.Fee)
+	}
+
+	// No fee, same hash as before fees existed.
+	if err := tx.SetFee(0, privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash != hash {
+		t.Error("a transaction without fee changed hash")
+	}
+	if err := tx.SetFee(-1, privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Verify() == nil {
+		t.Error("verified a transaction with a negative fee")
+	}
+}
+
 //TestPrintTransaction --helper test to print out a fresh transaction
+func TestStakeTransaction(t *testing.T) {
+	d, _ := time.Parse(time.RFC3339, "2018-07-09T15:04:05Z")
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	tx, err := NewStakeTransaction(privateKey, from, from, 10, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal("cannot create a transaction staking with the sender", err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify stake transaction", err)
+	}
+	tx, err = NewUnstakeTransaction(privateKey, from, "d70613f93152c84050e7826c4e2b0cc02c1c3b99", 10, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type != TypeUnstake {
+		t.Errorf("invalid unstake transaction type: %d", tx.Type)
+	}
+
+	tx, _ = NewStakeTransaction(privateKey, from, "", 10, utils.ToMilliSeconds(d))
+	if tx.Verify() == nil {
+		t.Error("verified a stake transaction without a delegate")
+	}
+	tx, _ = NewStakeTransaction(privateKey, from, from, 0, utils.ToMilliSeconds(d))
+	if tx.Verify() == nil {
+		t.Error("verified a stake transaction without a value")
+	}
+}
+
+func TestGovernanceTransactions(t *testing.T) {
+	d, _ := time.Parse(time.RFC3339, "2018-07-09T15:04:05Z")
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	activation := utils.ToMilliSeconds(d.Add(MinActivationDelay))
+	tx, err := NewProposeParameterTransaction(privateKey, from, ParameterHertzPrice, 2, activation, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify proposal", err)
+	}
+	testTx, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testTx.Parameter != ParameterHertzPrice || testTx.ActivationTime != activation {
+		t.Errorf("proposal not equal after JSON [parameter=%s, activationTime=%d]", testTx.Parameter, testTx.ActivationTime)
+	}
+
+	// Activation is covered by the hash, and must leave time to vote.
+	testTx.ActivationTime = activation - 1
+	if testTx.Verify() == nil {
+		t.Error("verified a proposal with a changed activation time")
+	}
+	tx, _ = NewProposeParameterTransaction(privateKey, from, ParameterHertzPrice, 2, activation-1, utils.ToMilliSeconds(d))
+	if tx.Verify() == nil {
+		t.Error("verified a proposal activating too soon")
+	}
+	tx, _ = NewProposeParameterTransaction(privateKey, from, "delegateCount", 2, activation, utils.ToMilliSeconds(d))
+	if tx.Verify() == nil {
+		t.Error("verified a proposal of an unknown parameter")
+	}
+
+	// A delegate proposal names the delegate as its to address.
+	tx, err = NewProposeDelegateTransaction(privateKey, from, ParameterAddDelegate, "d70613f93152c84050e7826c4e2b0cc02c1c3b99", activation, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify delegate proposal", err)
+	}
+	tx, _ = NewProposeDelegateTransaction(privateKey, from, ParameterRemoveDelegate, "", activation, utils.ToMilliSeconds(d))
+	if tx.Verify() == nil {
+		t.Error("verified a delegate proposal without a delegate")
+	}
+	tx, _ = NewProposeParameterTransaction(privateKey, from, ParameterAddDelegate, 1, activation, utils.ToMilliSeconds(d))
+	if tx.Verify() == nil {
+		t.Error("verified a delegate proposal with a value")
+	}
+
+	tx, err = NewVoteTransaction(privateKey, from, "e0b5a1f4d1d1d2e2a1e54a0d1ad3b3c8b4c6a1f6b2c5cd5d51d2ed7c2a4b7e06", true, utils.ToMilliSeconds(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal("cannot verify vote", err)
+	}
+	testTx, err = ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testTx.Approve = false
+	if testTx.Verify() == nil {
+		t.Error("verified a vote with a changed approval")
+	}
+}
+
 func TestPrintTransaction(t *testing.T) {
 	var privateKey = "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
 	var from = "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
@@ -438,6 +712,29 @@ func TestTransactionCalculateHash(t *testing.T) {
 	t.Skip("Need a unit test for this...")
 }
 
+//TestTransactionNewHashFields
+func TestTransactionNewHashFields(t *testing.T) {
+	base := Transaction{Type: TypeTransferTokens, From: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", To: "d70613f93152c84050e7826c4e2b0cc02c1c3b99", Value: 1, Time: 1}
+	named := base
+	named.Name = "a"
+	named.Fee = 1
+	shifted := base
+	shifted.Name = "a\x01\x00\x00\x00\x00\x00\x00\x00"
+	parameter := base
+	parameter.Parameter = "a"
+	hashes := make(map[string]bool)
+	for _, transaction := range []Transaction{base, named, shifted, parameter} {
+		hash, err := transaction.NewHash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hashes[hash] {
+			t.Errorf("transactions with different fields have the same hash [hash=%s]", hash)
+		}
+		hashes[hash] = true
+	}
+}
+
 //TestTransactionEquals
 func TestTransactionEquals(t *testing.T) {
 	// TODO: Transaction.Equals()
@@ -0,0 +1,124 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock - Source of time for consensus code so timeouts can be driven by a simulation
+type Clock interface {
+	Now() time.Time
+	After(duration time.Duration) <-chan time.Time
+}
+
+// NewSystemClock
+func NewSystemClock() Clock {
+	return &systemClock{}
+}
+
+// systemClock
+type systemClock struct {
+}
+
+// Now
+func (this *systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After
+func (this *systemClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
+
+// NewVirtualClock - Clock that only moves when advanced
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+// VirtualClock
+type VirtualClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []*waiter
+}
+
+// waiter
+type waiter struct {
+	until   time.Time
+	channel chan time.Time
+}
+
+// Now
+func (this *VirtualClock) Now() time.Time {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.now
+}
+
+// After - Fires once the clock has been advanced past the duration
+func (this *VirtualClock) After(duration time.Duration) <-chan time.Time {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	channel := make(chan time.Time, 1)
+	if duration <= 0 {
+		channel <- this.now
+		return channel
+	}
+	this.waiters = append(this.waiters, &waiter{until: this.now.Add(duration), channel: channel})
+	return channel
+}
+
+// Advance - Moves the clock forward and fires every waiter that is due
+func (this *VirtualClock) Advance(duration time.Duration) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.now = this.now.Add(duration)
+	waiters := make([]*waiter, 0, len(this.waiters))
+	for _, waiter := range this.waiters {
+		if waiter.until.After(this.now) {
+			waiters = append(waiters, waiter)
+			continue
+		}
+		waiter.channel <- this.now
+	}
+	this.waiters = waiters
+}
+
+// Next - When the earliest pending After call fires, false when none is pending
+func (this *VirtualClock) Next() (time.Time, bool) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if len(this.waiters) == 0 {
+		return time.Time{}, false
+	}
+	next := this.waiters[0].until
+	for _, waiter := range this.waiters[1:] {
+		if waiter.until.Before(next) {
+			next = waiter.until
+		}
+	}
+	return next, true
+}
+
+// Waiters - Number of pending After calls
+func (this *VirtualClock) Waiters() int {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return len(this.waiters)
+}
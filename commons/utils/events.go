@@ -43,15 +43,20 @@ type EventManager struct {
 // Events - Singleton to get the events manager instance
 func Events() *EventManager {
 	eventManagerOnce.Do(func() {
-		eventManagerInstance = &EventManager{
-			events:     make(map[string]*event),
-			eventsSync: sync.RWMutex{},
-		}
+		eventManagerInstance = NewEventManager()
 	})
 
 	return eventManagerInstance
 }
 
+// NewEventManager - Events manager that is not shared with the rest of the process
+func NewEventManager() *EventManager {
+	return &EventManager{
+		events:     make(map[string]*event),
+		eventsSync: sync.RWMutex{},
+	}
+}
+
 // On - Tells EventManager to add a subscriber for an event
 func (thisRef *EventManager) On(eventName string, eventHandler EventHandler) {
 	thisRef.addEventIfNotExists(eventName)
@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+
+curl 'http://127.0.0.1:1975/v1/parameters'
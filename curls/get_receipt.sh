@@ -1,3 +1,3 @@
 #!/usr/bin/env bash
 
-curl 'http://127.0.0.1:3502/v1/receipts/635afdaa172b3bde30ec6b57444c914095817b7b7a94731ead0085b2093e0b2b'
+curl 'http://127.0.0.1:1975/v1/receipts/635afdaa172b3bde30ec6b57444c914095817b7b7a94731ead0085b2093e0b2b'
@@ -19,25 +19,24 @@ package dapos
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/dgraph-io/badger"
-	"github.com/dispatchlabs/disgo/commons/services"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/dispatchlabs/disgo/disgover"
 )
 
 // GetDelegateNodes
 func (this *DAPoSService) GetDelegateNodes() *types.Response {
 
 	// Find nodes.
-	cDelegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	cDelegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
 	if err != nil {
 		utils.Error(err)
 		return types.NewResponseWithError(err)
 	}
 
-	txn := services.NewTxn(false)
+	txn := this.db.NewTxn(false)
 	defer txn.Discard()
 	//get stored delegates
 	sDelegates, err := types.ToNodesByType(txn, types.TypeDelegate)
@@ -70,13 +69,13 @@ func (this *DAPoSService) GetDelegateNodes() *types.Response {
 
 // GetReceipt
 func (this *DAPoSService) GetReceipt(transactionHash string) *types.Response {
-	txn := services.NewTxn(false)
+	txn := this.db.NewTxn(false)
 	defer txn.Discard()
 	response := types.NewResponse()
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
-		receipt, err := types.ToReceiptFromCache(services.GetCache(), transactionHash)
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
+		receipt, err := types.ToReceiptFromCache(this.db.GetCache(), transactionHash)
 		if err != nil {
 			receipt, err = types.ToReceiptFromKey(txn, []byte(fmt.Sprintf("table-receipt-" +transactionHash)))
 			if err != nil {
@@ -102,14 +101,50 @@ func (this *DAPoSService) GetReceipt(transactionHash string) *types.Response {
 	return response
 }
 
+// GetReceipts
+func (this *DAPoSService) GetReceipts(status, page, size string) *types.Response {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	pageSize, err := strconv.Atoi(size)
+	if err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+
+	// Delegate?
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
+		response.Data, response.Paging, err = types.ToReceiptsByStatus(txn, status, pageNumber, pageSize)
+		if err != nil {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+		} else {
+			response.Status = types.StatusOk
+		}
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("GetReceipts [status=%s, responseStatus=%s]", status, response.Status))
+
+	return response
+}
+
 // GetAccount
 func (this *DAPoSService) GetAccount(address string) *types.Response {
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 	response := types.NewResponse()
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 		account, err := types.ToAccountByAddress(txn, address)
 		if err != nil {
 			if err == badger.ErrKeyNotFound {
@@ -118,6 +153,7 @@ func (this *DAPoSService) GetAccount(address string) *types.Response {
 				response.Status = types.StatusInternalError
 			}
 		} else {
+			account.ShowBalancesAt(utils.ToMilliSeconds(this.clock.Now()))
 			response.Data = account
 			response.Status = types.StatusOk
 		}
@@ -130,12 +166,165 @@ func (this *DAPoSService) GetAccount(address string) *types.Response {
 	return response
 }
 
+// GetDelegateStake - Total stake bonded to the delegate
+func (this *DAPoSService) GetDelegateStake(delegate string) *types.Response {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
+		delegateStake, err := types.ToDelegateStake(txn, delegate)
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				response.Status = types.StatusNotFound
+			} else {
+				response.Status = types.StatusInternalError
+			}
+		} else {
+			response.Data = delegateStake
+			response.Status = types.StatusOk
+		}
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("retrieved delegate stake [delegate=%s, status=%s]", delegate, response.Status))
+
+	return response
+}
+
+// GetDelegateStakes - Delegates with stake, most staked first
+func (this *DAPoSService) GetDelegateStakes() *types.Response {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
+		delegateStakes, err := types.ToDelegateStakes(txn)
+		if err != nil {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+		} else {
+			response.Data = delegateStakes
+			response.Status = types.StatusOk
+		}
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("retrieved delegate stakes [status=%s]", response.Status))
+
+	return response
+}
+
+// GetParameters - Network parameters in use now
+func (this *DAPoSService) GetParameters() *types.Response {
+	response := types.NewResponse()
+
+	// Delegate?
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
+		parameters := this.parameters()
+		response.Data = &parameters
+		response.Status = types.StatusOk
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("retrieved parameters [status=%s]", response.Status))
+
+	return response
+}
+
+// GetProposal
+func (this *DAPoSService) GetProposal(hash string) *types.Response {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
+		proposal, err := types.ToProposal(txn, hash)
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				response.Status = types.StatusNotFound
+			} else {
+				response.Status = types.StatusInternalError
+			}
+		} else {
+			response.Data = proposal
+			response.Status = types.StatusOk
+		}
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("retrieved proposal [hash=%s, status=%s]", hash, response.Status))
+
+	return response
+}
+
+// GetProposals - Proposals in order of activation
+func (this *DAPoSService) GetProposals() *types.Response {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
+		proposals, err := types.ToProposals(txn)
+		if err != nil {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+		} else {
+			response.Data = proposals
+			response.Status = types.StatusOk
+		}
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("retrieved proposals [status=%s]", response.Status))
+
+	return response
+}
+
+// GetAccountByName - The account that holds the registered name
+func (this *DAPoSService) GetAccountByName(name string) *types.Response {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
+		account, err := types.ToAccountByName(txn, name)
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				response.Status = types.StatusNotFound
+			} else {
+				response.Status = types.StatusInternalError
+			}
+		} else {
+			account.ShowBalancesAt(utils.ToMilliSeconds(this.clock.Now()))
+			response.Data = account
+			response.Status = types.StatusOk
+		}
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("retrieved account by name [name=%s, status=%s]", name, response.Status))
+
+	return response
+}
+
 // NewTransaction
 func (this *DAPoSService) NewTransaction(transaction *types.Transaction) *types.Response {
 	response := types.NewResponse()
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 		response = this.startGossiping(transaction)
 	} else {
 		response.Status = types.StatusNotDelegate
@@ -148,17 +337,25 @@ func (this *DAPoSService) NewTransaction(transaction *types.Transaction) *types.
 
 // GetTransaction
 func (this *DAPoSService) GetTransaction(hash string) *types.Response {
-	txn := services.NewTxn(false)
+	txn := this.db.NewTxn(false)
 	defer txn.Discard()
 	response := types.NewResponse()
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 		transaction, err := types.ToTransactionByHash(txn, hash)
 		if err != nil {
 			if err == badger.ErrKeyNotFound {
-				tx, _ := types.ToTransactionFromCache(services.GetCache(), hash)
+				tx, _ := types.ToTransactionFromCache(this.db.GetCache(), hash)
 				if tx != nil {
+
+					// Still in flight, show the timeline so far.
+					receipt, err := types.ToReceiptFromCache(this.db.GetCache(), hash)
+					if err == nil {
+						inFlight := *tx
+						inFlight.Receipt = *receipt
+						tx = &inFlight
+					}
 					response.Data = tx
 					response.Status = types.StatusOk
 				} else {
@@ -182,7 +379,7 @@ func (this *DAPoSService) GetTransaction(hash string) *types.Response {
 
 // GetTransactions
 func (this *DAPoSService) GetTransactions(page,size,start string) *types.Response {
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 	response := types.NewResponse()
 	var err error
@@ -200,7 +397,7 @@ func (this *DAPoSService) GetTransactions(page,size,start string) *types.Respons
 	}
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 
 		response.Data, response.Paging, err = types.TransactionPaging(txn, start,pageNumber,pageSize)
 		if err != nil {
@@ -221,7 +418,7 @@ func (this *DAPoSService) GetTransactions(page,size,start string) *types.Respons
 
 // GetTransactionsByFromAddress
 func (this *DAPoSService) GetTransactionsByFromAddress(address,page,size,start string) *types.Response {
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 	response := types.NewResponse()
 	pageNumber, err := strconv.Atoi(page)
@@ -238,7 +435,7 @@ func (this *DAPoSService) GetTransactionsByFromAddress(address,page,size,start s
 	}
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 		response.Data, err = types.ToTransactionsByFromAddress(txn, address, start, pageNumber, pageSize)
 		if err != nil {
 			response.Status = types.StatusInternalError
@@ -258,7 +455,7 @@ func (this *DAPoSService) GetTransactionsByFromAddress(address,page,size,start s
 
 // GetTransactionsByToAddress
 func (this *DAPoSService) GetTransactionsByToAddress(address,page,size,start string ) *types.Response {
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 	response := types.NewResponse()
 	pageNumber, err := strconv.Atoi(page)
@@ -275,7 +472,7 @@ func (this *DAPoSService) GetTransactionsByToAddress(address,page,size,start str
 	}
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 		response.Data, err = types.ToTransactionsByToAddress(txn, address, start,pageNumber,pageSize)
 		if err != nil {
 			response.Status = types.StatusInternalError
@@ -293,9 +490,49 @@ func (this *DAPoSService) GetTransactionsByToAddress(address,page,size,start str
 	return response
 }
 
+// DumpQueue - Mempool counts and the transactions waiting for execution
 func (this *DAPoSService) DumpQueue() *types.Response {
 	response := types.NewResponse()
-	response.Data = this.gossipQueue.Dump()
+	response.Data = &Queue{
+		Mempool:   this.mempool.Stats(),
+		Execution: this.gossipQueue.Dump(),
+	}
+	return response
+}
+
+// GetTimeouts
+func (this *DAPoSService) GetTimeouts() *types.Response {
+	delegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
+	if err != nil {
+		return types.NewResponseWithError(err)
+	}
+	timeouts := &Timeouts{
+		GossipTimeout:    this.gossipTimeout(),
+		TxReceiveTimeout: this.txReceiveTimeout(this.parameters()),
+		ExecutionDelay:   int64(this.executionDelay(len(delegates)) / time.Millisecond),
+		Delegates:        make([]DelegateLatency, 0),
+	}
+	for _, address := range this.latency.Addresses() {
+		p50, samples := this.latency.Percentile(address, 50)
+		p95, _ := this.latency.Percentile(address, 95)
+		p99, _ := this.latency.Percentile(address, 99)
+		timeouts.Delegates = append(timeouts.Delegates, DelegateLatency{
+			Address: address,
+			Samples: samples,
+			P50:     int64(p50 / time.Millisecond),
+			P95:     int64(p95 / time.Millisecond),
+			P99:     int64(p99 / time.Millisecond),
+		})
+	}
+	response := types.NewResponse()
+	response.Data = timeouts
+	return response
+}
+
+// GetReputations - Scores of the delegates gossiped with and whether they are banned
+func (this *DAPoSService) GetReputations() *types.Response {
+	response := types.NewResponse()
+	response.Data = this.reputation.Reputations()
 	return response
 }
 
@@ -306,7 +543,7 @@ func (this *DAPoSService) ToBeSupported() *types.Response {
 }
 
 func (this *DAPoSService) GetAccounts(page, size, start string) *types.Response {
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 	response := types.NewResponse()
 	var err error
@@ -324,7 +561,7 @@ func (this *DAPoSService) GetAccounts(page, size, start string) *types.Response
 	}
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 
 		response.Data, err = types.AccountPaging(txn, start, pageNumber, pageSize)
 		if err != nil {
@@ -344,7 +581,7 @@ func (this *DAPoSService) GetAccounts(page, size, start string) *types.Response
 }
 
 func (this *DAPoSService) GetGossips(page string) *types.Response {
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 	response := types.NewResponse()
 	var err error
@@ -356,7 +593,7 @@ func (this *DAPoSService) GetGossips(page string) *types.Response {
 	}
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 
 		response.Data, err = types.GossipPaging(pageNumber, txn)
 		if err != nil {
@@ -377,12 +614,12 @@ func (this *DAPoSService) GetGossips(page string) *types.Response {
 
 // GetGossip
 func (this *DAPoSService) GetGossip(hash string) *types.Response {
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 	response := types.NewResponse()
 
 	// Delegate?
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 		gossip, err := types.ToGossipByTransactionHash(txn, hash)
 		if err != nil {
 			if err == badger.ErrKeyNotFound {
@@ -0,0 +1,102 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// gossipBatcher - Collects gossips per delegate for a short window and sends them as one GossipBatchGrpc
+type gossipBatcher struct {
+	mutex   sync.Mutex
+	clock   utils.Clock
+	window  time.Duration
+	size    int // Zero or less, only the window closes a batch
+	pending map[string]*gossipBatch
+	sending int32 // Batches being sent
+	send    func(node types.Node, gossips []*types.Gossip)
+}
+
+// gossipBatch - Gossips waiting for one delegate, at most one per transaction
+type gossipBatch struct {
+	node    types.Node
+	hashes  map[string]int
+	gossips []*types.Gossip
+}
+
+// newGossipBatcher
+func newGossipBatcher(clock utils.Clock, window time.Duration, size int, send func(node types.Node, gossips []*types.Gossip)) *gossipBatcher {
+	return &gossipBatcher{clock: clock, window: window, size: size, pending: make(map[string]*gossipBatch), send: send}
+}
+
+// Add - Queues a copy of the gossip for the delegate (the cached gossip keeps collecting rumors), the batch goes
+// out when the window closes or it is full
+func (this *gossipBatcher) Add(node types.Node, gossip *types.Gossip) {
+	copied := *gossip
+	copied.Rumors = append([]types.Rumor(nil), gossip.Rumors...)
+
+	this.mutex.Lock()
+	batch, ok := this.pending[node.Address]
+	if !ok {
+		batch = &gossipBatch{node: node, hashes: make(map[string]int)}
+		this.pending[node.Address] = batch
+		go func() {
+			<-this.clock.After(this.window)
+			this.flush(node.Address, batch)
+		}()
+	}
+
+	// One gossip per transaction, keep the one carrying the most rumors.
+	if index, ok := batch.hashes[copied.Transaction.Hash]; ok {
+		if len(copied.Rumors) > len(batch.gossips[index].Rumors) {
+			batch.gossips[index] = &copied
+		}
+	} else {
+		batch.hashes[copied.Transaction.Hash] = len(batch.gossips)
+		batch.gossips = append(batch.gossips, &copied)
+	}
+	full := this.size > 0 && len(batch.gossips) >= this.size
+	this.mutex.Unlock()
+
+	if full {
+		this.flush(node.Address, batch)
+	}
+}
+
+// flush - Sends the batch unless it was already sent
+func (this *gossipBatcher) flush(address string, batch *gossipBatch) {
+	this.mutex.Lock()
+	if this.pending[address] != batch {
+		this.mutex.Unlock()
+		return
+	}
+	delete(this.pending, address)
+	atomic.AddInt32(&this.sending, 1)
+	this.mutex.Unlock()
+	this.send(batch.node, batch.gossips)
+	atomic.AddInt32(&this.sending, -1)
+}
+
+// Sending - Batches being sent, the ones waiting for their window are not
+func (this *gossipBatcher) Sending() int {
+	return int(atomic.LoadInt32(&this.sending))
+}
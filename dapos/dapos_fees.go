@@ -0,0 +1,103 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"math/big"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// distributeFee - Splits the fee between the delegates of the chain (see types.ToDelegateAddresses) in proportion to
+// their stake, equally while nobody staked. The remainder goes one token each starting from a delegate picked by the
+// transaction hash. Nothing is distributed on a chain without delegates, its genesis lists none and no proposal added
+// one, the fee is burnt.
+func (this *DAPoSService) distributeFee(txn *badger.Txn, transaction *types.Transaction, fee int64) ([]types.Reward, error) {
+	addresses, err := types.ToDelegateAddresses(txn, transaction.Time)
+	if err != nil {
+		return nil, err
+	}
+	rewards := make([]types.Reward, 0)
+	if len(addresses) == 0 {
+		return rewards, nil
+	}
+
+	// Stake of each delegate.
+	count := int64(len(addresses))
+	stakes := make([]int64, count)
+	var totalStake int64
+	for i, address := range addresses {
+		delegateStake, err := types.ToDelegateStake(txn, address)
+		if err != nil {
+			if err != badger.ErrKeyNotFound {
+				return nil, err
+			}
+			continue
+		}
+		stakes[i] = delegateStake.Total
+		totalStake += delegateStake.Total
+	}
+
+	// Shares.
+	shares := make([]int64, count)
+	var distributed int64
+	for i := range addresses {
+		if totalStake > 0 {
+			share := new(big.Int).Mul(big.NewInt(fee), big.NewInt(stakes[i]))
+			shares[i] = share.Div(share, big.NewInt(totalStake)).Int64()
+		} else {
+			shares[i] = fee / count
+		}
+		distributed += shares[i]
+	}
+	first := int64(0)
+	if len(transaction.Hash) > 0 {
+		first = int64(transaction.Hash[len(transaction.Hash)-1]) % count
+	}
+	for i := int64(0); i < fee-distributed; i++ {
+		shares[(first+i)%count]++
+	}
+
+	now := this.clock.Now()
+	for i, address := range addresses {
+		if shares[i] == 0 {
+			continue
+		}
+		account, err := types.ToAccountByAddress(txn, address)
+		if err != nil {
+			if err != badger.ErrKeyNotFound {
+				return nil, err
+			}
+			account = &types.Account{Address: address, Balance: big.NewInt(0), Created: now}
+		}
+		account.Balance.SetInt64(account.Balance.Int64() + shares[i])
+		account.Updated = now
+		err = account.Persist(txn)
+		if err != nil {
+			return nil, err
+		}
+
+		// The delegate finds the transaction in its history, with the reward in the receipt.
+		err = txn.Set([]byte(transaction.ToKeyFor(address)), []byte(transaction.Key()))
+		if err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, types.Reward{Delegate: address, Value: shares[i]})
+	}
+	return rewards, nil
+}
@@ -0,0 +1,148 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dvm"
+	"github.com/pkg/errors"
+)
+
+// createGenesis - Creates the genesis accounts and contracts in a new DB, a DB created from another genesis cannot be used
+func (this *DAPoSService) createGenesis() error {
+	genesis, err := types.ToGenesisFromConfig(this.config)
+	if err != nil {
+		return err
+	}
+	hash := genesis.Hash()
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+
+	// Already created?
+	existing, err := types.ToGenesis(txn)
+	if err == nil {
+		if existing.Hash() != hash {
+			return errors.Errorf("DB was created from another genesis [hash=%s, expected=%s]", existing.Hash(), hash)
+		}
+		this.setDefaults(genesis)
+		return nil
+	} else if err != badger.ErrKeyNotFound {
+		return err
+	}
+
+	// Genesis transaction? A DB from before genesis documents already has its account.
+	create := true
+	if this.config.GenesisFile == "" {
+		transaction, err := types.ToTransactionFromJson([]byte(this.config.GenesisTransaction))
+		if err != nil {
+			return err
+		}
+		_, err = types.ToTransactionByKey(txn, []byte(transaction.Key()))
+		if err == nil {
+			create = false
+		} else if err == badger.ErrKeyNotFound {
+			err = transaction.Set(txn, this.db.GetCache())
+			if err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+	}
+	if create {
+		err = this.createGenesisAccounts(txn, genesis)
+		if err != nil {
+			return err
+		}
+	}
+	err = genesis.Persist(txn)
+	if err != nil {
+		return err
+	}
+
+	// Contract code and storage are kept by the DVM. Installed before the genesis is committed, a boot that fails in
+	// between installs them again.
+	if create {
+		for _, contract := range genesis.Contracts {
+			code, err := hex.DecodeString(contract.Code)
+			if err != nil {
+				return err
+			}
+			err = dvm.GetDVMService().InstallContract(contract.Address, code, contract.Storage)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = txn.Commit(nil)
+	if err != nil {
+		return err
+	}
+	this.setDefaults(genesis)
+	utils.Info(fmt.Sprintf("created genesis [chainId=%s, hash=%s, allocations=%d, contracts=%d]", genesis.ChainId, hash, len(genesis.Allocations), len(genesis.Contracts)))
+	return nil
+}
+
+// createGenesisAccounts
+func (this *DAPoSService) createGenesisAccounts(txn *badger.Txn, genesis *types.Genesis) error {
+	accounts, err := genesis.ToAccounts()
+	if err != nil {
+		return err
+	}
+	for _, contract := range genesis.Contracts {
+		transaction, err := genesis.ContractTransaction(contract)
+		if err != nil {
+			return err
+		}
+		err = transaction.Persist(txn)
+		if err != nil {
+			return err
+		}
+	}
+	for _, account := range accounts {
+		account.Created = this.clock.Now()
+		account.Updated = account.Created
+		for _, lock := range account.Locks {
+			err = lock.Persist(txn, account.Address)
+			if err != nil {
+				return err
+			}
+		}
+		err = account.Set(txn, this.db.GetCache())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setDefaults - Parameters start from the genesis values
+func (this *DAPoSService) setDefaults(genesis *types.Genesis) {
+	this.parametersMutex.Lock()
+	defer this.parametersMutex.Unlock()
+	this.defaults = genesis.ToParameters()
+}
+
+// networkId - Of the genesis, DisGover reads it before DAPoS starts
+func (this *DAPoSService) networkId() string {
+	return this.disGover.ThisNode.NetworkId
+}
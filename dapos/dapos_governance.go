@@ -0,0 +1,159 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// parameters - Values in use now
+func (this *DAPoSService) parameters() types.Parameters {
+	return this.parametersAt(utils.ToMilliSeconds(this.clock.Now()))
+}
+
+// parametersAt - Values in use at the time (milliseconds), executions read them at the transaction's time so every
+// delegate uses the same
+func (this *DAPoSService) parametersAt(time int64) types.Parameters {
+	this.parametersMutex.RLock()
+	defer this.parametersMutex.RUnlock()
+	parameters := this.defaults
+	for _, proposal := range this.approved {
+		if proposal.ActivationTime > time {
+			break
+		}
+		parameters.Apply(proposal)
+	}
+	return parameters
+}
+
+// transactionCacheTtl
+func (this *DAPoSService) transactionCacheTtl() time.Duration {
+	return time.Duration(this.parameters().TransactionCacheTtl) * time.Millisecond
+}
+
+// receiptCacheTtl
+func (this *DAPoSService) receiptCacheTtl() time.Duration {
+	return time.Duration(this.parameters().ReceiptCacheTtl) * time.Millisecond
+}
+
+// gossipCacheTtl
+func (this *DAPoSService) gossipCacheTtl() time.Duration {
+	return time.Duration(this.parameters().GossipCacheTtl) * time.Millisecond
+}
+
+// loadApprovedProposals
+func (this *DAPoSService) loadApprovedProposals() {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	proposals, err := types.ToProposals(txn)
+	if err != nil {
+		utils.Error("unable to load proposals", err)
+		return
+	}
+	approved := make([]*types.Proposal, 0)
+	for _, proposal := range proposals {
+		if proposal.Status == types.ProposalApproved {
+			approved = append(approved, proposal)
+		}
+	}
+	this.parametersMutex.Lock()
+	this.approved = approved
+	this.parametersMutex.Unlock()
+}
+
+// totalStake - Tokens staked with all the delegates
+func totalStake(txn *badger.Txn) (int64, error) {
+	delegateStakes, err := types.ToDelegateStakes(txn)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, delegateStake := range delegateStakes {
+		total += delegateStake.Total
+	}
+	return total, nil
+}
+
+// tallyProposals - Decides the proposals whose voting closed. Like the release of locks, this only happens once no vote
+// sent before the close can still be executed, and well before the activation (see types.ProposalTallyDelay).
+func (this *DAPoSService) tallyProposals() {
+	this.tallyAt = 0
+	closed := utils.ToMilliSeconds(this.clock.Now().Add(-this.mempoolTtl()))
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+
+	proposals, err := types.ToProposals(txn)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	tallied := make([]*types.Proposal, 0)
+	for _, proposal := range proposals {
+		if proposal.Status != types.ProposalVoting {
+			continue
+		}
+		if proposal.Closes() > closed {
+			this.scheduleTally(proposal.Closes())
+			continue
+		}
+		proposal.Tally(this.clock.Now())
+		err = proposal.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			return
+		}
+		tallied = append(tallied, proposal)
+	}
+	if len(tallied) == 0 {
+		return
+	}
+	err = txn.Commit(nil)
+	if err != nil {
+		if err == badger.ErrConflict {
+			this.scheduleTally(closed)
+			return
+		}
+		utils.Error(err)
+		return
+	}
+
+	this.parametersMutex.Lock()
+	for _, proposal := range tallied {
+		utils.Info(fmt.Sprintf("tallied proposal [hash=%s, parameter=%s, value=%d, status=%s, yes=%d, no=%d]", proposal.Hash, proposal.Parameter, proposal.Value, proposal.Status, proposal.Yes, proposal.No))
+		if proposal.Status == types.ProposalApproved {
+			this.approved = append(this.approved, proposal)
+		}
+	}
+	types.SortProposals(this.approved)
+	this.parametersMutex.Unlock()
+}
+
+// scheduleTally - Tallies once the voting that closes at the time (milliseconds) is over, only called from the
+// transaction worker
+func (this *DAPoSService) scheduleTally(closes int64) {
+	if this.tallyAt != 0 && this.tallyAt <= closes {
+		return
+	}
+	this.tallyAt = closes
+	delay := time.Duration(closes-utils.ToMilliSeconds(this.clock.Now()))*time.Millisecond + this.mempoolTtl()
+	this.signalAfter(delay, this.tallyChan)
+}
@@ -18,40 +18,44 @@ package dapos
 
 import (
 	"fmt"
-	"math/rand"
 	"math/big"
 	"strings"
+	"sync/atomic"
 	"time"
 	"encoding/hex"
 
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/helper"
-	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/queue"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/dispatchlabs/disgo/disgover"
 	"github.com/dispatchlabs/disgo/dvm"
 	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
+	"github.com/pkg/errors"
 )
 
-var delegateMap = map[string]*types.Node{}
-
 // startGossiping
 func (this *DAPoSService) startGossiping(transaction *types.Transaction) *types.Response {
 	utils.Debug("startGossiping")
-	txn := services.NewTxn(false)
+	txn := this.db.NewTxn(false)
 	defer txn.Discard()
 
 	// Verify?
-	err := transaction.Verify()
+	err := this.verifyTransaction(transaction)
 	if err != nil {
 		utils.Info(fmt.Sprintf("invalid transaction [hash=%s]", transaction.Hash))
 		return types.NewResponseWithStatus(types.StatusInvalidTransaction, err.Error())
 	}
-	elapsedMilliSeconds := utils.ToMilliSeconds(time.Now()) - transaction.Time
-	if elapsedMilliSeconds > types.TxReceiveTimeout {
+	elapsedMilliSeconds := utils.ToMilliSeconds(this.clock.Now()) - transaction.Time
+	txReceiveTimeout := this.txReceiveTimeout(this.parametersAt(transaction.Time))
+	if elapsedMilliSeconds > txReceiveTimeout {
 		utils.Error(fmt.Sprintf("Timed out [hash=%s]", transaction.Hash))
-		return types.NewResponseWithStatus(types.StatusTransactionTimeOut, "Transaction was received later than 3 second limit")
+		return types.NewResponseWithStatus(types.StatusTransactionTimeOut, fmt.Sprintf("Transaction was received later than %d millisecond limit", txReceiveTimeout))
+	}
+	futureLimit := this.parameters().TxFutureLimit
+	if -elapsedMilliSeconds > futureLimit {
+		utils.Info(fmt.Sprintf("transaction too far in the future [hash=%s]", transaction.Hash))
+		return types.NewResponseWithStatus(types.StatusInvalidTransaction, fmt.Sprintf("Transaction time is more than %d milliseconds ahead", futureLimit))
 	}
 
 	// Duplicate transaction?
@@ -68,45 +72,71 @@ func (this *DAPoSService) startGossiping(transaction *types.Transaction) *types.
 	// TODO: Check minimum hertz
 
 	// Are we already gossiping about this transaction?
-	_, err = types.ToTransactionFromCache(services.GetCache(), transaction.Hash)
+	_, err = types.ToTransactionFromCache(this.db.GetCache(), transaction.Hash)
 	if err == nil {
 		utils.Info(fmt.Sprintf("already processing this transaction [hash=%s]", transaction.Hash))
 		return types.NewResponseWithStatus(types.StatusAlreadyProcessingTransaction, "Transaction is already being processed")
 	}
 	// Cache gossip with my rumor.
 	gossip := types.NewGossip(*transaction)
-	rumor := types.NewRumor(types.GetAccount().PrivateKey, types.GetAccount().Address, transaction.Hash)
+	rumor := types.NewRumor(this.account.PrivateKey, this.account.Address, transaction.Hash, this.networkId(), utils.ToMilliSeconds(this.clock.Now()))
 	gossip.Rumors = append(gossip.Rumors, *rumor)
 
+	// Room in the mempool?
+	status, err := this.admitGossip(gossip)
+	if err != nil {
+		utils.Info(fmt.Sprintf("transaction not admitted to mempool [hash=%s, status=%s]", transaction.Hash, status))
+		return types.NewResponseWithStatus(status, err.Error())
+	}
+
 	this.cacheOnFirstReceive(gossip)
-	this.gossipChan <- gossip
+	this.dispatchGossip(gossip)
 
 	return types.NewResponseWithStatus(types.StatusPending, "Pending")
 }
 
+// verifyTransaction - Verifies the transaction was signed as its from account requires, and for this network
+func (this *DAPoSService) verifyTransaction(transaction *types.Transaction) error {
+	if !types.IsNetwork(transaction.NetworkId, this.networkId()) {
+		return errors.Errorf("transaction is for another network [networkId=%s, expected=%s]", transaction.NetworkId, this.networkId())
+	}
+	err := transaction.Verify()
+	if err != nil {
+		return err
+	}
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	return verifySigners(txn, transaction)
+}
+
 func (this *DAPoSService) cacheOnFirstReceive(gossip *types.Gossip) {
 	// Cache receipt.
 	utils.Debug(fmt.Sprintf("First receipt of transaction [hash=%s] [Rumors=%d]", gossip.Transaction.Hash, len(gossip.Rumors)))
-	receipt := types.NewReceipt(gossip.Transaction.Hash)
-	receipt.Cache(services.GetCache())
+	receipt := types.NewReceipt(gossip.Transaction.Hash, this.clock.Now())
+	this.addReceiptEvent(receipt, types.EventReceived, this.account.Address, this.clock.Now())
+	rumored := this.snapshotGossip(gossip)
+	if len(rumored.Rumors) > 0 {
+		this.addReceiptEvent(receipt, types.EventFirstRumor, rumored.Rumors[0].Address, time.Unix(0, rumored.Rumors[0].Time*int64(time.Millisecond)))
+	}
+	this.persistReceipt(receipt)
 
 	// Cache gossip with my rumor.
-	gossip.Cache(services.GetCache())
+	gossip.Cache(this.db.GetCache(), this.gossipCacheTtl())
 
 	// transaction.Receipt.Status = types.StatusReceived
-	gossip.Transaction.Cache(services.GetCache())
+	gossip.Transaction.Cache(this.db.GetCache(), this.transactionCacheTtl())
 
-	delegateNodes, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	delegateNodes, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
 	if err != nil {
 		utils.Error(err)
 		return
 	}
 	for _, node := range delegateNodes {
-		haveSent := gossip.HaveSent(services.GetCache(), gossip.Transaction.Hash, node.Address)
-		isThisAddress := node.Address == disgover.GetDisGoverService().ThisNode.Address
+		haveSent := gossip.HaveSent(this.db.GetCache(), gossip.Transaction.Hash, node.Address)
+		isThisAddress := node.Address == this.disGover.ThisNode.Address
 
 		if !haveSent && !isThisAddress {
-			this.peerGossipGrpc(*node, gossip)
+			this.sendGossip(*node, rumored)
 		}
 	}
 
@@ -117,16 +147,16 @@ func (this *DAPoSService) Temp_ProcessTransaction(transaction *types.Transaction
 	// go func(tx *types.Transaction) {
 
 	// Cache receipt.
-	receipt := types.NewReceipt(transaction.Hash)
-	receipt.Cache(services.GetCache())
+	receipt := types.NewReceipt(transaction.Hash, this.clock.Now())
+	receipt.Cache(this.db.GetCache(), this.receiptCacheTtl())
 
 	// Cache gossip with my rumor.
 	gossip := types.NewGossip(*transaction)
-	rumor := types.NewRumor(types.GetAccount().PrivateKey, types.GetAccount().Address, transaction.Hash)
+	rumor := types.NewRumor(this.account.PrivateKey, this.account.Address, transaction.Hash, this.networkId(), utils.ToMilliSeconds(this.clock.Now()))
 	gossip.Rumors = append(gossip.Rumors, *rumor)
-	gossip.Cache(services.GetCache())
+	gossip.Cache(this.db.GetCache(), this.gossipCacheTtl())
 
-	this.gossipChan <- gossip
+	this.dispatchGossip(gossip)
 
 	return types.NewResponseWithStatus(types.StatusPending, "Pending")
 	// }(transaction)
@@ -135,49 +165,76 @@ func (this *DAPoSService) Temp_ProcessTransaction(transaction *types.Transaction
 // synchronizeGossip
 func (this *DAPoSService) synchronizeGossip(gossip *types.Gossip) (*types.Gossip, error, bool) {
 
+	// The cached gossip is shared, merge one peer's rumors at a time.
+	this.gossipMutex.Lock()
+
 	// PersistAndCache synchronizedGossip.
 	var synchronizedGossip *types.Gossip
 	hasAll := false
-	ourGossip, err := types.ToGossipFromCache(services.GetCache(), gossip.Transaction.Hash)
+	ourGossip, err := types.ToGossipFromCache(this.db.GetCache(), gossip.Transaction.Hash)
 	if err != nil {
 		synchronizedGossip = gossip
 	} else {
 		synchronizedGossip = ourGossip
+		hasAll = true
 		for _, rumor := range gossip.Rumors {
-			hasAll = true
 			if !ourGossip.ContainsRumor(rumor.Address) {
 				hasAll = false
 			}
-			if !synchronizedGossip.ContainsRumor(rumor.Address) && rumor.Verify() { // We don't want to propagate cryptographic lies.
+			if !synchronizedGossip.ContainsRumor(rumor.Address) && rumor.Verify(this.networkId()) { // We don't want to propagate cryptographic lies.
 				synchronizedGossip.Rumors = append(synchronizedGossip.Rumors, rumor)
 			}
 		}
+		if !hasAll {
+			this.recordRumors(synchronizedGossip)
+		}
 		//we have already seen all of these rumors, so we don't want to put them back into our Gossip worker
 	}
 
 	// Did rumor?
 	didRumor := false
 	for _, rumor := range synchronizedGossip.Rumors {
-		if rumor.Address == types.GetAccount().Address {
+		if rumor.Address == this.account.Address {
 			didRumor = true
 		}
 	}
 	if !didRumor {
 
-		// We don't want to propagate cryptographic lies.
-		err = gossip.Transaction.Verify()
-		if err == nil {
-			synchronizedGossip.Rumors = append(synchronizedGossip.Rumors, *types.NewRumor(types.GetAccount().PrivateKey, types.GetAccount().Address, gossip.Transaction.Hash))
-		} else {
+		// We don't want to propagate cryptographic lies, nor hold them in the mempool.
+		err := this.verifyTransaction(&gossip.Transaction)
+		if err != nil {
+			this.gossipMutex.Unlock()
 			utils.Error(err)
+			this.judgeTransaction(gossip)
 			return synchronizedGossip, err, true
 		}
+
+		// Room in the mempool? A delegate that cannot hold the transaction does not rumor about it.
+		status, err := this.admitGossip(synchronizedGossip)
+		if err != nil && err != queue.ErrDuplicate {
+			this.gossipMutex.Unlock()
+			utils.Warn(fmt.Sprintf("not rumoring about transaction [hash=%s, status=%s]", gossip.Transaction.Hash, status))
+			return synchronizedGossip, nil, false
+		}
+		synchronizedGossip.Rumors = append(synchronizedGossip.Rumors, *types.NewRumor(this.account.PrivateKey, this.account.Address, gossip.Transaction.Hash, this.networkId(), utils.ToMilliSeconds(this.clock.Now())))
+		this.recordRumors(synchronizedGossip)
+		this.gossipMutex.Unlock()
+
 		//This is the first time receiving this gossip
 		this.cacheOnFirstReceive(synchronizedGossip)
+		return synchronizedGossip, nil, !hasAll
 	}
+	this.gossipMutex.Unlock()
 	return synchronizedGossip, nil, !hasAll
 }
 
+// snapshotGossip - A copy of the gossip, its rumors taken while synchronizeGossip is not appending to them
+func (this *DAPoSService) snapshotGossip(gossip *types.Gossip) *types.Gossip {
+	this.gossipMutex.Lock()
+	defer this.gossipMutex.Unlock()
+	return &types.Gossip{Transaction: gossip.Transaction, Rumors: append([]types.Rumor{}, gossip.Rumors...)}
+}
+
 // gossipWorker
 func (this *DAPoSService) gossipWorker() {
 	var gossip *types.Gossip
@@ -186,49 +243,59 @@ func (this *DAPoSService) gossipWorker() {
 		case gossip = <-this.gossipChan:
 
 			go func(gossip *types.Gossip) {
+				defer atomic.AddInt32(&this.working, -1)
+
+				// Peers keep merging rumors into the cached gossip, work on the rumors received so far.
+				cached := gossip
+				gossip = this.snapshotGossip(cached)
+
 				// Find nodes in cache?
-				delegateNodes, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+				delegateNodes, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
 				if err != nil {
 					utils.Error(err)
 					return
 				}
-				if delegateMap == nil || len(delegateMap) == 0 {
+				if this.delegateMap == nil || len(this.delegateMap) == 0 {
 					for _, d := range delegateNodes {
-						delegateMap[d.Address] = d
+						this.delegateMap[d.Address] = d
 					}
 				}
 
-				// Gossip timeout?
+				// Gossip timeout? Measured by this delegate, another delegate can accept the same rumors (see gossipTimeout).
 				if len(gossip.Rumors) > 1 {
-					if !types.ValidateTimeDelta(gossip.Rumors) {
+					if !types.ValidateTimeDelta(gossip.Rumors, this.gossipTimeout(), utils.ToMilliSeconds(this.clock.Now())) {
 						utils.Warn("The rumors have an invalid time delta (greater than gossip timeout milliseconds")
-						updateReceiptStatus(gossip.Transaction.Hash, types.StatusGossipingTimedOut)
+						this.judgeTimeliness(gossip.Rumors, this.gossipTimeout())
+						this.updateReceiptStatus(gossip.Transaction.Hash, types.StatusGossipingTimedOut)
 						//ignore this gossip's rumors and hopefully still hit 2/3 from well timed gossip, but keep listening
 						return
 					}
 				}
 				// Do we have 2/3 of rumors?
 				if float32(len(gossip.Rumors)) >= float32(len(delegateNodes))*2/3 {
-					if !this.gossipQueue.Exists(gossip.Transaction.Hash) {
+					// Not evicted or expired from the mempool meanwhile?
+					if !this.gossipQueue.Exists(gossip.Transaction.Hash) && this.mempool.Exists(gossip.Transaction.Hash) {
 						//for _, rumor := range gossip.Rumors {
 						//	utils.Info(fmt.Sprintf("rumor from: [address=%s] for [tx=%s] with [hash=%s]", rumor.Address, rumor.TransactionHash, rumor.Hash))
 						//}
-						this.gossipQueue.Push(gossip)
-
-						go func() {
-							//adding timeout as a function of tx time.  If tx is in the future, add future delta to the default timeout
-							delta := gossip.Transaction.Time - utils.ToMilliSeconds(time.Now())
-							totalMilliseconds := (types.GossipTimeout * len(delegateNodes)) + types.TxReceiveTimeout
-							timeout := time.Duration(totalMilliseconds) * time.Millisecond
-							utils.Debug("Timeout Queue value: ", timeout)
-							if delta > 0 {
-								timeout = time.Millisecond*time.Duration(delta) + timeout
-							}
-							time.Sleep(timeout)
-							this.timoutChan <- true
-						}()
+						//adding timeout as a function of tx time.  If tx is in the future, add future delta to the default timeout
+						delta := gossip.Transaction.Time - utils.ToMilliSeconds(this.clock.Now())
+						timeout := this.executionDelay(len(delegateNodes))
+						utils.Debug("Timeout Queue value: ", timeout)
+						if delta > 0 {
+							timeout = time.Millisecond*time.Duration(delta) + timeout
+						}
+
+						this.recordReceiptEvent(gossip.Transaction.Hash, types.EventConsensusReached, gossip.Rumors[len(gossip.Rumors)-1].Address)
+
+						// Write ahead, a restarted delegate still executes it.
+						err = this.mempool.SetQueued(gossip.Transaction.Hash, utils.ToMilliSeconds(this.clock.Now().Add(timeout)))
+						if err != nil {
+							utils.Error(err)
+						}
+						this.queueForExecution(gossip, timeout)
 						//for _, node := range delegateNodes {
-						//	haveSent := gossip.HaveSent(services.GetCache(), gossip.Transaction.Hash, node.Address)
+						//	haveSent := gossip.HaveSent(this.db.GetCache(), gossip.Transaction.Hash, node.Address)
 						//
 						//	if !haveSent {
 						//		utils.Info(fmt.Sprintf("*********** Last send after 2/3 [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
@@ -250,8 +317,8 @@ func (this *DAPoSService) gossipWorker() {
 				node := this.getRandomDelegate(gossip, delegateNodes)
 				if node == nil {
 					utils.Warn("did not find any delegates to rumor with")
-					gossip.Cache(services.GetCache())
-					updateReceiptStatus(gossip.Transaction.Hash, types.StatusCouldNotReachConsensus)
+					cached.Cache(this.db.GetCache(), this.gossipCacheTtl())
+					this.updateReceiptStatus(gossip.Transaction.Hash, types.StatusCouldNotReachConsensus)
 
 					//Commented out because if we have no-one left to talk to, why are we continuing?
 					//Plus it was causing me all kinds of timeout problems
@@ -264,26 +331,126 @@ func (this *DAPoSService) gossipWorker() {
 				utils.Debug(fmt.Sprintf("Picked RandomDelegate = [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
 
 				// Peer gossip.
-				//peerGossip, err := this.peerGossipGrpc(*node, gossip)
-				_, err = this.peerGossipGrpc(*node, gossip)
-				if err != nil {
-					utils.Error(err)
-					this.gossipChan <- gossip
-					return
-				}
-				//this.gossipChan <- peerGossip
+				this.sendGossip(*node, gossip)
 			}(gossip)
 		}
 	}
 }
 
-func updateReceiptStatus(txHash, status string) {
-	receipt, err := types.ToReceiptFromCache(services.GetCache(), txHash)
+// sendGossip - Gossips with the delegate, batched when a batch window is configured. Gossip that could not be
+// delivered goes back on the gossip channel.
+func (this *DAPoSService) sendGossip(node types.Node, gossip *types.Gossip) {
+	if this.batcher != nil {
+		this.batcher.Add(node, gossip)
+		return
+	}
+	_, err := this.peerGossipGrpc(node, gossip)
+	if err != nil {
+		utils.Error(err)
+		this.dispatchGossip(gossip)
+	}
+}
+
+// queueForExecution - Executes the gossip once the delay passed
+func (this *DAPoSService) queueForExecution(gossip *types.Gossip, delay time.Duration) {
+	this.recordReceiptEvent(gossip.Transaction.Hash, types.EventQueued, this.account.Address)
+	this.gossipQueue.Push(gossip)
+	this.signalAfter(delay, this.timoutChan)
+}
+
+// signalAfter - Signals the transaction worker once the delay passed, the signal counts as work until it is handled
+func (this *DAPoSService) signalAfter(delay time.Duration, channel chan bool) {
+	go func() {
+		<-this.clock.After(delay)
+		atomic.AddInt32(&this.working, 1)
+		channel <- true
+	}()
+}
+
+// recordRumors - Writes the rumors collected so far ahead of gossiping them
+func (this *DAPoSService) recordRumors(gossip *types.Gossip) {
+	err := this.mempool.Update(gossip)
+	if err != nil {
+		utils.Error(err)
+	}
+}
+
+// updateReceiptStatus - Gossip can still reach consensus, the status is persisted once the transaction executes or expires
+func (this *DAPoSService) updateReceiptStatus(txHash, status string) {
+	receipt, err := types.ToReceiptFromCache(this.db.GetCache(), txHash)
 	if err != nil {
 		utils.Error(err)
 	} else {
 		receipt.Status = status
-		receipt.Cache(services.GetCache())
+		receipt.Updated = this.clock.Now()
+		receipt.Cache(this.db.GetCache(), this.receiptCacheTtl())
+	}
+}
+
+// verifySigners - A multisig account is spent with enough of its signers' signatures, any other account with its own
+func verifySigners(txn *badger.Txn, transaction *types.Transaction) error {
+	fromAccount, err := types.ToAccountByAddress(txn, transaction.From)
+	if err != nil && err != badger.ErrKeyNotFound {
+		return err
+	}
+	if err == nil && fromAccount.IsMultisig() {
+		if len(transaction.Signatures) == 0 {
+			return errors.New("a multisig account requires cosignatures")
+		}
+		return transaction.VerifySignatures(fromAccount.Signers, fromAccount.Threshold)
+	}
+	if len(transaction.Signatures) > 0 {
+		return errors.New("only a multisig account can be spent with cosignatures")
+	}
+	return nil
+}
+
+// recordReceiptEvent - Adds the lifecycle event to the cached receipt and persists it, so a delegate that restarts
+// keeps the timeline of its in-flight transactions
+func (this *DAPoSService) recordReceiptEvent(txHash, event, delegate string) {
+	receipt, err := types.ToReceiptFromCache(this.db.GetCache(), txHash)
+	if err != nil {
+		utils.Debug(fmt.Sprintf("no receipt to record %s [hash=%s]", event, txHash))
+		return
+	}
+	if this.addReceiptEvent(receipt, event, delegate, this.clock.Now()) {
+		this.persistReceipt(receipt)
+	}
+}
+
+// addReceiptEvent - The receipt is shared through the cache, workers add to its timeline one at a time. False when
+// the event was already recorded
+func (this *DAPoSService) addReceiptEvent(receipt *types.Receipt, event, delegate string, at time.Time) bool {
+	this.receiptMutex.Lock()
+	defer this.receiptMutex.Unlock()
+	if receipt.HasEvent(event) {
+		return false
+	}
+	receipt.AddEvent(event, delegate, at)
+	return true
+}
+
+// setReceiptStatus - Persists the receipt with its failure status, ending its timeline with a Failed event
+func (this *DAPoSService) setReceiptStatus(receipt *types.Receipt, status, humanReadableStatus string) {
+	receipt.Status = status
+	receipt.HumanReadableStatus = humanReadableStatus
+	receipt.Updated = this.clock.Now()
+	this.addReceiptEvent(receipt, types.EventFailed, this.account.Address, receipt.Updated)
+	this.persistReceipt(receipt)
+}
+
+// persistReceipt - Receipts are not only kept in the cache
+func (this *DAPoSService) persistReceipt(receipt *types.Receipt) {
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+	this.receiptMutex.Lock()
+	err := receipt.Set(txn, this.db.GetCache(), this.receiptCacheTtl())
+	this.receiptMutex.Unlock()
+	if err == nil {
+		err = txn.Commit(nil)
+	}
+	if err != nil {
+		utils.Error(err)
 	}
 }
 
@@ -297,11 +464,11 @@ func (this *DAPoSService) getRandomDelegate(gossip *types.Gossip, delegateNodes
 	// Get delegates that have not rumored?
 	delegatesNotRumored := make([]*types.Node, 0)
 	for _, node := range delegateNodes {
-		haveSent := gossip.HaveSent(services.GetCache(), gossip.Transaction.Hash, node.Address)
+		haveSent := gossip.HaveSent(this.db.GetCache(), gossip.Transaction.Hash, node.Address)
 		containsRumor := gossip.ContainsRumor(node.Address)
-		isThisAddress := node.Address == disgover.GetDisGoverService().ThisNode.Address
+		isThisAddress := node.Address == this.disGover.ThisNode.Address
 
-		if !node.IsAvailable() {
+		if !node.IsAvailable(this.clock.Now()) {
 			utils.Debug(fmt.Sprintf("Node is not available: [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
 		}
 		if haveSent {
@@ -310,7 +477,7 @@ func (this *DAPoSService) getRandomDelegate(gossip *types.Gossip, delegateNodes
 		if !containsRumor {
 			utils.Debug(fmt.Sprintf("Don't have a Rumor for: [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
 		}
-		if isThisAddress || haveSent || !node.IsAvailable() {
+		if isThisAddress || haveSent || !node.IsAvailable(this.clock.Now()) || this.reputation.Banned(node.Address) {
 			continue
 		}
 		delegatesNotRumored = append(delegatesNotRumored, node)
@@ -318,10 +485,26 @@ func (this *DAPoSService) getRandomDelegate(gossip *types.Gossip, delegateNodes
 	if len(delegatesNotRumored) == 0 {
 		return nil
 	}
-	// Find random delegate.
-	rand.Seed(time.Now().UTC().UnixNano())
-	index := rand.Intn(len(delegatesNotRumored))
-	return delegatesNotRumored[index]
+	// Find random delegate, weighted by stake and reputation.
+	weights := make([]int64, len(delegatesNotRumored))
+	var total int64
+	for i, node := range delegatesNotRumored {
+		weights[i] = this.stakeWeight(node.Address) * this.reputation.Weight(node.Address)
+		total += weights[i]
+	}
+	if total <= 0 {
+		return nil
+	}
+	this.randomMutex.Lock()
+	pick := this.random.Int63n(total)
+	this.randomMutex.Unlock()
+	for i, weight := range weights {
+		if pick < weight {
+			return delegatesNotRumored[i]
+		}
+		pick -= weight
+	}
+	return delegatesNotRumored[len(delegatesNotRumored)-1]
 }
 
 // gossipWorker - transfer tokens, deploy smart contract, and execution of smart contract.
@@ -331,7 +514,12 @@ func (this *DAPoSService) transactionWorker() {
 		select {
 		case <-this.timoutChan:
 			this.doWork()
+		case <-this.releaseChan:
+			this.releaseLocks()
+		case <-this.tallyChan:
+			this.tallyProposals()
 		}
+		atomic.AddInt32(&this.working, -1)
 	}
 }
 
@@ -340,38 +528,43 @@ func (this *DAPoSService) doWork() {
 
 	if this.gossipQueue.HasAvailable() {
 		gossip = this.gossipQueue.Pop()
+		reason := queue.ReasonExecuted
+		defer func() {
+			this.mempool.Remove(gossip.Transaction.Hash, reason)
+		}()
+
 		// Get receipt.
-		receipt, err := types.ToReceiptFromCache(services.GetCache(), gossip.Transaction.Hash)
+		receipt, err := types.ToReceiptFromCache(this.db.GetCache(), gossip.Transaction.Hash)
 		if err != nil {
 			utils.Error(fmt.Sprintf("receipt not found [hash=%s]", gossip.Transaction.Hash))
-			receipt = types.NewReceipt(gossip.Transaction.Hash)
-			receipt.Status = types.StatusReceiptNotFound
-			receipt.Cache(services.GetCache())
+			receipt = types.NewReceipt(gossip.Transaction.Hash, this.clock.Now())
+			this.setReceiptStatus(receipt, types.StatusReceiptNotFound, "")
+			reason = queue.ReasonFailed
 			return
 		}
 		initialRcvDuration := gossip.Rumors[0].Time - gossip.Transaction.Time
-		utils.Debug("Initial Receive Duration = ", initialRcvDuration, types.TxReceiveTimeout)
-		if initialRcvDuration >= types.TxReceiveTimeout {
+		txReceiveTimeout := this.parametersAt(gossip.Transaction.Time).TxReceiveTimeout
+		utils.Debug("Initial Receive Duration = ", initialRcvDuration, txReceiveTimeout)
+		if initialRcvDuration >= txReceiveTimeout {
 			utils.Error(fmt.Sprintf("Timed out [hash=%s] %v milliseconds", gossip.Transaction.Hash, initialRcvDuration))
-			receipt = types.NewReceipt(gossip.Transaction.Hash)
-			receipt.Status = types.StatusTransactionTimeOut
-			receipt.Cache(services.GetCache())
+			this.setReceiptStatus(receipt, types.StatusTransactionTimeOut, "")
+			reason = queue.ReasonFailed
 			return
 		}
-		receipt.Created = time.Now()
-		if types.GetConfig().IsBookkeeper {
-			executeTransaction(&gossip.Transaction, receipt, gossip)
+		receipt.Created = this.clock.Now()
+		if this.config.IsBookkeeper {
+			this.executeTransaction(&gossip.Transaction, receipt, gossip)
 		}
 	}
 }
 
 // executeTransaction
-func executeTransaction(transaction *types.Transaction, receipt *types.Receipt, gossip *types.Gossip) {
+func (this *DAPoSService) executeTransaction(transaction *types.Transaction, receipt *types.Receipt, gossip *types.Gossip) {
 	utils.Info("executeTransaction --> ", transaction.Hash)
-	services.Lock(transaction.Hash)
-	defer services.Unlock(transaction.Hash)
+	this.db.Lock(transaction.Hash)
+	defer this.db.Unlock(transaction.Hash)
 
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 
 	// Has this transaction already been processed?
@@ -382,46 +575,376 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 	}
 
 	// Find/create fromAccount?
-	now := time.Now()
+	now := this.clock.Now()
 	fromAccount, err := types.ToAccountByAddress(txn, transaction.From)
 	if err != nil {
 		if err == badger.ErrKeyNotFound {
 			fromAccount = &types.Account{Address: transaction.From, Balance: big.NewInt(0), Created: now}
 		} else {
 			utils.Error(err)
-			receipt.Status = types.StatusInternalError
-			receipt.HumanReadableStatus = err.Error()
-			receipt.Cache(services.GetCache())
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 			return
 		}
 	}
 
+	// Transfer to a name? The name is resolved to the account that holds it now.
+	to := transaction.To
+	if to == "" && transaction.Name != "" && (transaction.Type == types.TypeTransferTokens || transaction.Type == types.TypeLockTokens) {
+		nameAccount, err := types.ToAccountByName(txn, transaction.Name)
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				utils.Error(fmt.Sprintf("name not registered [hash=%s, name=%s]", transaction.Hash, transaction.Name))
+				this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Name is not registered")
+			} else {
+				utils.Error(err)
+				this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			}
+			return
+		}
+		if nameAccount.Address == transaction.From {
+			utils.Error(fmt.Sprintf("name resolved to the from address [hash=%s, name=%s]", transaction.Hash, transaction.Name))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Name is held by the from account")
+			return
+		}
+		to = nameAccount.Address
+	}
+
 	// Find/create toAccount?
-	toAccount, err := types.ToAccountByAddress(txn, transaction.To)
+	toAccount, err := types.ToAccountByAddress(txn, to)
 	if err != nil {
 		if err == badger.ErrKeyNotFound {
-			toAccount = &types.Account{Address: transaction.To, Balance: big.NewInt(0), Created: now}
+			toAccount = &types.Account{Address: to, Balance: big.NewInt(0), Created: now}
 		} else {
 			utils.Error(err)
-			receipt.SetInternalErrorWithNewTransaction(services.GetDb(), err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 			return
 		}
 	}
 
+	// Signed as the from account requires?
+	err = verifySigners(txn, transaction)
+	if err != nil {
+		utils.Error(fmt.Sprintf("invalid signers [hash=%s]", transaction.Hash), err)
+		this.setReceiptStatus(receipt, types.StatusInvalidTransaction, err.Error())
+		return
+	}
+
 	// Execute.
+	parameters := this.parametersAt(transaction.Time)
+	var releaseAt int64
+	var tallyAt int64
+	var delegateStake *types.DelegateStake
 	switch transaction.Type {
 	case types.TypeTransferTokens:
 
 		// Sufficient tokens?
-		if fromAccount.Balance.Int64() < transaction.Value {
+		if fromAccount.SpendableBalance(transaction.Time) < transaction.Value {
 			utils.Error(fmt.Sprintf("insufficient tokens [hash=%s]", transaction.Hash))
-			receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusInsufficientTokens)
+			this.setReceiptStatus(receipt, types.StatusInsufficientTokens, "")
 			return
 		}
 		fromAccount.Balance.SetInt64(fromAccount.Balance.Int64() - transaction.Value)
 		toAccount.Balance.SetInt64(toAccount.Balance.Int64() + transaction.Value)
 		utils.Info(fmt.Sprintf("transferred tokens [hash=%s, rumors=%d]", transaction.Hash, len(gossip.Rumors)))
 		break
+	case types.TypeBatchTransferTokens:
+
+		// Sufficient tokens for every output?
+		total := transaction.TotalValue()
+		if fromAccount.SpendableBalance(transaction.Time) < total {
+			utils.Error(fmt.Sprintf("insufficient tokens [hash=%s]", transaction.Hash))
+			this.setReceiptStatus(receipt, types.StatusInsufficientTokens, "")
+			return
+		}
+
+		// All outputs are applied in this transaction or none is.
+		outputAccounts := make([]*types.Account, 0, len(transaction.Outputs))
+		for _, output := range transaction.Outputs {
+			outputAccount, err := types.ToAccountByAddress(txn, output.To)
+			if err != nil {
+				if err != badger.ErrKeyNotFound {
+					utils.Error(err)
+					this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+					return
+				}
+				outputAccount = &types.Account{Address: output.To, Balance: big.NewInt(0), Created: now}
+			}
+			outputAccount.Balance.SetInt64(outputAccount.Balance.Int64() + output.Value)
+			outputAccount.Updated = now
+			outputAccounts = append(outputAccounts, outputAccount)
+		}
+		fromAccount.Balance.SetInt64(fromAccount.Balance.Int64() - total)
+		for _, outputAccount := range outputAccounts {
+			err = outputAccount.Persist(txn)
+			if err != nil {
+				utils.Error(err)
+				this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+				return
+			}
+		}
+		toAccount = nil
+		utils.Info(fmt.Sprintf("transferred tokens [hash=%s, outputs=%d, rumors=%d]", transaction.Hash, len(transaction.Outputs), len(gossip.Rumors)))
+		break
+	case types.TypeCreateMultisig:
+		address := transaction.MultisigAddress()
+
+		// Nobody holds a key for the address, an account there can only have been credited by transfers that ran first.
+		multisigAccount, err := types.ToAccountByAddress(txn, address)
+		if err == nil {
+			if multisigAccount.IsMultisig() || multisigAccount.TransactionHash != "" {
+				utils.Error(fmt.Sprintf("multisig account already exists [hash=%s, address=%s]", transaction.Hash, address))
+				this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Account already exists")
+				return
+			}
+		} else if err == badger.ErrKeyNotFound {
+			multisigAccount = &types.Account{Address: address, Balance: big.NewInt(0), Created: now}
+		} else {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		multisigAccount.Signers = transaction.Signers
+		multisigAccount.Threshold = transaction.Threshold
+		multisigAccount.Updated = now
+		err = multisigAccount.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		toAccount = nil
+		utils.Info(fmt.Sprintf("created multisig account [hash=%s, address=%s, threshold=%d/%d]", transaction.Hash, address, transaction.Threshold, len(transaction.Signers)))
+		break
+	case types.TypeLockTokens:
+
+		// Sufficient tokens? The locked tokens count in the balance of the to account, but cannot be spent before the unlock time.
+		if fromAccount.SpendableBalance(transaction.Time) < transaction.Value {
+			utils.Error(fmt.Sprintf("insufficient tokens [hash=%s]", transaction.Hash))
+			this.setReceiptStatus(receipt, types.StatusInsufficientTokens, "")
+			return
+		}
+		fromAccount.Balance.SetInt64(fromAccount.Balance.Int64() - transaction.Value)
+		toAccount.Balance.SetInt64(toAccount.Balance.Int64() + transaction.Value)
+		lock := types.Lock{TransactionHash: transaction.Hash, Value: transaction.Value, Until: transaction.UnlockTime}
+		toAccount.Locks = append(toAccount.Locks, lock)
+		err = lock.Persist(txn, toAccount.Address)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		releaseAt = lock.Until
+		utils.Info(fmt.Sprintf("locked tokens [hash=%s, until=%d, rumors=%d]", transaction.Hash, transaction.UnlockTime, len(gossip.Rumors)))
+		break
+	case types.TypeStake:
+
+		// Only with the delegates of the chain, stake never makes another address a delegate.
+		isDelegate, err := types.IsDelegate(txn, transaction.To, transaction.Time)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		if !isDelegate {
+			utils.Error(fmt.Sprintf("not a delegate [hash=%s, delegate=%s]", transaction.Hash, transaction.To))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Not a delegate")
+			return
+		}
+		if fromAccount.SpendableBalance(transaction.Time) < transaction.Value {
+			utils.Error(fmt.Sprintf("insufficient tokens [hash=%s]", transaction.Hash))
+			this.setReceiptStatus(receipt, types.StatusInsufficientTokens, "")
+			return
+		}
+		stakers := 0
+		if fromAccount.StakeWith(transaction.To) == 0 {
+			stakers = 1
+		}
+		fromAccount.Balance.SetInt64(fromAccount.Balance.Int64() - transaction.Value)
+		fromAccount.Bond(transaction.To, transaction.Value)
+		err = fromAccount.PersistBonded(txn, transaction.Time)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		delegateStake, err = this.addDelegateStake(txn, transaction.To, transaction.Value, stakers)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		toAccount = nil
+		utils.Info(fmt.Sprintf("staked tokens [hash=%s, delegate=%s, value=%d]", transaction.Hash, transaction.To, transaction.Value))
+		break
+	case types.TypeUnstake:
+		err = fromAccount.Unbond(transaction.To, transaction.Value)
+		if err != nil {
+			utils.Error(fmt.Sprintf("insufficient stake [hash=%s]", transaction.Hash), err)
+			this.setReceiptStatus(receipt, types.StatusInsufficientTokens, "Insufficient stake")
+			return
+		}
+		err = fromAccount.PersistBonded(txn, transaction.Time)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		stakers := 0
+		if fromAccount.StakeWith(transaction.To) == 0 {
+			stakers = -1
+		}
+		fromAccount.Balance.SetInt64(fromAccount.Balance.Int64() + transaction.Value)
+
+		// Unbonding tokens are locked for the unbonding period.
+		if parameters.UnbondingPeriod > 0 {
+			lock := types.Lock{TransactionHash: transaction.Hash, Value: transaction.Value, Until: transaction.Time + parameters.UnbondingPeriod}
+			fromAccount.Locks = append(fromAccount.Locks, lock)
+			err = lock.Persist(txn, fromAccount.Address)
+			if err != nil {
+				utils.Error(err)
+				this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+				return
+			}
+			releaseAt = lock.Until
+		}
+		delegateStake, err = this.addDelegateStake(txn, transaction.To, -transaction.Value, stakers)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		toAccount = nil
+		utils.Info(fmt.Sprintf("unstaked tokens [hash=%s, delegate=%s, value=%d]", transaction.Hash, transaction.To, transaction.Value))
+		break
+	case types.TypeProposeParameter:
+		total, err := totalStake(txn)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		proposal := types.NewProposal(transaction, total, now)
+		err = proposal.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		tallyAt = proposal.Closes()
+		toAccount = nil
+		utils.Info(fmt.Sprintf("proposed parameter [hash=%s, parameter=%s, value=%d, activationTime=%d]", transaction.Hash, transaction.Parameter, transaction.Value, transaction.ActivationTime))
+		break
+	case types.TypeVote:
+		proposal, err := types.ToProposal(txn, transaction.Proposal)
+		if err != nil {
+			if err == badger.ErrKeyNotFound {
+				utils.Error(fmt.Sprintf("proposal not found [hash=%s, proposal=%s]", transaction.Hash, transaction.Proposal))
+				this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Proposal not found")
+			} else {
+				utils.Error(err)
+				this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			}
+			return
+		}
+		if proposal.Status != types.ProposalVoting || transaction.Time >= proposal.Closes() {
+			utils.Error(fmt.Sprintf("voting closed [hash=%s, proposal=%s]", transaction.Hash, transaction.Proposal))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Voting on the proposal is closed")
+			return
+		}
+		_, err = txn.Get([]byte(proposal.VoteKey(transaction.From)))
+		if err == nil {
+			utils.Error(fmt.Sprintf("already voted [hash=%s, proposal=%s]", transaction.Hash, transaction.Proposal))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Already voted on the proposal")
+			return
+		}
+
+		// Weighted by the tokens staked when the proposal was made, tokens staked or moved to another account after it
+		// cannot vote again.
+		weight, err := types.ToBondedAt(txn, transaction.From, proposal.Time)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		if weight == 0 {
+			utils.Error(fmt.Sprintf("no stake to vote with [hash=%s, proposal=%s]", transaction.Hash, transaction.Proposal))
+			this.setReceiptStatus(receipt, types.StatusInsufficientTokens, "No stake to vote with")
+			return
+		}
+		if transaction.Approve {
+			proposal.Yes += weight
+		} else {
+			proposal.No += weight
+		}
+		proposal.Updated = now
+		err = proposal.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		err = txn.Set([]byte(proposal.VoteKey(transaction.From)), []byte(transaction.Key()))
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		toAccount = nil
+		utils.Info(fmt.Sprintf("voted [hash=%s, proposal=%s, approve=%t, weight=%d]", transaction.Hash, transaction.Proposal, transaction.Approve, weight))
+		break
+	case types.TypeRegisterName:
+		_, err := types.ToAccountByName(txn, transaction.Name)
+		if err == nil {
+			utils.Error(fmt.Sprintf("name already registered [hash=%s, name=%s]", transaction.Hash, transaction.Name))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Name is already registered")
+			return
+		} else if err != badger.ErrKeyNotFound {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		if fromAccount.Name != "" {
+			utils.Error(fmt.Sprintf("account already has a name [hash=%s, name=%s]", transaction.Hash, fromAccount.Name))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Account already has a name")
+			return
+		}
+		fromAccount.Name = transaction.Name
+		toAccount = nil
+		utils.Info(fmt.Sprintf("registered name [hash=%s, name=%s]", transaction.Hash, transaction.Name))
+		break
+	case types.TypeTransferName:
+		if fromAccount.Name != transaction.Name {
+			utils.Error(fmt.Sprintf("name not held by the from account [hash=%s, name=%s]", transaction.Hash, transaction.Name))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Name is not held by the from account")
+			return
+		}
+		if toAccount.Name != "" {
+			utils.Error(fmt.Sprintf("account already has a name [hash=%s, name=%s]", transaction.Hash, toAccount.Name))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "To account already has a name")
+			return
+		}
+
+		// The name key now points at the to account.
+		fromAccount.Name = ""
+		toAccount.Name = transaction.Name
+		utils.Info(fmt.Sprintf("transferred name [hash=%s, name=%s, to=%s]", transaction.Hash, transaction.Name, transaction.To))
+		break
+	case types.TypeReleaseName:
+		if fromAccount.Name != transaction.Name {
+			utils.Error(fmt.Sprintf("name not held by the from account [hash=%s, name=%s]", transaction.Hash, transaction.Name))
+			this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "Name is not held by the from account")
+			return
+		}
+		err = txn.Delete([]byte(fromAccount.NameKey()))
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		fromAccount.Name = ""
+		toAccount = nil
+		utils.Info(fmt.Sprintf("released name [hash=%s, name=%s]", transaction.Hash, transaction.Name))
+		break
 	case types.TypeDeploySmartContract:
 		dvmService := dvm.GetDVMService()
 
@@ -431,18 +954,14 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		dvmResult, err := dvmService.DeploySmartContract(transaction)
 		if err != nil {
 			utils.Error(err, utils.GetCallStackWithFileAndLineNumber())
-			receipt.Status = types.StatusInternalError
-			receipt.HumanReadableStatus = err.Error()
-			receipt.Cache(services.GetCache())
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 			return
 		}
 
 		err = processDVMResult(transaction, dvmResult, receipt)
 		if err != nil {
 			utils.Error(err)
-			receipt.Status = types.StatusInternalError
-			receipt.HumanReadableStatus = err.Error()
-			receipt.Cache(services.GetCache())
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 			return
 		}
 
@@ -465,9 +984,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		contractTx, err := types.ToTransactionByAddress(txn, transaction.To)
 		if err != nil {
 			utils.Error(err, utils.GetCallStackWithFileAndLineNumber())
-			receipt.Status = types.StatusInternalError
-			receipt.HumanReadableStatus = err.Error()
-			receipt.Cache(services.GetCache())
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 			return
 		}
 
@@ -475,9 +992,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		transaction.Params, err = helper.GetConvertedParams(transaction)
 		if err != nil {
 			utils.Error(err, utils.GetCallStackWithFileAndLineNumber())
-			receipt.Status = types.StatusInternalError
-			receipt.HumanReadableStatus = err.Error()
-			receipt.Cache(services.GetCache())
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 			return
 		}
 		// }
@@ -491,9 +1006,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		err = processDVMResult(transaction, dvmResult, receipt)
 		if err != nil {
 			utils.Error(err)
-			receipt.Status = types.StatusInternalError
-			receipt.HumanReadableStatus = err.Error()
-			receipt.Cache(services.GetCache())
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 			return
 		}
 		receipt.ContractAddress = transaction.To
@@ -501,60 +1014,88 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		break
 	default:
 		utils.Error(fmt.Sprintf("invalid transaction type [hash=%s]", transaction.Hash))
-		receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusInvalidTransaction)
+		this.setReceiptStatus(receipt, types.StatusInvalidTransaction, "")
 		return
 	}
+	this.addReceiptEvent(receipt, types.EventExecuted, this.account.Address, this.clock.Now())
+
+	// Fee?
+	fee := transaction.TotalFee(receipt.HertzUsed, parameters.HertzPrice)
+	if fee > 0 {
+		if fromAccount.SpendableBalance(transaction.Time) < fee {
+			utils.Error(fmt.Sprintf("insufficient tokens for fee [hash=%s, fee=%d]", transaction.Hash, fee))
+			this.setReceiptStatus(receipt, types.StatusInsufficientTokens, "Insufficient tokens for the fee")
+			return
+		}
+		fromAccount.Balance.SetInt64(fromAccount.Balance.Int64() - fee)
+	}
 
 	// Persist transaction
 	err = transaction.Persist(txn)
 	if err != nil {
 		utils.Error(err)
-		receipt.Status = types.StatusInternalError
-		receipt.HumanReadableStatus = err.Error()
-		receipt.Cache(services.GetCache())
+		this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 		return
 	}
 
+	// Index the account a name resolved to as the recipient.
+	if to != transaction.To {
+		err = txn.Set([]byte(transaction.ToKeyFor(to)), []byte(transaction.Key()))
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+	}
+
 	// Save fromAccount.
 	fromAccount.Updated = now
 	err = fromAccount.Persist(txn)
 	if err != nil {
 		utils.Error(err)
-		receipt.Status = types.StatusInternalError
-		receipt.HumanReadableStatus = err.Error()
-		receipt.Cache(services.GetCache())
+		this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 		return
 	}
 
-	// Save toAccount.
-	toAccount.Updated = now
-	err = toAccount.Persist(txn)
-	if err != nil {
-		utils.Error(err)
-		receipt.Status = types.StatusInternalError
-		receipt.HumanReadableStatus = err.Error()
-		receipt.Cache(services.GetCache())
-		return
+	// Save toAccount, unless the transaction saved the accounts it created or credited.
+	if toAccount != nil {
+		toAccount.Updated = now
+		err = toAccount.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+	}
+
+	// Pay the delegates, their accounts are read back in this transaction so they include the changes above.
+	if fee > 0 {
+		rewards, err := this.distributeFee(txn, transaction, fee)
+		if err != nil {
+			utils.Error(err)
+			this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
+			return
+		}
+		receipt.Fee = fee
+		receipt.Rewards = rewards
 	}
 
 	// Save receipt.
 	receipt.Status = types.StatusOk
-	err = receipt.Set(txn, services.GetCache())
+	receipt.Updated = this.clock.Now()
+	this.addReceiptEvent(receipt, types.EventPersisted, this.account.Address, receipt.Updated)
+	err = receipt.Set(txn, this.db.GetCache(), this.receiptCacheTtl())
 	if err != nil {
 		utils.Error(err)
-		receipt.Status = types.StatusInternalError
-		receipt.HumanReadableStatus = err.Error()
-		receipt.Cache(services.GetCache())
+		this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 		return
 	}
 
 	// Save gossip.
-	err = gossip.Set(txn, services.GetCache())
+	err = gossip.Set(txn, this.db.GetCache(), this.gossipCacheTtl())
 	if err != nil {
 		utils.Error(err)
-		receipt.Status = types.StatusInternalError
-		receipt.HumanReadableStatus = err.Error()
-		receipt.Cache(services.GetCache())
+		this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 		return
 	}
 
@@ -565,11 +1106,18 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 			return
 		}
 		utils.Error(err)
-		receipt.Status = types.StatusInternalError
-		receipt.HumanReadableStatus = err.Error()
-		receipt.Cache(services.GetCache())
+		this.setReceiptStatus(receipt, types.StatusInternalError, err.Error())
 		return
 	}
+	if releaseAt != 0 {
+		this.scheduleRelease(releaseAt)
+	}
+	if tallyAt != 0 {
+		this.scheduleTally(tallyAt)
+	}
+	if delegateStake != nil {
+		delegateStake.Cache(this.db.GetCache())
+	}
 }
 
 //TODO: implement if useful
@@ -579,6 +1127,7 @@ func processDVMResult(transaction *types.Transaction, dvmResult *dvm.DVMResult,
 	utils.Info("######### DUMPING-DVMResult #########")
 	utils.Info(dvmResult)
 
+	receipt.HertzUsed = int64(dvmResult.HertzCost)
 	if dvmResult.ContractMethodExecError != nil {
 		utils.Error(dvmResult.ContractMethodExecError)
 		return dvmResult.ContractMethodExecError
@@ -625,10 +1174,11 @@ func processDVMResult(transaction *types.Transaction, dvmResult *dvm.DVMResult,
 	return errorToReturn
 }
 
-func getAccountFromBadgerByAddress(address string) (*types.Account, error) {
+// getAccountFromBadgerByAddress
+func (this *DAPoSService) getAccountFromBadgerByAddress(address string) (*types.Account, error) {
 	utils.Debug(fmt.Sprintf("toAccountByAddress: %s", address))
 
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 
 	account, err := types.ToAccountByAddress(txn, address)
@@ -0,0 +1,152 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// latencySamples - Number of round trips kept per delegate
+const latencySamples = 100
+
+// Timeouts - Timeouts currently in use and the latencies they were derived from
+type Timeouts struct {
+	GossipTimeout    int64             `json:"gossipTimeout"`
+	TxReceiveTimeout int64             `json:"txReceiveTimeout"`
+	ExecutionDelay   int64             `json:"executionDelay"`
+	Delegates        []DelegateLatency `json:"delegates"`
+}
+
+// DelegateLatency - Round trip percentiles to one delegate in milliseconds
+type DelegateLatency struct {
+	Address string `json:"address"`
+	Samples int    `json:"samples"`
+	P50     int64  `json:"p50"`
+	P95     int64  `json:"p95"`
+	P99     int64  `json:"p99"`
+}
+
+// latencyTracker - Round trips of GossipGrpc calls per delegate
+type latencyTracker struct {
+	mutex   sync.RWMutex
+	samples map[string][]time.Duration
+	next    map[string]int
+}
+
+// newLatencyTracker
+func newLatencyTracker() *latencyTracker {
+	return &latencyTracker{samples: make(map[string][]time.Duration), next: make(map[string]int)}
+}
+
+// Record - Keeps the last latencySamples round trips to the address
+func (this *latencyTracker) Record(address string, roundTrip time.Duration) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	samples := this.samples[address]
+	if len(samples) < latencySamples {
+		this.samples[address] = append(samples, roundTrip)
+		return
+	}
+	samples[this.next[address]] = roundTrip
+	this.next[address] = (this.next[address] + 1) % latencySamples
+}
+
+// Percentile - Percentile (0 to 100) of the round trips to the address, or of every delegate when address is empty
+func (this *latencyTracker) Percentile(address string, percentile float64) (time.Duration, int) {
+	this.mutex.RLock()
+	samples := make([]time.Duration, 0)
+	if address == "" {
+		for _, delegateSamples := range this.samples {
+			samples = append(samples, delegateSamples...)
+		}
+	} else {
+		samples = append(samples, this.samples[address]...)
+	}
+	this.mutex.RUnlock()
+
+	if len(samples) == 0 {
+		return 0, 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	rank := int(math.Ceil(percentile/100*float64(len(samples)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return samples[rank], len(samples)
+}
+
+// Addresses - Delegates with at least one round trip
+func (this *latencyTracker) Addresses() []string {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	addresses := make([]string, 0)
+	for address := range this.samples {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// gossipTimeout - Milliseconds allowed between rumors, twice the 99th percentile round trip within the configured
+// minimum and the governed maximum, the maximum wins. Each delegate derives it from its own round trips, so delegates can judge the
+// same rumors differently: one drops the gossip and judges the late delegates (ValidateTimeDelta) while another with
+// a longer timeout accepts it. Only gossip within the configured minimum is timely for every delegate.
+func (this *DAPoSService) gossipTimeout() int64 {
+	maxGossipTimeout := this.parameters().GossipTimeout
+	p99, count := this.latency.Percentile("", 99)
+	if count == 0 {
+		return maxGossipTimeout
+	}
+	timeout := int64(2 * p99 / time.Millisecond)
+	if timeout < this.config.MinGossipTimeout {
+		timeout = this.config.MinGossipTimeout
+	}
+
+	// Governance bounds it last, even a configured minimum above the governed maximum.
+	if timeout > maxGossipTimeout {
+		return maxGossipTimeout
+	}
+	return timeout
+}
+
+// txReceiveTimeout - Milliseconds allowed from a transaction's time until a delegate receives it, the governed value
+// scaled down like the gossip timeout within the configured minimum. Only the receiving delegate and local timers use
+// it, the execution check uses the governed value so every delegate decides the same.
+func (this *DAPoSService) txReceiveTimeout(parameters types.Parameters) int64 {
+	timeout := parameters.TxReceiveTimeout * this.gossipTimeout() / parameters.GossipTimeout
+	if timeout < this.config.MinTxReceiveTimeout {
+		timeout = this.config.MinTxReceiveTimeout
+	}
+	if timeout > parameters.TxReceiveTimeout {
+		return parameters.TxReceiveTimeout
+	}
+	return timeout
+}
+
+// executionDelay - How long a transaction waits once 2/3 of the delegates rumored, gossip reaches everyone in about log2(delegates) hops
+func (this *DAPoSService) executionDelay(delegates int) time.Duration {
+	hops := 1
+	if delegates > 1 {
+		hops = int(math.Ceil(math.Log2(float64(delegates)))) + 1
+	}
+	return time.Duration(this.txReceiveTimeout(this.parameters())+this.gossipTimeout()*int64(hops)) * time.Millisecond
+}
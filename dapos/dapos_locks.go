@@ -0,0 +1,91 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// releaseLocks - Removes the locks that ended from their accounts. Locked tokens are spendable from the end of the lock
+// on, the release only happens once no transaction still to execute can be older than that, so every delegate sees
+// the same spendable balance.
+func (this *DAPoSService) releaseLocks() {
+	this.releaseAt = 0
+	ended := utils.ToMilliSeconds(this.clock.Now().Add(-this.mempoolTtl()))
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+
+	addresses, next, err := types.ToAddressesWithLocksEndedBy(txn, ended)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	if next != 0 {
+		this.scheduleRelease(next)
+	}
+	if len(addresses) == 0 {
+		return
+	}
+
+	released := 0
+	for _, address := range addresses {
+		account, err := types.ToAccountByAddress(txn, address)
+		if err != nil {
+			utils.Error(fmt.Sprintf("unable to find account with locks [address=%s]", address), err)
+			continue
+		}
+		for _, lock := range account.Release(ended) {
+			err = lock.Unset(txn, address)
+			if err != nil {
+				utils.Error(err)
+				return
+			}
+			released++
+		}
+		err = account.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			return
+		}
+	}
+	err = txn.Commit(nil)
+	if err != nil {
+		if err == badger.ErrConflict {
+			this.scheduleRelease(ended)
+			return
+		}
+		utils.Error(err)
+		return
+	}
+	utils.Info(fmt.Sprintf("released locks [count=%d, accounts=%d]", released, len(addresses)))
+}
+
+// scheduleRelease - Releases the locks once the lock ending at until (milliseconds) can be removed, only called from
+// the transaction worker
+func (this *DAPoSService) scheduleRelease(until int64) {
+	if this.releaseAt != 0 && this.releaseAt <= until {
+		return
+	}
+	this.releaseAt = until
+	delay := time.Duration(until-utils.ToMilliSeconds(this.clock.Now()))*time.Millisecond + this.mempoolTtl()
+	this.signalAfter(delay, this.releaseChan)
+}
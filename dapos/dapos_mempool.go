@@ -0,0 +1,109 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/queue"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// Queue - What this delegate is holding, the mempool and the transactions that reached consensus
+type Queue struct {
+	Mempool   *queue.MempoolStats `json:"mempool"`
+	Execution []*types.Gossip     `json:"execution"`
+}
+
+// admitGossip - Adds first received gossip to the mempool, returns the status to reject it with (empty when admitted)
+func (this *DAPoSService) admitGossip(gossip *types.Gossip) (string, error) {
+	evicted, err := this.mempool.Add(gossip)
+	if evicted != "" {
+		this.evictTransaction(evicted)
+	}
+	switch err {
+	case nil:
+		return "", nil
+	case queue.ErrDuplicate:
+		return types.StatusAlreadyProcessingTransaction, err
+	case queue.ErrPoolFull:
+		return types.StatusMempoolFull, err
+	case queue.ErrAccountLimit:
+		return types.StatusAccountLimitReached, err
+	}
+	return types.StatusInternalError, err
+}
+
+// dispatchGossip - Hands the gossip to the gossip worker without blocking, mempool gossip that does not fit is
+// dispatched again by the mempool worker
+func (this *DAPoSService) dispatchGossip(gossip *types.Gossip) {
+	atomic.AddInt32(&this.working, 1)
+	select {
+	case this.gossipChan <- gossip:
+		this.mempool.SetDispatched(gossip.Transaction.Hash, true)
+	default:
+		atomic.AddInt32(&this.working, -1)
+		utils.Warn(fmt.Sprintf("gossip channel is full [hash=%s]", gossip.Transaction.Hash))
+		this.mempool.SetDispatched(gossip.Transaction.Hash, false)
+	}
+}
+
+// evictTransaction - A transaction evicted from the mempool is not executed by this delegate, even when it already
+// reached consensus
+func (this *DAPoSService) evictTransaction(hash string) {
+	this.gossipQueue.Remove(hash)
+	receipt, err := types.ToReceiptFromCache(this.db.GetCache(), hash)
+	if err != nil {
+		receipt = types.NewReceipt(hash, this.clock.Now())
+	}
+	this.setReceiptStatus(receipt, types.StatusMempoolFull, "Transaction evicted from the mempool by a higher priority transaction")
+}
+
+// mempoolWorker - Dispatches gossip that did not fit in the gossip channel (highest Hertz first) and expires
+// transactions that will never execute
+func (this *DAPoSService) mempoolWorker() {
+	interval := time.Duration(this.config.MempoolInterval) * time.Millisecond
+	if interval <= 0 {
+		return
+	}
+	for {
+		<-this.clock.After(interval)
+		for _, gossip := range this.mempool.Undispatched() {
+			if len(this.gossipChan) == cap(this.gossipChan) {
+				break
+			}
+			this.dispatchGossip(gossip)
+		}
+
+		for _, hash := range this.mempool.Expire(utils.ToMilliSeconds(this.clock.Now().Add(-this.mempoolTtl()))) {
+			utils.Warn(fmt.Sprintf("transaction expired in mempool [hash=%s]", hash))
+			this.expireTransaction(hash)
+		}
+	}
+}
+
+// mempoolTtl - How long a transaction can wait for consensus and execution
+func (this *DAPoSService) mempoolTtl() time.Duration {
+	delegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
+	if err != nil {
+		utils.Error(err)
+	}
+	return time.Duration(this.parameters().TxReceiveTimeout)*time.Millisecond + 2*this.executionDelay(len(delegates))
+}
@@ -0,0 +1,105 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/queue"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// recoverConsensus - Replays the mempool after a restart. Queued transactions execute when due, the others are
+// gossiped again (by the mempool worker) and the ones too old to reach consensus get a terminal receipt.
+func (this *DAPoSService) recoverConsensus() {
+	records := this.mempool.Records()
+	if len(records) == 0 {
+		return
+	}
+	utils.Info(fmt.Sprintf("recovering in-flight transactions [count=%d]", len(records)))
+
+	now := this.clock.Now()
+	ttl := this.mempoolTtl()
+	for _, record := range records {
+		gossip := record.Gossip
+		hash := gossip.Transaction.Hash
+
+		// Executed before we went down?
+		if this.isExecuted(gossip.Transaction) {
+			this.mempool.Remove(hash, queue.ReasonExecuted)
+			continue
+		}
+
+		receipt := this.recoverReceipt(hash)
+		receipt.Cache(this.db.GetCache(), this.receiptCacheTtl())
+		gossip.Cache(this.db.GetCache(), this.gossipCacheTtl())
+		gossip.Transaction.Cache(this.db.GetCache(), this.transactionCacheTtl())
+
+		switch record.Stage {
+		case queue.StageQueued:
+			utils.Info(fmt.Sprintf("recovered transaction queued for execution [hash=%s]", hash))
+			this.mempool.SetDispatched(hash, true)
+			this.queueForExecution(gossip, time.Duration(record.Due-utils.ToMilliSeconds(now))*time.Millisecond)
+		default:
+			if utils.ToMilliSeconds(now.Add(-ttl)) > gossip.Transaction.Time {
+				this.mempool.Remove(hash, queue.ReasonExpired)
+				this.expireTransaction(hash)
+				continue
+			}
+			utils.Info(fmt.Sprintf("recovered transaction to gossip [hash=%s, rumors=%d]", hash, len(gossip.Rumors)))
+		}
+	}
+}
+
+// recoverReceipt - The receipt persisted before the restart with the timeline so far, a new one when there is none
+func (this *DAPoSService) recoverReceipt(hash string) *types.Receipt {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	receipt, err := types.ToReceiptFromKey(txn, []byte(types.Receipt{TransactionHash: hash}.Key()))
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			utils.Error(err)
+		}
+		return types.NewReceipt(hash, this.clock.Now())
+	}
+	return receipt
+}
+
+// expireTransaction - Persists a terminal receipt for a transaction that will never execute, instead of leaving it pending
+func (this *DAPoSService) expireTransaction(hash string) {
+	receipt, err := types.ToReceiptFromCache(this.db.GetCache(), hash)
+	if err != nil {
+		receipt = types.NewReceipt(hash, this.clock.Now())
+	}
+	switch receipt.Status {
+	case types.StatusPending:
+		this.setReceiptStatus(receipt, types.StatusTransactionTimeOut, "Transaction did not reach consensus in time")
+	case types.StatusGossipingTimedOut, types.StatusCouldNotReachConsensus:
+		this.setReceiptStatus(receipt, receipt.Status, "Transaction did not reach consensus in time")
+	}
+}
+
+// isExecuted
+func (this *DAPoSService) isExecuted(transaction types.Transaction) bool {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	_, err := txn.Get([]byte(transaction.Key()))
+	return err == nil
+}
@@ -0,0 +1,216 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// Scoring, a delegate starts at a score of 1 and is banned while its score is below reputationBanScore
+const (
+	reputationDecay          = 0.2  // Weight of the newest call in the score
+	reputationInvalidPenalty = 0.25 // Score lost per invalid message
+	reputationLatePenalty    = 0.05 // Score lost per late rumor
+	reputationBanScore       = 0.2
+	reputationBanPeriod      = 5 * time.Minute
+	reputationProbationScore = 0.5 // Score of a delegate once its ban is over
+	reputationWeightScale    = 100 // Weight of a perfect score, multiplies the stake weight
+)
+
+// Reputation - How a delegate behaved as a gossip peer, latency is a moving average in milliseconds
+type Reputation struct {
+	Address     string    `json:"address"`
+	Score       float64   `json:"score"`
+	Latency     int64     `json:"latency"`
+	Successes   int64     `json:"successes"`
+	Errors      int64     `json:"errors"`
+	Invalid     int64     `json:"invalid"`
+	Late        int64     `json:"late"`
+	BannedUntil time.Time `json:"bannedUntil,omitempty"`
+}
+
+// reputationTracker - Reputations of the delegates gossiped with
+type reputationTracker struct {
+	mutex       sync.Mutex
+	clock       utils.Clock
+	reputations map[string]*Reputation
+}
+
+// newReputationTracker
+func newReputationTracker(clock utils.Clock) *reputationTracker {
+	return &reputationTracker{clock: clock, reputations: make(map[string]*Reputation)}
+}
+
+// RecordSuccess - A call answered, a round trip over the timeout moves the score down in proportion
+func (this *reputationTracker) RecordSuccess(address string, roundTrip time.Duration, timeout time.Duration) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	reputation := this.get(address)
+	reputation.Successes++
+	if reputation.Successes == 1 {
+		reputation.Latency = int64(roundTrip / time.Millisecond)
+	} else {
+		reputation.Latency += int64(reputationDecay * float64(int64(roundTrip/time.Millisecond)-reputation.Latency))
+	}
+	target := 1.0
+	if roundTrip > timeout && roundTrip > 0 {
+		target = float64(timeout) / float64(roundTrip)
+	}
+	this.score(reputation, reputation.Score+reputationDecay*(target-reputation.Score))
+}
+
+// RecordError - A call failed or timed out
+func (this *reputationTracker) RecordError(address string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	reputation := this.get(address)
+	reputation.Errors++
+	this.score(reputation, reputation.Score-reputationDecay*reputation.Score)
+}
+
+// RecordInvalid - The delegate signed or answered with something that does not verify
+func (this *reputationTracker) RecordInvalid(address string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	reputation := this.get(address)
+	reputation.Invalid++
+	this.score(reputation, reputation.Score-reputationInvalidPenalty)
+}
+
+// RecordLate - The delegate rumored later than the gossip timeout allows
+func (this *reputationTracker) RecordLate(address string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	reputation := this.get(address)
+	reputation.Late++
+	this.score(reputation, reputation.Score-reputationLatePenalty)
+}
+
+// Banned - Whether the delegate is banned, a ban that is over puts the delegate on probation
+func (this *reputationTracker) Banned(address string) bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	reputation, ok := this.reputations[address]
+	if !ok {
+		return false
+	}
+	return this.banned(reputation)
+}
+
+// Weight - Chance of the delegate to be picked relative to the others, 0 while banned
+func (this *reputationTracker) Weight(address string) int64 {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	reputation, ok := this.reputations[address]
+	if !ok {
+		return reputationWeightScale + 1
+	}
+	if this.banned(reputation) {
+		return 0
+	}
+	return int64(reputation.Score*reputationWeightScale) + 1
+}
+
+// Reputations - Every delegate scored, sorted by address
+func (this *reputationTracker) Reputations() []Reputation {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	reputations := make([]Reputation, 0, len(this.reputations))
+	for _, reputation := range this.reputations {
+		this.banned(reputation)
+		reputations = append(reputations, *reputation)
+	}
+	sort.Slice(reputations, func(i, j int) bool { return reputations[i].Address < reputations[j].Address })
+	return reputations
+}
+
+// get
+func (this *reputationTracker) get(address string) *Reputation {
+	reputation, ok := this.reputations[address]
+	if !ok {
+		reputation = &Reputation{Address: address, Score: 1}
+		this.reputations[address] = reputation
+	}
+	return reputation
+}
+
+// score - Sets the score within 0 and 1, bans the delegate when it falls below reputationBanScore
+func (this *reputationTracker) score(reputation *Reputation, score float64) {
+	if score < 0 {
+		score = 0
+	}
+	if score > 1 {
+		score = 1
+	}
+	reputation.Score = score
+	if score < reputationBanScore && !this.banned(reputation) {
+		reputation.BannedUntil = this.clock.Now().Add(reputationBanPeriod)
+		utils.Warn(fmt.Sprintf("banned delegate [address=%s, score=%.2f, until=%s]", reputation.Address, score, reputation.BannedUntil))
+	}
+}
+
+// banned - Lifts a ban that is over
+func (this *reputationTracker) banned(reputation *Reputation) bool {
+	if reputation.BannedUntil.IsZero() {
+		return false
+	}
+	if this.clock.Now().Before(reputation.BannedUntil) {
+		return true
+	}
+	reputation.BannedUntil = time.Time{}
+	reputation.Score = reputationProbationScore
+	utils.Info(fmt.Sprintf("lifted ban of delegate [address=%s]", reputation.Address))
+	return false
+}
+
+// judgeTransaction - Called when the transaction of the gossip does not verify. A rumor that verifies is signed evidence
+// its delegate vouched for the transaction, so every such delegate is penalized. Rumors that do not verify cannot be
+// attributed.
+func (this *DAPoSService) judgeTransaction(gossip *types.Gossip) {
+	for _, rumor := range gossip.Rumors {
+		if rumor.Address != this.account.Address && rumor.TransactionHash == gossip.Transaction.Hash && rumor.Verify(this.networkId()) {
+			this.reputation.RecordInvalid(rumor.Address)
+		}
+	}
+}
+
+// judgeResponse - The delegate answered with rumors that do not verify
+func (this *DAPoSService) judgeResponse(address string, gossip *types.Gossip) {
+	for _, rumor := range gossip.Rumors {
+		if !rumor.Verify(this.networkId()) {
+			this.reputation.RecordInvalid(address)
+			return
+		}
+	}
+}
+
+// judgeTimeliness - Penalizes every delegate that rumored more than the gossip timeout after the rumor before it
+func (this *DAPoSService) judgeTimeliness(rumors []types.Rumor, gossipTimeout int64) {
+	sorted := append([]types.Rumor{}, rumors...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Time-sorted[i-1].Time > gossipTimeout && sorted[i].Address != this.account.Address {
+			this.reputation.RecordLate(sorted[i].Address)
+		}
+	}
+}
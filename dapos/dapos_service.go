@@ -17,17 +17,17 @@
 package dapos
 
 import (
+	"math/rand"
 	"sync"
+	"sync/atomic"
 
-	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/services"
-	"time"
 
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/disgover"
 	"github.com/dispatchlabs/disgo/commons/queue"
-	"math/big"
+	"time"
 )
 
 var daposServiceInstance *DAPoSService
@@ -36,24 +36,72 @@ var daposServiceOnce sync.Once
 // GetDAPoSService
 func GetDAPoSService() *DAPoSService {
 	daposServiceOnce.Do(func() {
-		daposServiceInstance = &DAPoSService{
-			running: false,
-			gossipChan: make(chan *types.Gossip, 1000),
-			queueChan: make(chan *types.Gossip, 1000),
-			timoutChan: make(chan bool, 1000),
-			gossipQueue: queue.NewGossipQueue(),
-		} // TODO: What should this be?
+		daposServiceInstance = NewDAPoSService(services.GetDbService(), types.GetAccount(), types.GetConfig(), utils.Events(), utils.NewSystemClock(), disgover.GetDisGoverService())
 	})
 	return daposServiceInstance
 }
 
+// NewDAPoSService - DAPoS service with its own DB, keys, config, events and clock (eg; one per simulated node)
+func NewDAPoSService(db *services.DbService, account *types.Account, config *types.Config, events *utils.EventManager, clock utils.Clock, disGover *disgover.DisGoverService) *DAPoSService {
+	this := &DAPoSService{
+		running: false,
+		gossipChan: make(chan *types.Gossip, 1000),
+		queueChan: make(chan *types.Gossip, 1000),
+		timoutChan: make(chan bool, 1000),
+		releaseChan: make(chan bool, 1000),
+		tallyChan: make(chan bool, 1000),
+		gossipQueue: queue.NewGossipQueue(),
+		mempool: queue.NewMempool(db, config.MempoolSize, config.MempoolAccountLimit),
+		delegateMap: map[string]*types.Node{},
+		defaults: types.NewParameters(),
+		latency: newLatencyTracker(),
+		reputation: newReputationTracker(clock),
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+		db: db,
+		account: account,
+		config: config,
+		events: events,
+		clock: clock,
+		disGover: disGover,
+	} // TODO: What should this be?
+	if config.GossipBatchWindow > 0 {
+		this.batcher = newGossipBatcher(clock, time.Duration(config.GossipBatchWindow)*time.Millisecond, config.GossipBatchSize, this.peerGossipBatchGrpc)
+	}
+	return this
+}
+
 // DAPoSService -
 type DAPoSService struct {
 	running         bool
 	gossipChan      chan *types.Gossip
 	queueChan      	chan *types.Gossip
 	timoutChan 		chan bool
+	releaseChan     chan bool
+	releaseAt       int64 // Milliseconds, end of the lock the next release is scheduled for
+	tallyChan       chan bool
+	tallyAt         int64 // Milliseconds, close of the voting the next tally is scheduled for
+	defaults        types.Parameters // Genesis values
+	approved        []*types.Proposal // Parameter changes, in order of activation
+	parametersMutex sync.RWMutex
 	gossipQueue 	*queue.GossipQueue
+	mempool         *queue.Mempool
+	transport       DAPoSTransport
+	delegateMap     map[string]*types.Node
+	latency         *latencyTracker
+	reputation      *reputationTracker
+	batcher         *gossipBatcher
+	working         int32 // Gossips and timer signals not handled yet
+	initialized     int32 // Set once the DB is synchronized and the genesis created, other delegates synchronize from it
+	gossipMutex     sync.Mutex
+	receiptMutex    sync.Mutex
+	random          *rand.Rand // Picks the delegates to gossip with, seeded once
+	randomMutex     sync.Mutex
+	db              *services.DbService
+	account         *types.Account
+	config          *types.Config
+	events          *utils.EventManager
+	clock           utils.Clock
+	disGover        *disgover.DisGoverService
 }
 
 // IsRunning -
@@ -61,59 +109,59 @@ func (this *DAPoSService) IsRunning() bool {
 	return this.running
 }
 
+// Working - Gossips, timer signals and batches not handled yet, zero when nothing is gossiped or executed until the
+// clock moves on (eg; a simulated network has settled)
+func (this *DAPoSService) Working() int {
+	working := int(atomic.LoadInt32(&this.working))
+	if this.batcher != nil {
+		working += this.batcher.Sending()
+	}
+	return working
+}
+
 // Go -
 func (this *DAPoSService) Go() {
 	this.running = true
 	utils.Info("running, waiting for delegates sync")
 
-	utils.Events().On(
+	this.events.On(
 		types.Events.DisGoverServiceInitFinished,
 		this.disGoverServiceInitFinished,
 	)
+	this.disGover.OnAvailability(this.nodeAvailabilityChanged)
 }
 
 // OnEvent - Event to
 func (this *DAPoSService) disGoverServiceInitFinished() {
 
-	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+	if this.disGover.ThisNode.Type == types.TypeDelegate {
 		this.peerSynchronize()
 	}
 
-	// Create genesis transaction.
-	err := this.createGenesisTransactionAndAccount()
+	// Create genesis.
+	err := this.createGenesis()
 	if err != nil {
-		services.GetDbService().Close()
+		this.db.Close()
 		utils.Fatal("unable to create genesis block", err)
 	}
 
+	// Pick up where we left off.
+	err = this.mempool.Load()
+	if err != nil {
+		utils.Error("unable to load mempool", err)
+	}
+	this.recoverConsensus()
+	this.releaseLocks()
+	this.cacheDelegateStakes()
+	this.loadApprovedProposals()
+	this.tallyProposals()
+
 	go this.gossipWorker()
 	go this.transactionWorker()
+	go this.mempoolWorker()
 	//go this.queueWorker()
 
-	utils.Events().Raise(types.Events.DAPoSServiceInitFinished)
-}
+	atomic.StoreInt32(&this.initialized, 1)
 
-// createGenesisTransactionAndAccount
-func (this *DAPoSService) createGenesisTransactionAndAccount() error {
-	txn := services.GetDb().NewTransaction(true)
-	defer txn.Discard()
-	transaction, err := types.ToTransactionFromJson([]byte(types.GetConfig().GenesisTransaction))
-	if err != nil {
-		return err
-	}
-	_, err = types.ToTransactionByKey(txn, []byte(transaction.Key()))
-	if err != nil {
-		if err == badger.ErrKeyNotFound {
-			err = transaction.Set(txn,services.GetCache())
-			if err != nil {
-				return err
-			}
-			account := &types.Account{Address: transaction.To, Name: "Dispatch Labs", Balance: big.NewInt(transaction.Value), Updated: time.Now(), Created: time.Now()}
-			err = account.Set(txn,services.GetCache())
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return txn.Commit(nil)
+	this.events.Raise(types.Events.DAPoSServiceInitFinished)
 }
@@ -0,0 +1,66 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// addDelegateStake - Adds the value (negative when unstaking) to the delegate's total, cache it once committed
+func (this *DAPoSService) addDelegateStake(txn *badger.Txn, delegate string, value int64, stakers int) (*types.DelegateStake, error) {
+	delegateStake, err := types.ToDelegateStake(txn, delegate)
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			return nil, err
+		}
+		delegateStake = &types.DelegateStake{Delegate: delegate}
+	}
+	delegateStake.Total += value
+	delegateStake.Stakers += stakers
+	delegateStake.Updated = this.clock.Now()
+	err = delegateStake.Persist(txn)
+	if err != nil {
+		return nil, err
+	}
+	return delegateStake, nil
+}
+
+// cacheDelegateStakes - Gossip picks delegates by stake from the cache
+func (this *DAPoSService) cacheDelegateStakes() {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	delegateStakes, err := types.ToDelegateStakes(txn)
+	if err != nil {
+		utils.Error("unable to load delegate stakes", err)
+		return
+	}
+	for _, delegateStake := range delegateStakes {
+		delegateStake.Cache(this.db.GetCache())
+	}
+}
+
+// stakeWeight - Chance of the delegate to be picked as a gossip peer, delegates without stake can still be picked.
+// Stake weights gossip and the fee shares only, it does not elect the delegates, the genesis lists them.
+func (this *DAPoSService) stakeWeight(delegate string) int64 {
+	delegateStake, err := types.ToDelegateStakeFromCache(this.db.GetCache(), delegate)
+	if err != nil || delegateStake.Total < 0 {
+		return 1
+	}
+	return delegateStake.Total + 1
+}
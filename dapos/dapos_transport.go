@@ -0,0 +1,59 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+)
+
+// DAPoSTransport - How a delegate serves its peers and reaches them
+type DAPoSTransport interface {
+	Serve(server proto.DAPoSGrpcServer)
+	NewClient(node *types.Node) (proto.DAPoSGrpcClient, error)
+}
+
+// WithTransport - Serves this delegate over the given transport and uses it to reach peers
+func (this *DAPoSService) WithTransport(transport DAPoSTransport) *DAPoSService {
+	this.transport = transport
+	transport.Serve(this)
+	return this
+}
+
+// NewGrpcTransport
+func NewGrpcTransport() DAPoSTransport {
+	return &grpcTransport{}
+}
+
+// grpcTransport
+type grpcTransport struct {
+}
+
+// Serve
+func (this *grpcTransport) Serve(server proto.DAPoSGrpcServer) {
+	proto.RegisterDAPoSGrpcServer(services.GetGrpcService().Server, server)
+}
+
+// NewClient
+func (this *grpcTransport) NewClient(node *types.Node) (proto.DAPoSGrpcClient, error) {
+	conn, err := services.GetGrpcConnection(node.Address, node.GrpcEndpoint.Host, node.GrpcEndpoint.Port)
+	if err != nil {
+		return nil, err
+	}
+	return proto.NewDAPoSGrpcClient(conn), nil
+}
@@ -17,34 +17,40 @@
 package dapos
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/dgraph-io/badger"
-	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/pkg/errors"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/dapos/proto"
-	"github.com/dispatchlabs/disgo/disgover"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"strings"
+	"sync/atomic"
 )
 
 // TODO: Should we GZIP the response from remote call?
 
 // WithGrpc -
 func (this *DAPoSService) WithGrpc() *DAPoSService {
-	proto.RegisterDAPoSGrpcServer(services.GetGrpcService().Server, this)
-	return this
+	return this.WithTransport(NewGrpcTransport())
 }
 
 
 // SynchronizeGrpc
 func (this *DAPoSService) SynchronizeGrpc(constext context.Context, request *proto.SynchronizeRequest) (*proto.SynchronizeResponse, error) {
 	utils.Info("synchronizing DB with a delegate...")
+
+	// Still synchronizing our own DB? The records would shift between pages.
+	if atomic.LoadInt32(&this.initialized) == 0 {
+		return nil, errors.New("delegate is still synchronizing its DB")
+	}
 	var items = make([]*proto.Item, 0)
-	err := services.GetDb().View(func(txn *badger.Txn) error {
+	err := this.db.GetDb().View(func(txn *badger.Txn) error {
 		opts := badger.DefaultIteratorOptions
 		opts.PrefetchSize = 100
 		it := txn.NewIterator(opts)
@@ -86,7 +92,7 @@ func (this *DAPoSService) peerSynchronize() {
 	utils.Info("synchronizing DB with peer delegate...")
 
 	// Find delegate nodes.
-	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(),types.TypeDelegate)
+	delegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(),types.TypeDelegate)
 	if err != nil {
 		utils.Error(err)
 		return
@@ -98,57 +104,109 @@ func (this *DAPoSService) peerSynchronize() {
 	for _, delegate := range delegates {
 
 		// Is this me?
-		if delegate.Address == disgover.GetDisGoverService().ThisNode.Address {
+		if delegate.Address == this.disGover.ThisNode.Address {
 			continue
 		}
 		// Connect to delegate.
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), grpc.WithInsecure())
+		client, err := this.transport.NewClient(delegate)
 		if err != nil {
 			utils.Warn(fmt.Sprintf("unable to connect to delegate [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
 			continue
 		}
-		defer conn.Close()
-		client := proto.NewDAPoSGrpcClient(conn)
-		contextWithTimeout, cancel := context.WithCancel(context.Background())
-		defer cancel()
 
 		// Synchronize
-		var index int64 = 0
-		for {
-			txn := services.NewTxn(true)
-			defer txn.Discard()
-			response, err := client.SynchronizeGrpc(contextWithTimeout, &proto.SynchronizeRequest{Index: index})
-			if err != nil {
-				utils.Warn(fmt.Sprintf("unable to synchronize with delegate [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
-				continue
-			}
-			if len(response.Items) == 0 {
-				break
-			}
-			for _, item := range response.Items {
-				err = txn.Set([]byte(item.Key), item.Value)
-				if err != nil {
-					utils.Error(err)
-				}
-			}
-			index += int64(len(response.Items))
-			err = txn.Commit(nil)
+		index, err := this.synchronizeWith(client)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to synchronize with delegate [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
+			continue
+		}
+		utils.Info(fmt.Sprintf("synchronized %d records from peer delegate's DB", index))
+		return
+	}
+}
+
+// synchronizeWith - Copies the delegate's DB page by page, returns the number of records copied
+func (this *DAPoSService) synchronizeWith(client proto.DAPoSGrpcClient) (int64, error) {
+	var index int64 = 0
+	for {
+		contextWithTimeout, cancel := context.WithTimeout(context.Background(), time.Duration(this.parameters().GrpcTimeout)*time.Millisecond)
+		response, err := client.SynchronizeGrpc(contextWithTimeout, &proto.SynchronizeRequest{Index: index})
+		cancel()
+		if err != nil {
+			return index, err
+		}
+		if len(response.Items) == 0 {
+			return index, nil
+		}
+		txn := this.db.NewTxn(true)
+		for _, item := range response.Items {
+			err = txn.Set([]byte(item.Key), item.Value)
 			if err != nil {
 				utils.Error(err)
 			}
 		}
-		utils.Info(fmt.Sprintf("synchronized %d records from peer delegate's DB", index))
-		return
+		index += int64(len(response.Items))
+		err = txn.Commit(nil)
+		if err != nil {
+			utils.Error(err)
+		}
+		txn.Discard()
 	}
 }
 
-// Gossip
+// GossipGrpc - Gossip as JSON from the delegates that do not have TypedGossipGrpc yet
 func (this *DAPoSService) GossipGrpc(context context.Context, request *proto.Request) (*proto.Response, error) {
 	gossip, err := types.ToGossipFromJson([]byte(request.Payload))
 	if err != nil {
 		utils.Error(err)
 		return nil, err
 	}
+	synchronizedGossip, err := this.acceptGossip(gossip)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Response{Payload: synchronizedGossip.String()}, nil
+}
+
+// TypedGossipGrpc
+func (this *DAPoSService) TypedGossipGrpc(context context.Context, request *proto.Gossip) (*proto.Gossip, error) {
+	synchronizedGossip, err := this.receiveGossip(request)
+	if err != nil {
+		return nil, err
+	}
+	return convertToProtoGossip(synchronizedGossip)
+}
+
+// GossipBatchGrpc - Gossip that cannot be synchronized is left out of the response, the sender gossips it again
+func (this *DAPoSService) GossipBatchGrpc(context context.Context, batch *proto.GossipBatch) (*proto.GossipBatch, error) {
+	response := &proto.GossipBatch{Gossips: make([]*proto.Gossip, 0, len(batch.Gossips))}
+	for _, gossip := range batch.Gossips {
+		synchronizedGossip, err := this.receiveGossip(gossip)
+		if err != nil {
+			continue
+		}
+		protoGossip, err := convertToProtoGossip(synchronizedGossip)
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		response.Gossips = append(response.Gossips, protoGossip)
+	}
+	return response, nil
+}
+
+// receiveGossip
+func (this *DAPoSService) receiveGossip(protoGossip *proto.Gossip) (*types.Gossip, error) {
+	gossip, err := convertToDomainGossip(protoGossip)
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+	return this.acceptGossip(gossip)
+}
+
+// acceptGossip
+func (this *DAPoSService) acceptGossip(gossip *types.Gossip) (*types.Gossip, error) {
 
 	// Synchronize gossip.
 	synchronizedGossip, err, addToChan := this.synchronizeGossip(gossip)
@@ -157,51 +215,472 @@ func (this *DAPoSService) GossipGrpc(context context.Context, request *proto.Req
 		return nil, err
 	}
 
-	// Gossip what we got from our peer delegate.
+	// Gossip what we got from our peer delegate, merged with the rumors we already had.
 	if(addToChan) {
-		this.gossipChan <- gossip
+		this.dispatchGossip(synchronizedGossip)
 	}
-
-	return &proto.Response{Payload: synchronizedGossip.String()}, nil
+	return this.snapshotGossip(synchronizedGossip), nil
 }
 
 // peerGossipGrpc
 func (this *DAPoSService) peerGossipGrpc(node types.Node, gossip *types.Gossip) (*types.Gossip, error) {
 	utils.Debug(fmt.Sprintf("attempting to gossip with delegate [address=%s]", node.Address))
 
-	conn, err := services.GetGrpcConnection(node.Address, node.GrpcEndpoint.Host, node.GrpcEndpoint.Port)
+	client, err := this.transport.NewClient(&node)
 	if err != nil {
 		utils.Error(fmt.Sprintf("cannot dial seed [host=%s, port=%d]",  node.GrpcEndpoint.Host,  node.GrpcEndpoint.Port), err)
 		return nil, err
 	}
-	client := proto.NewDAPoSGrpcClient(conn)
 
-	contextWithTimeout, cancel := context.WithTimeout(context.Background(), 20000*time.Millisecond)
+	contextWithTimeout, cancel := context.WithTimeout(context.Background(), time.Duration(this.parameters().GrpcTimeout)*time.Millisecond)
 	defer cancel()
 
-	// Remote gossip.
-	response, err := client.GossipGrpc(contextWithTimeout, &proto.Request{Payload: gossip.String()})
+	request, err := convertToProtoGossip(gossip)
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+
+	// Remote gossip, as JSON to the delegates that do not have TypedGossipGrpc yet.
+	start := this.clock.Now()
+	var legacyResponse *proto.Response
+	response, err := client.TypedGossipGrpc(contextWithTimeout, request)
+	if status.Code(err) == codes.Unimplemented {
+		legacyResponse, err = client.GossipGrpc(contextWithTimeout, &proto.Request{Payload: gossip.String()})
+	}
 	if err != nil {
 		utils.Error(fmt.Sprintf("cannot connect to node [host=%s, port=%d]", node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), err)
+		this.reputation.RecordError(node.Address)
+		this.setNodeUnavailable(node)
+		return nil, err
+	}
+	this.recordRoundTrip(node.Address, this.clock.Now().Sub(start))
+	var remoteGossip *types.Gossip
+	if legacyResponse != nil {
+		remoteGossip, err = types.ToGossipFromJson([]byte(legacyResponse.Payload))
+	} else {
+		remoteGossip, err = convertToDomainGossip(response)
+	}
+	if err != nil {
+		utils.Error(err)
+		this.reputation.RecordInvalid(node.Address)
+		return nil, err
+	}
+	this.judgeResponse(node.Address, remoteGossip)
+	utils.Debug(fmt.Sprintf("sent gossip [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
+	remoteGossip.CacheSentDelegate(this.db.GetCache(), gossip.Transaction.Hash, node.Address)
+
+	return remoteGossip, err
+}
+
+// peerGossipBatchGrpc - Sends a batch, on failure every gossip goes back on the gossip channel
+func (this *DAPoSService) peerGossipBatchGrpc(node types.Node, gossips []*types.Gossip) {
+	utils.Debug(fmt.Sprintf("attempting to gossip batch with delegate [address=%s, gossips=%d]", node.Address, len(gossips)))
+
+	batch := &proto.GossipBatch{Gossips: make([]*proto.Gossip, 0, len(gossips))}
+	for _, gossip := range gossips {
+		protoGossip, err := convertToProtoGossip(gossip)
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		batch.Gossips = append(batch.Gossips, protoGossip)
+	}
+	response, err := this.callGossipBatchGrpc(node, batch)
+	if status.Code(err) == codes.Unimplemented {
 
-		txn := services.NewTxn(true)
-		defer txn.Discard()
-		node.Status = types.StatusNodeUnavailable
-		node.StatusTime = time.Now()
+		// A delegate without GossipBatchGrpc yet, one gossip at a time, gossip it did not take goes back on the channel.
+		for _, gossip := range gossips {
+			_, err := this.peerGossipGrpc(node, gossip)
+			if err != nil {
+				this.dispatchGossip(gossip)
+			}
+		}
+		return
+	}
+	if err != nil {
+		utils.Error(fmt.Sprintf("cannot gossip batch with node [host=%s, port=%d]", node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), err)
+		this.setNodeUnavailable(node)
+		for _, gossip := range gossips {
+			this.dispatchGossip(gossip)
+		}
+		return
+	}
+	synchronized := make(map[string]bool)
+	for _, protoGossip := range response.Gossips {
+		remoteGossip, err := convertToDomainGossip(protoGossip)
+		if err != nil {
+			utils.Error(err)
+			this.reputation.RecordInvalid(node.Address)
+			continue
+		}
+		this.judgeResponse(node.Address, remoteGossip)
+		remoteGossip.CacheSentDelegate(this.db.GetCache(), remoteGossip.Transaction.Hash, node.Address)
+		synchronized[remoteGossip.Transaction.Hash] = true
+	}
+
+	// Gossip the delegate could not synchronize goes back on the gossip channel, as it does when sent alone.
+	for _, gossip := range gossips {
+		if !synchronized[gossip.Transaction.Hash] {
+			utils.Warn(fmt.Sprintf("delegate did not synchronize gossip [hash=%s, address=%s]", gossip.Transaction.Hash, node.Address))
+			this.dispatchGossip(gossip)
+		}
+	}
+	utils.Debug(fmt.Sprintf("sent gossip batch [gossips=%d] to delegate [address=%s]", len(gossips), node.Address))
+}
+
+// callGossipBatchGrpc
+func (this *DAPoSService) callGossipBatchGrpc(node types.Node, batch *proto.GossipBatch) (*proto.GossipBatch, error) {
+	client, err := this.transport.NewClient(&node)
+	if err != nil {
+		return nil, err
+	}
+	contextWithTimeout, cancel := context.WithTimeout(context.Background(), time.Duration(this.parameters().GrpcTimeout)*time.Millisecond)
+	defer cancel()
 
-		setErr := node.Set(txn, services.GetCache())
-		if setErr != nil {
-			utils.Error(setErr)
+	start := this.clock.Now()
+	response, err := client.GossipBatchGrpc(contextWithTimeout, batch)
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			this.reputation.RecordError(node.Address)
 		}
 		return nil, err
 	}
-	remoteGossip, err := types.ToGossipFromJson([]byte(response.Payload))
+	this.recordRoundTrip(node.Address, this.clock.Now().Sub(start))
+	return response, nil
+}
+
+// recordRoundTrip - Feeds the round trip of a call that answered to the timeouts and the delegate's reputation
+func (this *DAPoSService) recordRoundTrip(address string, roundTrip time.Duration) {
+	this.latency.Record(address, roundTrip)
+	this.reputation.RecordSuccess(address, roundTrip, time.Duration(this.gossipTimeout())*time.Millisecond)
+}
+
+// setNodeUnavailable
+func (this *DAPoSService) setNodeUnavailable(node types.Node) {
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+	node.Status = types.StatusNodeUnavailable
+	node.StatusTime = this.clock.Now()
+
+	err := node.Set(txn, this.db.GetCache())
+	if err != nil {
+		utils.Error(err)
+	}
+}
+
+// setNodeAvailable
+func (this *DAPoSService) setNodeAvailable(node types.Node) {
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+	node.Status = types.StatusNodeAvailable
+	node.StatusTime = this.clock.Now()
+
+	err := node.Set(txn, this.db.GetCache())
 	if err != nil {
 		utils.Error(err)
+	}
+}
+
+// nodeAvailabilityChanged - DisGover's health checks found the node became available or unavailable, only nodes
+// already known are updated
+func (this *DAPoSService) nodeAvailabilityChanged(node *types.Node, available bool) {
+	cached, err := types.ToNodeFromCache(this.db.GetCache(), node.Address)
+	if err != nil {
+		return
+	}
+	if available {
+		this.setNodeAvailable(*cached)
+	} else {
+		this.setNodeUnavailable(*cached)
+	}
+}
+
+// convertToDomainGossip
+func convertToDomainGossip(gossip *proto.Gossip) (*types.Gossip, error) {
+	if gossip == nil || gossip.Transaction == nil {
+		return nil, errors.New("gossip is missing its transaction")
+	}
+	transaction, err := convertToDomainTransaction(gossip.Transaction)
+	if err != nil {
 		return nil, err
 	}
-	utils.Debug(fmt.Sprintf("sent gossip [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
-	remoteGossip.CacheSentDelegate(services.GetCache(), gossip.Transaction.Hash, node.Address)
+	return &types.Gossip{
+		Transaction: *transaction,
+		Rumors:      convertToDomainRumors(gossip.Rumors),
+	}, nil
+}
 
-	return remoteGossip, err
+// convertToProtoGossip
+func convertToProtoGossip(gossip *types.Gossip) (*proto.Gossip, error) {
+	transaction, err := convertToProtoTransaction(&gossip.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Gossip{
+		Transaction: transaction,
+		Rumors:      convertToProtoRumors(gossip.Rumors),
+	}, nil
+}
+
+// convertToDomainTransaction
+func convertToDomainTransaction(transaction *proto.Transaction) (*types.Transaction, error) {
+	var params []interface{}
+	if len(transaction.Params) > 0 {
+		err := json.Unmarshal(transaction.Params, &params)
+		if err != nil {
+			return nil, errors.Wrap(err, "value for field 'params' must be an array")
+		}
+	}
+	receipt, err := convertToDomainReceipt(transaction.Receipt)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Transaction{
+		Hash:           transaction.Hash,
+		Type:           byte(transaction.Type),
+		From:           transaction.From,
+		To:             transaction.To,
+		Value:          transaction.Value,
+		Code:           transaction.Code,
+		Abi:            transaction.Abi,
+		Method:         transaction.Method,
+		Params:         params,
+		Time:           transaction.Time,
+		Signature:      transaction.Signature,
+		Hertz:          transaction.Hertz,
+		Outputs:        convertToDomainOutputs(transaction.Outputs),
+		Signers:        transaction.Signers,
+		Threshold:      int(transaction.Threshold),
+		Signatures:     transaction.Signatures,
+		UnlockTime:     transaction.UnlockTime,
+		Name:           transaction.Name,
+		Fee:            transaction.Fee,
+		Parameter:      transaction.Parameter,
+		ActivationTime: transaction.ActivationTime,
+		Proposal:       transaction.Proposal,
+		Approve:        transaction.Approve,
+		NetworkId:      transaction.NetworkId,
+		Receipt:        *receipt,
+		Gossip:         convertToDomainRumors(transaction.Gossip),
+		FromName:       transaction.FromName,
+		ToName:         transaction.ToName,
+	}, nil
+}
+
+// convertToProtoTransaction
+func convertToProtoTransaction(transaction *types.Transaction) (*proto.Transaction, error) {
+	var params []byte
+	if transaction.Params != nil {
+		var err error
+		params, err = json.Marshal(transaction.Params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	receipt, err := convertToProtoReceipt(&transaction.Receipt)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Transaction{
+		Hash:           transaction.Hash,
+		Type:           uint32(transaction.Type),
+		From:           transaction.From,
+		To:             transaction.To,
+		Value:          transaction.Value,
+		Code:           transaction.Code,
+		Abi:            transaction.Abi,
+		Method:         transaction.Method,
+		Params:         params,
+		Time:           transaction.Time,
+		Signature:      transaction.Signature,
+		Hertz:          transaction.Hertz,
+		Outputs:        convertToProtoOutputs(transaction.Outputs),
+		Signers:        transaction.Signers,
+		Threshold:      int64(transaction.Threshold),
+		Signatures:     transaction.Signatures,
+		UnlockTime:     transaction.UnlockTime,
+		Name:           transaction.Name,
+		Fee:            transaction.Fee,
+		Parameter:      transaction.Parameter,
+		ActivationTime: transaction.ActivationTime,
+		Proposal:       transaction.Proposal,
+		Approve:        transaction.Approve,
+		NetworkId:      transaction.NetworkId,
+		Receipt:        receipt,
+		Gossip:         convertToProtoRumors(transaction.Gossip),
+		FromName:       transaction.FromName,
+		ToName:         transaction.ToName,
+	}, nil
+}
+
+// convertToDomainOutputs
+func convertToDomainOutputs(outputs []*proto.Output) []types.Output {
+	if len(outputs) == 0 {
+		return nil
+	}
+	domainOutputs := make([]types.Output, 0, len(outputs))
+	for _, output := range outputs {
+		domainOutputs = append(domainOutputs, types.Output{To: output.To, Value: output.Value})
+	}
+	return domainOutputs
+}
+
+// convertToProtoOutputs
+func convertToProtoOutputs(outputs []types.Output) []*proto.Output {
+	protoOutputs := make([]*proto.Output, 0, len(outputs))
+	for _, output := range outputs {
+		protoOutputs = append(protoOutputs, &proto.Output{To: output.To, Value: output.Value})
+	}
+	return protoOutputs
+}
+
+// convertToDomainReceipt
+func convertToDomainReceipt(receipt *proto.Receipt) (*types.Receipt, error) {
+	if receipt == nil {
+		return &types.Receipt{}, nil
+	}
+	var contractResult []interface{}
+	if len(receipt.ContractResult) > 0 {
+		err := json.Unmarshal(receipt.ContractResult, &contractResult)
+		if err != nil {
+			return nil, errors.Wrap(err, "value for field 'contractResult' must be an array")
+		}
+	}
+	var created time.Time
+	if receipt.Created != 0 {
+		created = time.Unix(0, receipt.Created).UTC()
+	}
+	var updated time.Time
+	if receipt.Updated != 0 {
+		updated = time.Unix(0, receipt.Updated).UTC()
+	}
+	return &types.Receipt{
+		TransactionHash:     receipt.TransactionHash,
+		Status:              receipt.Status,
+		HumanReadableStatus: receipt.HumanReadableStatus,
+		ContractAddress:     receipt.ContractAddress,
+		ContractResult:      contractResult,
+		HertzUsed:           receipt.HertzUsed,
+		Fee:                 receipt.Fee,
+		Rewards:             convertToDomainRewards(receipt.Rewards),
+		Created:             created,
+		Updated:             updated,
+		Timeline:            convertToDomainReceiptEvents(receipt.Timeline),
+	}, nil
+}
+
+// convertToProtoReceipt
+func convertToProtoReceipt(receipt *types.Receipt) (*proto.Receipt, error) {
+	var contractResult []byte
+	if receipt.ContractResult != nil {
+		var err error
+		contractResult, err = json.Marshal(receipt.ContractResult)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var created int64
+	if !receipt.Created.IsZero() {
+		created = receipt.Created.UnixNano()
+	}
+	var updated int64
+	if !receipt.Updated.IsZero() {
+		updated = receipt.Updated.UnixNano()
+	}
+	return &proto.Receipt{
+		TransactionHash:     receipt.TransactionHash,
+		Status:              receipt.Status,
+		HumanReadableStatus: receipt.HumanReadableStatus,
+		ContractAddress:     receipt.ContractAddress,
+		ContractResult:      contractResult,
+		HertzUsed:           receipt.HertzUsed,
+		Fee:                 receipt.Fee,
+		Rewards:             convertToProtoRewards(receipt.Rewards),
+		Created:             created,
+		Updated:             updated,
+		Timeline:            convertToProtoReceiptEvents(receipt.Timeline),
+	}, nil
+}
+
+// convertToDomainReceiptEvents
+func convertToDomainReceiptEvents(events []*proto.ReceiptEvent) []types.ReceiptEvent {
+	if len(events) == 0 {
+		return nil
+	}
+	domainEvents := make([]types.ReceiptEvent, 0, len(events))
+	for _, event := range events {
+		domainEvents = append(domainEvents, types.ReceiptEvent{
+			Event:    event.Event,
+			Time:     time.Unix(0, event.Time).UTC(),
+			Delegate: event.Delegate,
+		})
+	}
+	return domainEvents
+}
+
+// convertToProtoReceiptEvents
+func convertToProtoReceiptEvents(events []types.ReceiptEvent) []*proto.ReceiptEvent {
+	protoEvents := make([]*proto.ReceiptEvent, 0, len(events))
+	for _, event := range events {
+		protoEvents = append(protoEvents, &proto.ReceiptEvent{
+			Event:    event.Event,
+			Time:     event.Time.UnixNano(),
+			Delegate: event.Delegate,
+		})
+	}
+	return protoEvents
+}
+
+// convertToDomainRewards
+func convertToDomainRewards(rewards []*proto.Reward) []types.Reward {
+	if len(rewards) == 0 {
+		return nil
+	}
+	domainRewards := make([]types.Reward, 0, len(rewards))
+	for _, reward := range rewards {
+		domainRewards = append(domainRewards, types.Reward{Delegate: reward.Delegate, Value: reward.Value})
+	}
+	return domainRewards
+}
+
+// convertToProtoRewards
+func convertToProtoRewards(rewards []types.Reward) []*proto.Reward {
+	protoRewards := make([]*proto.Reward, 0, len(rewards))
+	for _, reward := range rewards {
+		protoRewards = append(protoRewards, &proto.Reward{Delegate: reward.Delegate, Value: reward.Value})
+	}
+	return protoRewards
+}
+
+// convertToDomainRumors
+func convertToDomainRumors(rumors []*proto.Rumor) []types.Rumor {
+	domainRumors := make([]types.Rumor, 0, len(rumors))
+	for _, rumor := range rumors {
+		domainRumors = append(domainRumors, types.Rumor{
+			Hash:            rumor.Hash,
+			Address:         rumor.Address,
+			TransactionHash: rumor.TransactionHash,
+			Time:            rumor.Time,
+			Signature:       rumor.Signature,
+			NetworkId:       rumor.NetworkId,
+		})
+	}
+	return domainRumors
+}
+
+// convertToProtoRumors
+func convertToProtoRumors(rumors []types.Rumor) []*proto.Rumor {
+	protoRumors := make([]*proto.Rumor, 0, len(rumors))
+	for _, rumor := range rumors {
+		protoRumors = append(protoRumors, &proto.Rumor{
+			Hash:            rumor.Hash,
+			Address:         rumor.Address,
+			TransactionHash: rumor.TransactionHash,
+			Time:            rumor.Time,
+			Signature:       rumor.Signature,
+			NetworkId:       rumor.NetworkId,
+		})
+	}
+	return protoRumors
 }
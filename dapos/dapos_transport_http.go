@@ -35,6 +35,7 @@ func (this *DAPoSService) WithHttp() *DAPoSService {
 	//Accounts
 	services.GetHttpRouter().HandleFunc("/v1/accounts/{address}", this.getAccountHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/accounts", this.unsupportedFunctionHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/names/{name}", this.getAccountByNameHandler).Methods("GET")
 	//Transactions
 	services.GetHttpRouter().HandleFunc("/v1/transactions", this.newTransactionHandler).Methods("POST")
 	services.GetHttpRouter().HandleFunc("/v1/transactions/{hash}", this.getTransactionHandler).Methods("GET")
@@ -47,16 +48,24 @@ func (this *DAPoSService) WithHttp() *DAPoSService {
 	services.GetHttpRouter().HandleFunc("/v1/delegates", this.getDelegatesHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/delegates/subscribe", this.unsupportedFunctionHandler).Methods("POST")
 	services.GetHttpRouter().HandleFunc("/v1/delegates/unsubscribe", this.unsupportedFunctionHandler).Methods("POST")
+	services.GetHttpRouter().HandleFunc("/v1/stakes", this.getDelegateStakesHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/stakes/{delegate}", this.getDelegateStakeHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/parameters", this.getParametersHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/proposals", this.getProposalsHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/proposals/{hash}", this.getProposalHandler).Methods("GET")
 
 	//Page
 	services.GetHttpRouter().HandleFunc("/v1/page", this.unsupportedFunctionHandler).Methods("GET") //TODO:only return hashes
 	services.GetHttpRouter().HandleFunc("/v1/page/{id}", this.unsupportedFunctionHandler).Methods("GET")
 	//analytical
 	services.GetHttpRouter().HandleFunc("/v1/queue", this.getQueueHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/timeouts", this.getTimeoutsHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/reputations", this.getReputationsHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/gossips", this.getGossipsHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/gossips/{hash}", this.getGossipHandler).Methods("GET")
 
-	services.GetHttpRouter().HandleFunc("/v1/receipts/{hash}", this.unsupportedFunctionHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/receipts/{hash}", this.getReceiptHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/receipts", this.getReceiptsHandler).Methods("GET")
 
 	return this
 }
@@ -92,6 +101,8 @@ func setHeaders(response *types.Response, responseWriter *http.ResponseWriter) {
 			(*responseWriter).WriteHeader(http.StatusInternalServerError)
 		} else if response.Status == types.StatusNotDelegate {
 			(*responseWriter).WriteHeader(http.StatusTeapot)
+		} else if response.Status == types.StatusMempoolFull || response.Status == types.StatusAccountLimitReached {
+			(*responseWriter).WriteHeader(http.StatusTooManyRequests)
 		} else {
 			(*responseWriter).WriteHeader(http.StatusBadRequest)
 		}
@@ -112,6 +123,51 @@ func (this *DAPoSService) getAccountHandler(responseWriter http.ResponseWriter,
 	responseWriter.Write([]byte(response.String()))
 }
 
+// getDelegateStakesHandler
+func (this *DAPoSService) getDelegateStakesHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetDelegateStakes()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getDelegateStakeHandler
+func (this *DAPoSService) getDelegateStakeHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetDelegateStake(vars["delegate"])
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getParametersHandler
+func (this *DAPoSService) getParametersHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetParameters()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getProposalsHandler
+func (this *DAPoSService) getProposalsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetProposals()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getProposalHandler
+func (this *DAPoSService) getProposalHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetProposal(vars["hash"])
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getAccountByNameHandler
+func (this *DAPoSService) getAccountByNameHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetAccountByName(vars["name"])
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
 // getTransactionHandler
 func (this *DAPoSService) getTransactionHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	vars := mux.Vars(request)
@@ -139,7 +195,7 @@ func (this *DAPoSService) newTransactionHandler(responseWriter http.ResponseWrit
 		}
 	}
 
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 
 	if transaction.Type == types.TypeDeploySmartContract {
@@ -208,6 +264,20 @@ func (this *DAPoSService) getQueueHandler(responseWriter http.ResponseWriter, re
 	responseWriter.Write([]byte(response.String()))
 }
 
+// getTimeoutsHandler
+func (this *DAPoSService) getTimeoutsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetTimeouts()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getReputationsHandler
+func (this *DAPoSService) getReputationsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetReputations()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
 // getArtifactHandler
 func (this *DAPoSService) unsupportedFunctionHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	response := this.ToBeSupported()
@@ -251,10 +321,33 @@ func (this *DAPoSService) getGossipHandler(responseWriter http.ResponseWriter, r
 }
 
 // getReceiptHandler
-// func (this *DAPoSService) getReceiptHandler(responseWriter http.ResponseWriter, request *http.Request) {
-// 	vars := mux.Vars(request)
-// 	response := this.GetReceipt(vars["hash"])
-// 	setHeaders(response, &responseWriter)
-// 	responseWriter.Write([]byte(response.String()))
-// }
+func (this *DAPoSService) getReceiptHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetReceipt(vars["hash"])
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getReceiptsHandler
+func (this *DAPoSService) getReceiptsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := types.NewResponse()
+	status := request.URL.Query().Get("status")
+	if status == "" {
+		response.Status = http.StatusText(http.StatusBadRequest)
+		response.HumanReadableStatus = "\"status\" parameter must be provided"
+		services.Error(responseWriter, response.String(), http.StatusBadRequest)
+		return
+	}
+	pageNumber := request.URL.Query().Get("page")
+	if pageNumber == "" {
+		pageNumber = "1"
+	}
+	pageLimit := request.URL.Query().Get("pageSize")
+	if pageLimit == "" {
+		pageLimit = "10"
+	}
+	response = this.GetReceipts(status, pageNumber, pageLimit)
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
 
@@ -0,0 +1,114 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"github.com/dispatchlabs/disgo/commons/transport"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	protobuf "github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const memoryService = "dapos"
+
+// NewMemoryTransport - In process transport over a shared network, address is this node's address
+func NewMemoryTransport(network *transport.Network, address string) DAPoSTransport {
+	return &memoryTransport{network: network, address: address}
+}
+
+// memoryTransport
+type memoryTransport struct {
+	network *transport.Network
+	address string
+}
+
+// Serve
+func (this *memoryTransport) Serve(server proto.DAPoSGrpcServer) {
+	this.network.Register(memoryService, this.address, server)
+}
+
+// NewClient
+func (this *memoryTransport) NewClient(node *types.Node) (proto.DAPoSGrpcClient, error) {
+	return &memoryClient{network: this.network, from: this.address, to: node.Address}, nil
+}
+
+// memoryClient - Messages are copied on the way in and out so peers never share memory
+type memoryClient struct {
+	network *transport.Network
+	from    string
+	to      string
+}
+
+// call
+func (this *memoryClient) call(ctx context.Context, in protobuf.Message, invoke func(server proto.DAPoSGrpcServer, in protobuf.Message) (protobuf.Message, error)) (protobuf.Message, error) {
+	var out protobuf.Message
+	err := this.network.Call(ctx, memoryService, this.from, this.to, func(server interface{}) error {
+		var err error
+		out, err = invoke(server.(proto.DAPoSGrpcServer), protobuf.Clone(in))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return protobuf.Clone(out), nil
+}
+
+// SynchronizeGrpc
+func (this *memoryClient) SynchronizeGrpc(ctx context.Context, in *proto.SynchronizeRequest, opts ...grpc.CallOption) (*proto.SynchronizeResponse, error) {
+	out, err := this.call(ctx, in, func(server proto.DAPoSGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.SynchronizeGrpc(ctx, in.(*proto.SynchronizeRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.SynchronizeResponse), nil
+}
+
+// GossipBatchGrpc
+func (this *memoryClient) GossipBatchGrpc(ctx context.Context, in *proto.GossipBatch, opts ...grpc.CallOption) (*proto.GossipBatch, error) {
+	out, err := this.call(ctx, in, func(server proto.DAPoSGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.GossipBatchGrpc(ctx, in.(*proto.GossipBatch))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.GossipBatch), nil
+}
+
+// GossipGrpc
+func (this *memoryClient) GossipGrpc(ctx context.Context, in *proto.Request, opts ...grpc.CallOption) (*proto.Response, error) {
+	out, err := this.call(ctx, in, func(server proto.DAPoSGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.GossipGrpc(ctx, in.(*proto.Request))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Response), nil
+}
+
+// TypedGossipGrpc
+func (this *memoryClient) TypedGossipGrpc(ctx context.Context, in *proto.Gossip, opts ...grpc.CallOption) (*proto.Gossip, error) {
+	out, err := this.call(ctx, in, func(server proto.DAPoSGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.TypedGossipGrpc(ctx, in.(*proto.Gossip))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Gossip), nil
+}
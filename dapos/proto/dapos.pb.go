@@ -33,7 +33,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dapos_95fabd77f80e7399, []int{0}
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -65,7 +65,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dapos_95fabd77f80e7399, []int{1}
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{1}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Request.Unmarshal(m, b)
@@ -110,7 +110,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dapos_95fabd77f80e7399, []int{2}
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{2}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -149,7 +149,7 @@ func (m *Item) Reset()         { *m = Item{} }
 func (m *Item) String() string { return proto.CompactTextString(m) }
 func (*Item) ProtoMessage()    {}
 func (*Item) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dapos_95fabd77f80e7399, []int{3}
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{3}
 }
 func (m *Item) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Item.Unmarshal(m, b)
@@ -194,7 +194,7 @@ func (m *SynchronizeRequest) Reset()         { *m = SynchronizeRequest{} }
 func (m *SynchronizeRequest) String() string { return proto.CompactTextString(m) }
 func (*SynchronizeRequest) ProtoMessage()    {}
 func (*SynchronizeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dapos_95fabd77f80e7399, []int{4}
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{4}
 }
 func (m *SynchronizeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SynchronizeRequest.Unmarshal(m, b)
@@ -232,7 +232,7 @@ func (m *SynchronizeResponse) Reset()         { *m = SynchronizeResponse{} }
 func (m *SynchronizeResponse) String() string { return proto.CompactTextString(m) }
 func (*SynchronizeResponse) ProtoMessage()    {}
 func (*SynchronizeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_dapos_95fabd77f80e7399, []int{5}
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{5}
 }
 func (m *SynchronizeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SynchronizeResponse.Unmarshal(m, b)
@@ -259,6 +259,686 @@ func (m *SynchronizeResponse) GetItems() []*Item {
 	return nil
 }
 
+type Receipt struct {
+	TransactionHash      string          `protobuf:"bytes,1,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
+	Status               string          `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	HumanReadableStatus  string          `protobuf:"bytes,3,opt,name=HumanReadableStatus,proto3" json:"HumanReadableStatus,omitempty"`
+	ContractAddress      string          `protobuf:"bytes,4,opt,name=ContractAddress,proto3" json:"ContractAddress,omitempty"`
+	ContractResult       []byte          `protobuf:"bytes,5,opt,name=ContractResult,proto3" json:"ContractResult,omitempty"`
+	Created              int64           `protobuf:"varint,6,opt,name=Created,proto3" json:"Created,omitempty"`
+	HertzUsed            int64           `protobuf:"varint,7,opt,name=HertzUsed,proto3" json:"HertzUsed,omitempty"`
+	Updated              int64           `protobuf:"varint,8,opt,name=Updated,proto3" json:"Updated,omitempty"`
+	Timeline             []*ReceiptEvent `protobuf:"bytes,9,rep,name=Timeline,proto3" json:"Timeline,omitempty"`
+	Fee                  int64           `protobuf:"varint,10,opt,name=Fee,proto3" json:"Fee,omitempty"`
+	Rewards              []*Reward       `protobuf:"bytes,11,rep,name=Rewards,proto3" json:"Rewards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Receipt) Reset()         { *m = Receipt{} }
+func (m *Receipt) String() string { return proto.CompactTextString(m) }
+func (*Receipt) ProtoMessage()    {}
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{6}
+}
+func (m *Receipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Receipt.Unmarshal(m, b)
+}
+func (m *Receipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Receipt.Marshal(b, m, deterministic)
+}
+func (dst *Receipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Receipt.Merge(dst, src)
+}
+func (m *Receipt) XXX_Size() int {
+	return xxx_messageInfo_Receipt.Size(m)
+}
+func (m *Receipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_Receipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Receipt proto.InternalMessageInfo
+
+func (m *Receipt) GetTransactionHash() string {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return ""
+}
+
+func (m *Receipt) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Receipt) GetHumanReadableStatus() string {
+	if m != nil {
+		return m.HumanReadableStatus
+	}
+	return ""
+}
+
+func (m *Receipt) GetContractAddress() string {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return ""
+}
+
+func (m *Receipt) GetContractResult() []byte {
+	if m != nil {
+		return m.ContractResult
+	}
+	return nil
+}
+
+func (m *Receipt) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *Receipt) GetHertzUsed() int64 {
+	if m != nil {
+		return m.HertzUsed
+	}
+	return 0
+}
+
+func (m *Receipt) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *Receipt) GetTimeline() []*ReceiptEvent {
+	if m != nil {
+		return m.Timeline
+	}
+	return nil
+}
+
+func (m *Receipt) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *Receipt) GetRewards() []*Reward {
+	if m != nil {
+		return m.Rewards
+	}
+	return nil
+}
+
+type Reward struct {
+	Delegate             string   `protobuf:"bytes,1,opt,name=Delegate,proto3" json:"Delegate,omitempty"`
+	Value                int64    `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Reward) Reset()         { *m = Reward{} }
+func (m *Reward) String() string { return proto.CompactTextString(m) }
+func (*Reward) ProtoMessage()    {}
+func (*Reward) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{7}
+}
+func (m *Reward) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Reward.Unmarshal(m, b)
+}
+func (m *Reward) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Reward.Marshal(b, m, deterministic)
+}
+func (dst *Reward) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Reward.Merge(dst, src)
+}
+func (m *Reward) XXX_Size() int {
+	return xxx_messageInfo_Reward.Size(m)
+}
+func (m *Reward) XXX_DiscardUnknown() {
+	xxx_messageInfo_Reward.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Reward proto.InternalMessageInfo
+
+func (m *Reward) GetDelegate() string {
+	if m != nil {
+		return m.Delegate
+	}
+	return ""
+}
+
+func (m *Reward) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type ReceiptEvent struct {
+	Event                string   `protobuf:"bytes,1,opt,name=Event,proto3" json:"Event,omitempty"`
+	Time                 int64    `protobuf:"varint,2,opt,name=Time,proto3" json:"Time,omitempty"`
+	Delegate             string   `protobuf:"bytes,3,opt,name=Delegate,proto3" json:"Delegate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiptEvent) Reset()         { *m = ReceiptEvent{} }
+func (m *ReceiptEvent) String() string { return proto.CompactTextString(m) }
+func (*ReceiptEvent) ProtoMessage()    {}
+func (*ReceiptEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{8}
+}
+func (m *ReceiptEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptEvent.Unmarshal(m, b)
+}
+func (m *ReceiptEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptEvent.Marshal(b, m, deterministic)
+}
+func (dst *ReceiptEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptEvent.Merge(dst, src)
+}
+func (m *ReceiptEvent) XXX_Size() int {
+	return xxx_messageInfo_ReceiptEvent.Size(m)
+}
+func (m *ReceiptEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptEvent proto.InternalMessageInfo
+
+func (m *ReceiptEvent) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *ReceiptEvent) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReceiptEvent) GetDelegate() string {
+	if m != nil {
+		return m.Delegate
+	}
+	return ""
+}
+
+type Rumor struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
+	TransactionHash      string   `protobuf:"bytes,3,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
+	Time                 int64    `protobuf:"varint,4,opt,name=Time,proto3" json:"Time,omitempty"`
+	Signature            string   `protobuf:"bytes,5,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	NetworkId            string   `protobuf:"bytes,6,opt,name=NetworkId,proto3" json:"NetworkId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Rumor) Reset()         { *m = Rumor{} }
+func (m *Rumor) String() string { return proto.CompactTextString(m) }
+func (*Rumor) ProtoMessage()    {}
+func (*Rumor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{9}
+}
+func (m *Rumor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rumor.Unmarshal(m, b)
+}
+func (m *Rumor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rumor.Marshal(b, m, deterministic)
+}
+func (dst *Rumor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rumor.Merge(dst, src)
+}
+func (m *Rumor) XXX_Size() int {
+	return xxx_messageInfo_Rumor.Size(m)
+}
+func (m *Rumor) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rumor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rumor proto.InternalMessageInfo
+
+func (m *Rumor) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Rumor) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Rumor) GetTransactionHash() string {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return ""
+}
+
+func (m *Rumor) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Rumor) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+func (m *Rumor) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+type Transaction struct {
+	Hash                 string    `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Type                 uint32    `protobuf:"varint,2,opt,name=Type,proto3" json:"Type,omitempty"`
+	From                 string    `protobuf:"bytes,3,opt,name=From,proto3" json:"From,omitempty"`
+	To                   string    `protobuf:"bytes,4,opt,name=To,proto3" json:"To,omitempty"`
+	Value                int64     `protobuf:"varint,5,opt,name=Value,proto3" json:"Value,omitempty"`
+	Code                 string    `protobuf:"bytes,6,opt,name=Code,proto3" json:"Code,omitempty"`
+	Abi                  string    `protobuf:"bytes,7,opt,name=Abi,proto3" json:"Abi,omitempty"`
+	Method               string    `protobuf:"bytes,8,opt,name=Method,proto3" json:"Method,omitempty"`
+	Params               []byte    `protobuf:"bytes,9,opt,name=Params,proto3" json:"Params,omitempty"`
+	Time                 int64     `protobuf:"varint,10,opt,name=Time,proto3" json:"Time,omitempty"`
+	Signature            string    `protobuf:"bytes,11,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	Hertz                int64     `protobuf:"varint,12,opt,name=Hertz,proto3" json:"Hertz,omitempty"`
+	Receipt              *Receipt  `protobuf:"bytes,13,opt,name=Receipt,proto3" json:"Receipt,omitempty"`
+	Gossip               []*Rumor  `protobuf:"bytes,14,rep,name=Gossip,proto3" json:"Gossip,omitempty"`
+	FromName             string    `protobuf:"bytes,15,opt,name=FromName,proto3" json:"FromName,omitempty"`
+	ToName               string    `protobuf:"bytes,16,opt,name=ToName,proto3" json:"ToName,omitempty"`
+	Outputs              []*Output `protobuf:"bytes,17,rep,name=Outputs,proto3" json:"Outputs,omitempty"`
+	Signers              []string  `protobuf:"bytes,18,rep,name=Signers,proto3" json:"Signers,omitempty"`
+	Threshold            int64     `protobuf:"varint,19,opt,name=Threshold,proto3" json:"Threshold,omitempty"`
+	Signatures           []string  `protobuf:"bytes,20,rep,name=Signatures,proto3" json:"Signatures,omitempty"`
+	UnlockTime           int64     `protobuf:"varint,21,opt,name=UnlockTime,proto3" json:"UnlockTime,omitempty"`
+	Name                 string    `protobuf:"bytes,22,opt,name=Name,proto3" json:"Name,omitempty"`
+	Fee                  int64     `protobuf:"varint,23,opt,name=Fee,proto3" json:"Fee,omitempty"`
+	Parameter            string    `protobuf:"bytes,24,opt,name=Parameter,proto3" json:"Parameter,omitempty"`
+	ActivationTime       int64     `protobuf:"varint,25,opt,name=ActivationTime,proto3" json:"ActivationTime,omitempty"`
+	Proposal             string    `protobuf:"bytes,26,opt,name=Proposal,proto3" json:"Proposal,omitempty"`
+	Approve              bool      `protobuf:"varint,27,opt,name=Approve,proto3" json:"Approve,omitempty"`
+	NetworkId            string    `protobuf:"bytes,28,opt,name=NetworkId,proto3" json:"NetworkId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{10}
+}
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transaction.Unmarshal(m, b)
+}
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+}
+func (dst *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(dst, src)
+}
+func (m *Transaction) XXX_Size() int {
+	return xxx_messageInfo_Transaction.Size(m)
+}
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
+
+func (m *Transaction) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Transaction) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *Transaction) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *Transaction) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *Transaction) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Transaction) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *Transaction) GetAbi() string {
+	if m != nil {
+		return m.Abi
+	}
+	return ""
+}
+
+func (m *Transaction) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *Transaction) GetParams() []byte {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *Transaction) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Transaction) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+func (m *Transaction) GetHertz() int64 {
+	if m != nil {
+		return m.Hertz
+	}
+	return 0
+}
+
+func (m *Transaction) GetReceipt() *Receipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *Transaction) GetGossip() []*Rumor {
+	if m != nil {
+		return m.Gossip
+	}
+	return nil
+}
+
+func (m *Transaction) GetFromName() string {
+	if m != nil {
+		return m.FromName
+	}
+	return ""
+}
+
+func (m *Transaction) GetToName() string {
+	if m != nil {
+		return m.ToName
+	}
+	return ""
+}
+
+func (m *Transaction) GetOutputs() []*Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *Transaction) GetSigners() []string {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
+func (m *Transaction) GetThreshold() int64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *Transaction) GetSignatures() []string {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+func (m *Transaction) GetUnlockTime() int64 {
+	if m != nil {
+		return m.UnlockTime
+	}
+	return 0
+}
+
+func (m *Transaction) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Transaction) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *Transaction) GetParameter() string {
+	if m != nil {
+		return m.Parameter
+	}
+	return ""
+}
+
+func (m *Transaction) GetActivationTime() int64 {
+	if m != nil {
+		return m.ActivationTime
+	}
+	return 0
+}
+
+func (m *Transaction) GetProposal() string {
+	if m != nil {
+		return m.Proposal
+	}
+	return ""
+}
+
+func (m *Transaction) GetApprove() bool {
+	if m != nil {
+		return m.Approve
+	}
+	return false
+}
+
+func (m *Transaction) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+type Output struct {
+	To                   string   `protobuf:"bytes,1,opt,name=To,proto3" json:"To,omitempty"`
+	Value                int64    `protobuf:"varint,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Output) Reset()         { *m = Output{} }
+func (m *Output) String() string { return proto.CompactTextString(m) }
+func (*Output) ProtoMessage()    {}
+func (*Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{11}
+}
+func (m *Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Output.Unmarshal(m, b)
+}
+func (m *Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Output.Marshal(b, m, deterministic)
+}
+func (dst *Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Output.Merge(dst, src)
+}
+func (m *Output) XXX_Size() int {
+	return xxx_messageInfo_Output.Size(m)
+}
+func (m *Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Output proto.InternalMessageInfo
+
+func (m *Output) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *Output) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type Gossip struct {
+	Transaction          *Transaction `protobuf:"bytes,1,opt,name=Transaction,proto3" json:"Transaction,omitempty"`
+	Rumors               []*Rumor     `protobuf:"bytes,2,rep,name=Rumors,proto3" json:"Rumors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Gossip) Reset()         { *m = Gossip{} }
+func (m *Gossip) String() string { return proto.CompactTextString(m) }
+func (*Gossip) ProtoMessage()    {}
+func (*Gossip) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{12}
+}
+func (m *Gossip) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Gossip.Unmarshal(m, b)
+}
+func (m *Gossip) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Gossip.Marshal(b, m, deterministic)
+}
+func (dst *Gossip) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Gossip.Merge(dst, src)
+}
+func (m *Gossip) XXX_Size() int {
+	return xxx_messageInfo_Gossip.Size(m)
+}
+func (m *Gossip) XXX_DiscardUnknown() {
+	xxx_messageInfo_Gossip.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Gossip proto.InternalMessageInfo
+
+func (m *Gossip) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *Gossip) GetRumors() []*Rumor {
+	if m != nil {
+		return m.Rumors
+	}
+	return nil
+}
+
+type GossipBatch struct {
+	Gossips              []*Gossip `protobuf:"bytes,1,rep,name=Gossips,proto3" json:"Gossips,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GossipBatch) Reset()         { *m = GossipBatch{} }
+func (m *GossipBatch) String() string { return proto.CompactTextString(m) }
+func (*GossipBatch) ProtoMessage()    {}
+func (*GossipBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dapos_58a1fe9d1dc92c09, []int{13}
+}
+func (m *GossipBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GossipBatch.Unmarshal(m, b)
+}
+func (m *GossipBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GossipBatch.Marshal(b, m, deterministic)
+}
+func (dst *GossipBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipBatch.Merge(dst, src)
+}
+func (m *GossipBatch) XXX_Size() int {
+	return xxx_messageInfo_GossipBatch.Size(m)
+}
+func (m *GossipBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipBatch proto.InternalMessageInfo
+
+func (m *GossipBatch) GetGossips() []*Gossip {
+	if m != nil {
+		return m.Gossips
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "proto.Empty")
 	proto.RegisterType((*Request)(nil), "proto.Request")
@@ -266,6 +946,14 @@ func init() {
 	proto.RegisterType((*Item)(nil), "proto.Item")
 	proto.RegisterType((*SynchronizeRequest)(nil), "proto.SynchronizeRequest")
 	proto.RegisterType((*SynchronizeResponse)(nil), "proto.SynchronizeResponse")
+	proto.RegisterType((*Receipt)(nil), "proto.Receipt")
+	proto.RegisterType((*Reward)(nil), "proto.Reward")
+	proto.RegisterType((*ReceiptEvent)(nil), "proto.ReceiptEvent")
+	proto.RegisterType((*Rumor)(nil), "proto.Rumor")
+	proto.RegisterType((*Transaction)(nil), "proto.Transaction")
+	proto.RegisterType((*Output)(nil), "proto.Output")
+	proto.RegisterType((*Gossip)(nil), "proto.Gossip")
+	proto.RegisterType((*GossipBatch)(nil), "proto.GossipBatch")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -282,6 +970,8 @@ const _ = grpc.SupportPackageIsVersion4
 type DAPoSGrpcClient interface {
 	SynchronizeGrpc(ctx context.Context, in *SynchronizeRequest, opts ...grpc.CallOption) (*SynchronizeResponse, error)
 	GossipGrpc(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+	TypedGossipGrpc(ctx context.Context, in *Gossip, opts ...grpc.CallOption) (*Gossip, error)
+	GossipBatchGrpc(ctx context.Context, in *GossipBatch, opts ...grpc.CallOption) (*GossipBatch, error)
 }
 
 type dAPoSGrpcClient struct {
@@ -310,10 +1000,30 @@ func (c *dAPoSGrpcClient) GossipGrpc(ctx context.Context, in *Request, opts ...g
 	return out, nil
 }
 
+func (c *dAPoSGrpcClient) TypedGossipGrpc(ctx context.Context, in *Gossip, opts ...grpc.CallOption) (*Gossip, error) {
+	out := new(Gossip)
+	err := c.cc.Invoke(ctx, "/proto.DAPoSGrpc/TypedGossipGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAPoSGrpcClient) GossipBatchGrpc(ctx context.Context, in *GossipBatch, opts ...grpc.CallOption) (*GossipBatch, error) {
+	out := new(GossipBatch)
+	err := c.cc.Invoke(ctx, "/proto.DAPoSGrpc/GossipBatchGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DAPoSGrpcServer is the server API for DAPoSGrpc service.
 type DAPoSGrpcServer interface {
 	SynchronizeGrpc(context.Context, *SynchronizeRequest) (*SynchronizeResponse, error)
 	GossipGrpc(context.Context, *Request) (*Response, error)
+	TypedGossipGrpc(context.Context, *Gossip) (*Gossip, error)
+	GossipBatchGrpc(context.Context, *GossipBatch) (*GossipBatch, error)
 }
 
 func RegisterDAPoSGrpcServer(s *grpc.Server, srv DAPoSGrpcServer) {
@@ -356,6 +1066,42 @@ func _DAPoSGrpc_GossipGrpc_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DAPoSGrpc_TypedGossipGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Gossip)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAPoSGrpcServer).TypedGossipGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.DAPoSGrpc/TypedGossipGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAPoSGrpcServer).TypedGossipGrpc(ctx, req.(*Gossip))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DAPoSGrpc_GossipBatchGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GossipBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAPoSGrpcServer).GossipBatchGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.DAPoSGrpc/GossipBatchGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAPoSGrpcServer).GossipBatchGrpc(ctx, req.(*GossipBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DAPoSGrpc_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.DAPoSGrpc",
 	HandlerType: (*DAPoSGrpcServer)(nil),
@@ -368,30 +1114,80 @@ var _DAPoSGrpc_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GossipGrpc",
 			Handler:    _DAPoSGrpc_GossipGrpc_Handler,
 		},
+		{
+			MethodName: "TypedGossipGrpc",
+			Handler:    _DAPoSGrpc_TypedGossipGrpc_Handler,
+		},
+		{
+			MethodName: "GossipBatchGrpc",
+			Handler:    _DAPoSGrpc_GossipBatchGrpc_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dapos.proto",
 }
 
-func init() { proto.RegisterFile("dapos.proto", fileDescriptor_dapos_95fabd77f80e7399) }
-
-var fileDescriptor_dapos_95fabd77f80e7399 = []byte{
-	// 261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x4f, 0x41, 0x4e, 0xc3, 0x30,
-	0x10, 0xc4, 0xa4, 0x26, 0x74, 0x83, 0x28, 0x5a, 0x7a, 0x08, 0x3d, 0x15, 0x8b, 0x43, 0xc5, 0x21,
-	0x48, 0xe5, 0x00, 0x57, 0x24, 0x50, 0x55, 0x4e, 0xc8, 0x7d, 0x41, 0x68, 0x56, 0xa2, 0xa2, 0x8d,
-	0x4d, 0xec, 0x20, 0xcc, 0x0b, 0x78, 0x36, 0x8a, 0x9d, 0x20, 0x22, 0x38, 0x79, 0x67, 0x76, 0x66,
-	0x76, 0x0c, 0x49, 0x91, 0x6b, 0x65, 0x32, 0x5d, 0x29, 0xab, 0x90, 0xfb, 0x47, 0xc4, 0xc0, 0x1f,
-	0x76, 0xda, 0x3a, 0x71, 0x03, 0xb1, 0xa4, 0xb7, 0x9a, 0x8c, 0x45, 0x84, 0x81, 0x75, 0x9a, 0x52,
-	0x36, 0x65, 0xb3, 0xa1, 0xf4, 0x33, 0xa6, 0x10, 0xeb, 0xdc, 0x6d, 0x55, 0x5e, 0xa4, 0xfb, 0x9e,
-	0xee, 0xa0, 0xb8, 0x80, 0x43, 0x49, 0x46, 0xab, 0xd2, 0xf4, 0x54, 0xac, 0xaf, 0xca, 0x60, 0xb0,
-	0xb4, 0xb4, 0xc3, 0x13, 0x88, 0x5e, 0xc9, 0xb5, 0xdb, 0x66, 0xc4, 0x31, 0xf0, 0xf7, 0x7c, 0x5b,
-	0x93, 0xcf, 0x3d, 0x92, 0x01, 0x88, 0x4b, 0xc0, 0x95, 0x2b, 0xd7, 0x2f, 0x95, 0x2a, 0x37, 0x9f,
-	0xd4, 0x35, 0x1b, 0x03, 0x5f, 0x96, 0x05, 0x7d, 0x78, 0x7f, 0x24, 0x03, 0x10, 0xb7, 0x70, 0xda,
-	0xd3, 0xb6, 0x65, 0xce, 0x81, 0x37, 0x27, 0x4d, 0xca, 0xa6, 0xd1, 0x2c, 0x99, 0x27, 0xe1, 0xe3,
-	0x59, 0xc3, 0xc9, 0xb0, 0x99, 0x7f, 0x31, 0x18, 0xde, 0xdf, 0x3d, 0xa9, 0xd5, 0xa2, 0xd2, 0x6b,
-	0x7c, 0x84, 0xd1, 0xaf, 0x1c, 0x4f, 0x9d, 0xb5, 0xa6, 0xbf, 0x5d, 0x26, 0x93, 0xff, 0x56, 0xe1,
-	0xb4, 0xd8, 0xc3, 0x2b, 0x80, 0x85, 0x32, 0x66, 0xa3, 0x7d, 0xcc, 0x71, 0xab, 0xed, 0xbc, 0xa3,
-	0x1f, 0xdc, 0x19, 0x9e, 0x0f, 0x3c, 0x73, 0xfd, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x00, 0xbb, 0xfd,
-	0xcc, 0xa5, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("dapos.proto", fileDescriptor_dapos_58a1fe9d1dc92c09) }
+
+var fileDescriptor_dapos_58a1fe9d1dc92c09 = []byte{
+	// 941 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0x4d, 0x73, 0x23, 0x35,
+	0x10, 0x8d, 0xe3, 0x8c, 0xe3, 0x69, 0x27, 0xf1, 0xa2, 0x84, 0x45, 0x6b, 0x52, 0x94, 0x99, 0x4a,
+	0x81, 0x8b, 0x43, 0x16, 0x02, 0x05, 0x14, 0x9c, 0x42, 0xf6, 0x23, 0xa1, 0x8a, 0x25, 0xa5, 0x38,
+	0xdc, 0x15, 0x8f, 0x6a, 0xed, 0x8a, 0x3d, 0x1a, 0x24, 0x4d, 0x16, 0xef, 0x7f, 0xe0, 0xb7, 0xf0,
+	0xdf, 0x38, 0x71, 0xa4, 0xba, 0xa5, 0x19, 0xcf, 0x38, 0xde, 0x93, 0xfb, 0xbd, 0x56, 0x4b, 0xad,
+	0xa7, 0xd7, 0x1e, 0xe8, 0xa5, 0x32, 0xd7, 0xf6, 0x34, 0x37, 0xda, 0x69, 0x16, 0xd1, 0x4f, 0xb2,
+	0x0b, 0xd1, 0xcb, 0x45, 0xee, 0x96, 0xc9, 0x0f, 0xb0, 0x2b, 0xd4, 0x9f, 0x85, 0xb2, 0x8e, 0x31,
+	0xd8, 0x71, 0xcb, 0x5c, 0xf1, 0xd6, 0xb0, 0x35, 0x8a, 0x05, 0xc5, 0x8c, 0xc3, 0x6e, 0x2e, 0x97,
+	0x73, 0x2d, 0x53, 0xbe, 0x4d, 0x74, 0x09, 0x93, 0x13, 0xe8, 0x0a, 0x65, 0x73, 0x9d, 0xd9, 0xc6,
+	0xaa, 0x56, 0x73, 0xd5, 0x29, 0xec, 0x5c, 0x39, 0xb5, 0x60, 0x4f, 0xa0, 0x7d, 0xaf, 0x96, 0x21,
+	0x8b, 0x21, 0x3b, 0x82, 0xe8, 0x41, 0xce, 0x0b, 0x45, 0xfb, 0xee, 0x09, 0x0f, 0x92, 0xaf, 0x80,
+	0xdd, 0x2c, 0xb3, 0xc9, 0xd4, 0xe8, 0x6c, 0xf6, 0x5e, 0x95, 0x9d, 0x1d, 0x41, 0x74, 0x95, 0xa5,
+	0xea, 0x2f, 0xaa, 0x6f, 0x0b, 0x0f, 0x92, 0x1f, 0xe1, 0xb0, 0xb1, 0x36, 0x34, 0xf3, 0x39, 0x44,
+	0x78, 0xa4, 0xe5, 0xad, 0x61, 0x7b, 0xd4, 0x3b, 0xeb, 0xf9, 0x8b, 0x9f, 0x22, 0x27, 0x7c, 0x26,
+	0xf9, 0xbb, 0x8d, 0xb7, 0x9e, 0xa8, 0x59, 0xee, 0xd8, 0x08, 0xfa, 0x63, 0x23, 0x33, 0x2b, 0x27,
+	0x6e, 0xa6, 0xb3, 0x4b, 0x69, 0xa7, 0xa1, 0xcb, 0x75, 0x9a, 0x3d, 0x85, 0xce, 0x8d, 0x93, 0xae,
+	0xb0, 0x41, 0x8a, 0x80, 0xd8, 0xd7, 0x70, 0x78, 0x59, 0x2c, 0x64, 0x26, 0x94, 0x4c, 0xe5, 0xdd,
+	0x5c, 0x85, 0x45, 0x6d, 0x5a, 0xb4, 0x29, 0x85, 0x67, 0x5e, 0xe8, 0xcc, 0x19, 0x39, 0x71, 0xe7,
+	0x69, 0x6a, 0x94, 0xb5, 0x7c, 0xc7, 0x9f, 0xb9, 0x46, 0xb3, 0x2f, 0xe0, 0xa0, 0xa4, 0x84, 0xb2,
+	0xc5, 0xdc, 0xf1, 0x88, 0xe4, 0x5a, 0x63, 0xf1, 0x05, 0x2e, 0x8c, 0x92, 0x4e, 0xa5, 0xbc, 0x43,
+	0x1a, 0x95, 0x90, 0x1d, 0x43, 0x7c, 0xa9, 0x8c, 0x7b, 0x7f, 0x6b, 0x55, 0xca, 0x77, 0x29, 0xb7,
+	0x22, 0xb0, 0xee, 0x36, 0x4f, 0xa9, 0xae, 0xeb, 0xeb, 0x02, 0x64, 0xcf, 0xa1, 0x3b, 0x9e, 0x2d,
+	0xd4, 0x7c, 0x96, 0x29, 0x1e, 0x93, 0x92, 0x87, 0x41, 0xc9, 0xa0, 0xdc, 0xcb, 0x07, 0x95, 0x39,
+	0x51, 0x2d, 0xc2, 0x27, 0x7e, 0xa5, 0x14, 0x07, 0xda, 0x06, 0x43, 0xf6, 0x25, 0xaa, 0xfc, 0x4e,
+	0x9a, 0xd4, 0xf2, 0x1e, 0xed, 0xb0, 0x5f, 0xed, 0x80, 0xac, 0x28, 0xb3, 0xc9, 0x4f, 0xd0, 0xf1,
+	0x21, 0x1b, 0x40, 0xf7, 0x85, 0x9a, 0xab, 0xb7, 0xd2, 0x95, 0x3e, 0xac, 0x30, 0xba, 0xe0, 0x8f,
+	0xca, 0x31, 0x6d, 0xe1, 0x41, 0x32, 0x86, 0xbd, 0x7a, 0x43, 0xb8, 0x8a, 0x82, 0x50, 0xee, 0x01,
+	0x7a, 0x1b, 0x1b, 0x0d, 0xa5, 0x14, 0x37, 0xce, 0x6a, 0x37, 0xcf, 0x4a, 0xfe, 0x69, 0x41, 0x24,
+	0x8a, 0x85, 0x36, 0x58, 0x59, 0x33, 0x05, 0xc5, 0xa8, 0x5a, 0xf9, 0x6e, 0x61, 0x2a, 0x02, 0xdc,
+	0xe4, 0xa6, 0xf6, 0x66, 0x37, 0x95, 0x1d, 0xed, 0xd4, 0x3a, 0x3a, 0x86, 0xf8, 0x66, 0xf6, 0x36,
+	0x93, 0xae, 0x30, 0x8a, 0x1e, 0x3a, 0x16, 0x2b, 0x02, 0xb3, 0x6f, 0x94, 0x7b, 0xa7, 0xcd, 0xfd,
+	0x95, 0x7f, 0xe5, 0x58, 0xac, 0x88, 0xe4, 0xdf, 0x08, 0x7a, 0xb5, 0x33, 0x36, 0xf6, 0x8d, 0x67,
+	0xe2, 0x84, 0x63, 0xd3, 0xfb, 0x82, 0x62, 0xe4, 0x5e, 0x19, 0xbd, 0x08, 0x6d, 0x52, 0xcc, 0x0e,
+	0x60, 0x7b, 0xac, 0x83, 0x25, 0xb7, 0xc7, 0x7a, 0xa5, 0x7c, 0x54, 0x53, 0x1e, 0x2b, 0x2f, 0x74,
+	0xaa, 0x42, 0x2b, 0x14, 0xa3, 0x09, 0xce, 0xef, 0x66, 0xe4, 0xb3, 0x58, 0x60, 0x88, 0x53, 0xf3,
+	0x9b, 0x72, 0x53, 0xed, 0x0d, 0x16, 0x8b, 0x80, 0x90, 0xbf, 0x96, 0x46, 0x2e, 0x2c, 0x8f, 0xc9,
+	0xd1, 0x01, 0x55, 0xba, 0xc0, 0x87, 0x74, 0xe9, 0xad, 0xeb, 0x72, 0x04, 0x11, 0x19, 0x9a, 0xef,
+	0xf9, 0xee, 0x08, 0xb0, 0x51, 0x35, 0xe2, 0x7c, 0x7f, 0xd8, 0x1a, 0xf5, 0xce, 0x0e, 0x9a, 0xf6,
+	0x15, 0x65, 0x9a, 0x9d, 0x40, 0xe7, 0xb5, 0xb6, 0x76, 0x96, 0xf3, 0x03, 0x72, 0xe9, 0x5e, 0xb9,
+	0x10, 0xdf, 0x5f, 0x84, 0x1c, 0xba, 0x05, 0xb5, 0x79, 0x23, 0x17, 0x8a, 0xf7, 0xbd, 0x5b, 0x4a,
+	0x8c, 0x77, 0x19, 0x6b, 0xca, 0x3c, 0xf1, 0x77, 0xf4, 0x08, 0x07, 0xe0, 0xf7, 0xc2, 0xe5, 0x85,
+	0xb3, 0xfc, 0xa3, 0xc6, 0x00, 0x78, 0x56, 0x94, 0x59, 0x34, 0x14, 0xde, 0x47, 0x19, 0xcb, 0xd9,
+	0xb0, 0x8d, 0x86, 0x0a, 0x10, 0xaf, 0x3e, 0x9e, 0x1a, 0x65, 0xa7, 0x7a, 0x9e, 0xf2, 0x43, 0x3f,
+	0xbe, 0x15, 0xc1, 0x3e, 0x03, 0xa8, 0x74, 0xb0, 0xfc, 0x88, 0x4a, 0x6b, 0x0c, 0xe6, 0x6f, 0xb3,
+	0xb9, 0x9e, 0xdc, 0x93, 0xa4, 0x1f, 0x53, 0x79, 0x8d, 0x41, 0xb1, 0xa9, 0xed, 0xa7, 0xfe, 0x09,
+	0xa9, 0xe9, 0x30, 0xc7, 0x9f, 0xac, 0xe6, 0xf8, 0x18, 0x62, 0x7a, 0x1c, 0xe5, 0x94, 0xe1, 0xdc,
+	0xcb, 0x5f, 0x11, 0xf8, 0x17, 0x75, 0x3e, 0x71, 0xb3, 0x07, 0x89, 0xb6, 0xa3, 0x73, 0x9e, 0x51,
+	0xe9, 0x1a, 0x8b, 0x02, 0x5e, 0x1b, 0x9d, 0x6b, 0x2b, 0xe7, 0x7c, 0xe0, 0x05, 0x2c, 0x31, 0x0d,
+	0x54, 0x9e, 0x1b, 0xfd, 0xa0, 0xf8, 0xa7, 0xc3, 0xd6, 0xa8, 0x2b, 0x4a, 0xd8, 0x34, 0xfd, 0xf1,
+	0xba, 0xe9, 0x4f, 0xa1, 0xe3, 0x25, 0x0c, 0x96, 0x6d, 0x3d, 0xb6, 0x6c, 0xe3, 0xcf, 0x22, 0x2d,
+	0x9f, 0x9a, 0x7d, 0xd7, 0x98, 0x16, 0x2a, 0xec, 0x9d, 0xb1, 0xf0, 0x3c, 0xb5, 0x8c, 0x68, 0x0c,
+	0xd5, 0x09, 0x74, 0xc8, 0x15, 0x38, 0xf7, 0x1b, 0xac, 0xe2, 0x73, 0xc9, 0xf7, 0xd0, 0xf3, 0xa7,
+	0xfc, 0x22, 0xdd, 0x64, 0x8a, 0x2e, 0xf0, 0xb0, 0xfc, 0x24, 0x95, 0x2e, 0xf0, 0xac, 0x28, 0xb3,
+	0x67, 0xff, 0xb5, 0x20, 0x7e, 0x71, 0x7e, 0xad, 0x6f, 0x5e, 0x9b, 0x7c, 0xc2, 0x7e, 0x85, 0x7e,
+	0xed, 0xf3, 0x46, 0xd4, 0xb3, 0x50, 0xf8, 0xf8, 0x13, 0x39, 0x18, 0x6c, 0x4a, 0xf9, 0x2f, 0x62,
+	0xb2, 0xc5, 0x9e, 0x03, 0xf8, 0x43, 0x68, 0x9b, 0xd5, 0x24, 0xf8, 0xda, 0x7e, 0x85, 0xab, 0x82,
+	0x6f, 0xa0, 0x8f, 0xff, 0x0e, 0x69, 0xad, 0xaa, 0xd9, 0xf5, 0xa0, 0x09, 0x93, 0x2d, 0xf6, 0x33,
+	0xf4, 0x6b, 0xb7, 0xa6, 0x12, 0xd6, 0x58, 0x43, 0xfc, 0x60, 0x03, 0x97, 0x6c, 0xdd, 0x75, 0x88,
+	0xfc, 0xf6, 0xff, 0x01, 0x00, 0xd9, 0x3d, 0x44, 0x8c, 0xac, 0x08, 0x00, 0x00,
 }
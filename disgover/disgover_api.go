@@ -28,3 +28,88 @@
 // - functions as a gateway to outside local network
 package disgover
 
+import (
+	"fmt"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// GetPeers - The live peers in the k-buckets
+func (this *DisGoverService) GetPeers() *types.Response {
+	response := types.NewResponse()
+	response.Data = this.Peers()
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved peers [status=%s]", response.Status))
+	return response
+}
+
+// GetPeer - Locates the node by address
+func (this *DisGoverService) GetPeer(address string) *types.Response {
+	response := types.NewResponse()
+	node, err := this.FindNode(address)
+	if err != nil {
+		response.Status = types.StatusNotFound
+	} else {
+		response.Data = node
+		response.Status = types.StatusOk
+	}
+	utils.Info(fmt.Sprintf("retrieved peer [address=%s, status=%s]", address, response.Status))
+	return response
+}
+
+
+// GetHealth - The health and check history of every node checked
+func (this *DisGoverService) GetHealth() *types.Response {
+	response := types.NewResponse()
+	response.Data = this.Health()
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved health [status=%s]", response.Status))
+	return response
+}
+
+// GetNodeHealth
+func (this *DisGoverService) GetNodeHealth(address string) *types.Response {
+	response := types.NewResponse()
+	health, err := this.NodeHealth(address)
+	if err != nil {
+		response.Status = types.StatusNotFound
+	} else {
+		response.Data = health
+		response.Status = types.StatusOk
+	}
+	utils.Info(fmt.Sprintf("retrieved node health [address=%s, status=%s]", address, response.Status))
+	return response
+}
+
+// GetRollouts - The releases this seed rolled out and their acknowledgements
+func (this *DisGoverService) GetRollouts() *types.Response {
+	response := types.NewResponse()
+	response.Data = this.Rollouts()
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved rollouts [status=%s]", response.Status))
+	return response
+}
+
+// GetRollout
+func (this *DisGoverService) GetRollout(hash string) *types.Response {
+	response := types.NewResponse()
+	rollout, err := this.Rollout(hash)
+	if err != nil {
+		response.Status = types.StatusNotFound
+	} else {
+		response.Data = rollout
+		response.Status = types.StatusOk
+	}
+	utils.Info(fmt.Sprintf("retrieved rollout [hash=%s, status=%s]", hash, response.Status))
+	return response
+}
+
+// GetTransfers - Progress of downloading every release's software
+func (this *DisGoverService) GetTransfers() *types.Response {
+	response := types.NewResponse()
+	response.Data = this.Transfers()
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved transfers [status=%s]", response.Status))
+	return response
+}
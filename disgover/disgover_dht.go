@@ -0,0 +1,318 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"github.com/libp2p/go-libp2p-kbucket"
+	"github.com/libp2p/go-libp2p-peer"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// FindNode - Locates the node by address, from the k-buckets or by asking the peers closest to it
+func (this *DisGoverService) FindNode(address string) (*types.Node, error) {
+	if address == this.ThisNode.Address {
+		return this.ThisNode, nil
+	}
+	node, _ := this.lookup(address)
+	if node == nil {
+		return nil, types.ErrNotFound
+	}
+	return node, nil
+}
+
+// Peers - The live peers in the k-buckets
+func (this *DisGoverService) Peers() []*types.Node {
+	ids := this.kdht.ListPeers()
+	this.peersMutex.RLock()
+	defer this.peersMutex.RUnlock()
+	peers := make([]*types.Node, 0, len(ids))
+	for _, id := range ids {
+		if node, ok := this.peers[string(id)]; ok {
+			peers = append(peers, node)
+		}
+	}
+	return peers
+}
+
+// FindNodeGrpc - Answers with the peers closest to the target, the node asking is added once it answers a ping
+func (this *DisGoverService) FindNodeGrpc(ctx context.Context, findNode *proto.FindNode) (*proto.Nodes, error) {
+	if findNode.Authentication == nil || !isValidProtoNode(findNode.Node) {
+		return nil, errors.New("invalid find node")
+	}
+	node := convertToDomainNode(findNode.Node)
+
+	// Same network and chain?
+	if !types.IsNetwork(node.NetworkId, this.ThisNode.NetworkId) || node.GenesisHash != this.ThisNode.GenesisHash {
+		return nil, errors.New(fmt.Sprintf("node is on another network or chain [networkId=%s, genesisHash=%s]", node.NetworkId, node.GenesisHash))
+	}
+	err := convertToDomainAuthentication(findNode.Authentication).Verify(this.db.GetCache(), node.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to authenticate you [error=%s]", err.Error()))
+	}
+	this.verifyPeer(node)
+
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*proto.Node, 0)
+	for _, peer := range this.closestPeers(findNode.Target, types.DhtBucketSize) {
+		nodes = append(nodes, convertToProtoNode(peer))
+	}
+	return &proto.Nodes{Authentication: convertToProtoAuthentication(authentication), Nodes: nodes}, nil
+}
+
+// peerFindNodeGrpc - Asks the node for the peers it knows closest to the target
+func (this *DisGoverService) peerFindNodeGrpc(node *types.Node, target string) ([]*types.Node, error) {
+	client, closeClient, err := this.transport.NewClient(node)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.FindNodeGrpc(ctx, &proto.FindNode{Authentication: convertToProtoAuthentication(authentication), Node: convertToProtoNode(this.ThisNode), Target: target})
+	if err != nil {
+		return nil, err
+	}
+
+	// Did the node we asked answer?
+	if response.Authentication == nil {
+		return nil, errors.New("unable to authenticate peer")
+	}
+	err = convertToDomainAuthentication(response.Authentication).Verify(this.db.GetCache(), node.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to authenticate peer [address=%s, error=%s]", node.Address, err.Error()))
+	}
+	nodes := make([]*types.Node, 0, len(response.Nodes))
+	for _, protoNode := range response.Nodes {
+		if isValidProtoNode(protoNode) {
+			nodes = append(nodes, convertToDomainNode(protoNode))
+		}
+	}
+	return nodes, nil
+}
+
+// lookup - Asks the closest peers not yet asked, DhtAlpha at a time, until the target answers or the closest peers
+// were all asked. Peers that answer are added to the k-buckets, peers that do not are removed.
+func (this *DisGoverService) lookup(target string) (*types.Node, []*types.Node) {
+	targetId := kbucket.ConvertPeerID(peer.ID(target))
+	candidates := make(map[string]*types.Node)
+	for _, node := range this.closestPeers(target, types.DhtBucketSize) {
+		candidates[node.Address] = node
+	}
+	if len(candidates) == 0 {
+		for _, seed := range this.config.Seeds {
+			if seed.Address != this.ThisNode.Address {
+				candidates[seed.Address] = seed
+			}
+		}
+	}
+
+	type answer struct {
+		node  *types.Node
+		nodes []*types.Node
+		err   error
+	}
+	asked := make(map[string]bool)
+	live := make([]*types.Node, 0)
+	for {
+
+		// Closest candidates not yet asked.
+		ids := make([]peer.ID, 0, len(candidates))
+		for address := range candidates {
+			ids = append(ids, peer.ID(address))
+		}
+		ids = kbucket.SortClosestPeers(ids, targetId)
+		if len(ids) > types.DhtBucketSize {
+			ids = ids[:types.DhtBucketSize]
+		}
+		next := make([]*types.Node, 0, types.DhtAlpha)
+		for _, id := range ids {
+			if len(next) < types.DhtAlpha && !asked[string(id)] {
+				next = append(next, candidates[string(id)])
+			}
+		}
+		if len(next) == 0 {
+			return nil, live
+		}
+
+		answers := make(chan answer, len(next))
+		for _, node := range next {
+			asked[node.Address] = true
+			go func(node *types.Node) {
+				nodes, err := this.peerFindNodeGrpc(node, target)
+				answers <- answer{node: node, nodes: nodes, err: err}
+			}(node)
+		}
+		var found *types.Node
+		for range next {
+			answer := <-answers
+			if answer.err != nil {
+				utils.Warn(fmt.Sprintf("peer did not answer find node [address=%s, error=%s]", answer.node.Address, answer.err.Error()))
+				this.removePeer(answer.node.Address)
+				delete(candidates, answer.node.Address)
+				continue
+			}
+			this.addPeer(answer.node)
+			live = append(live, answer.node)
+			if answer.node.Address == target {
+				found = answer.node
+			}
+			for _, node := range answer.nodes {
+				if _, ok := candidates[node.Address]; !ok && node.Address != this.ThisNode.Address {
+					candidates[node.Address] = node
+				}
+			}
+		}
+		if found != nil {
+			return found, live
+		}
+	}
+}
+
+// refreshWorker - Joins the DHT, then looks up this node and a random address every refresh interval so the
+// k-buckets stay populated with live peers
+func (this *DisGoverService) refreshWorker() {
+	this.lookup(this.ThisNode.Address)
+	atomic.AddInt32(&this.working, -1)
+
+	interval := time.Duration(this.config.DhtRefreshInterval) * time.Millisecond
+	if interval <= 0 {
+		return
+	}
+	for {
+		select {
+		case <-this.clock.After(interval):
+			this.refresh()
+		case <-this.stop:
+			return
+		}
+	}
+}
+
+// refresh
+func (this *DisGoverService) refresh() {
+	this.lookup(this.ThisNode.Address)
+	random := make([]byte, crypto.AddressLength)
+	_, err := rand.Read(random)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	this.lookup(hex.EncodeToString(random))
+	utils.Debug(fmt.Sprintf("refreshed k-buckets [peers=%d]", this.kdht.Size()))
+}
+
+// verifyPeer - Adds the node that called once it answers a ping at the endpoints it claims, a peer already known at
+// those endpoints is only moved to the front
+func (this *DisGoverService) verifyPeer(node *types.Node) {
+	this.peersMutex.Lock()
+	known, ok := this.peers[node.Address]
+	if ok && sameEndpoints(known, node) {
+		this.peersMutex.Unlock()
+		this.addPeer(node)
+		return
+	}
+	if this.verifying[node.Address] {
+		this.peersMutex.Unlock()
+		return
+	}
+	this.verifying[node.Address] = true
+	this.peersMutex.Unlock()
+
+	atomic.AddInt32(&this.working, 1)
+	go func() {
+		defer atomic.AddInt32(&this.working, -1)
+		err := this.peerPingGrpc(node)
+		this.peersMutex.Lock()
+		delete(this.verifying, node.Address)
+		this.peersMutex.Unlock()
+		if err != nil {
+			utils.Warn(fmt.Sprintf("node did not answer at its endpoints [address=%s, error=%s]", node.Address, err.Error()))
+			return
+		}
+		this.addPeer(node)
+	}()
+}
+
+// addPeer - Adds the node to its k-bucket or moves it to the front, a full bucket drops its least active peer
+func (this *DisGoverService) addPeer(node *types.Node) {
+	if node.Address == this.ThisNode.Address || node.GrpcEndpoint == nil || node.HttpEndpoint == nil {
+		return
+	}
+	peerNode := *node
+	this.peersMutex.Lock()
+	this.peers[node.Address] = &peerNode
+	this.peersMutex.Unlock()
+	this.kdht.Update(peer.ID(node.Address))
+}
+
+// removePeer
+func (this *DisGoverService) removePeer(address string) {
+	this.kdht.Remove(peer.ID(address))
+}
+
+// peerRemoved - Called by the k-buckets when a peer is dropped
+func (this *DisGoverService) peerRemoved(id peer.ID) {
+	this.peersMutex.Lock()
+	defer this.peersMutex.Unlock()
+	delete(this.peers, string(id))
+}
+
+// closestPeers - The peers of the k-buckets closest to the target
+func (this *DisGoverService) closestPeers(target string, count int) []*types.Node {
+	ids := this.kdht.NearestPeers(kbucket.ConvertPeerID(peer.ID(target)), count)
+	this.peersMutex.RLock()
+	defer this.peersMutex.RUnlock()
+	nodes := make([]*types.Node, 0, len(ids))
+	for _, id := range ids {
+		if node, ok := this.peers[string(id)]; ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// sameEndpoints
+func sameEndpoints(node *types.Node, other *types.Node) bool {
+	if node.GrpcEndpoint == nil || node.HttpEndpoint == nil || other.GrpcEndpoint == nil || other.HttpEndpoint == nil {
+		return false
+	}
+	return *node.GrpcEndpoint == *other.GrpcEndpoint && *node.HttpEndpoint == *other.HttpEndpoint
+}
+
+// isValidProtoNode - Has the endpoints the conversion to a domain node needs
+func isValidProtoNode(node *proto.Node) bool {
+	return node != nil && node.GrpcEndpoint != nil && node.HttpEndpoint != nil
+}
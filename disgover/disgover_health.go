@@ -0,0 +1,287 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// AvailabilityHandler - Called when the checks of a node find it became available or unavailable
+type AvailabilityHandler func(node *types.Node, available bool)
+
+// OnAvailability - Registers the handler with the health checks
+func (this *DisGoverService) OnAvailability(handler AvailabilityHandler) {
+	this.healthMutex.Lock()
+	defer this.healthMutex.Unlock()
+	this.availabilityHandlers = append(this.availabilityHandlers, handler)
+}
+
+// Health - The health of every node checked, sorted by address
+func (this *DisGoverService) Health() []*types.NodeHealth {
+	this.healthMutex.RLock()
+	defer this.healthMutex.RUnlock()
+	healths := make([]*types.NodeHealth, 0, len(this.health))
+	for _, health := range this.health {
+		healths = append(healths, copyHealth(health))
+	}
+	sort.Slice(healths, func(i, j int) bool { return healths[i].Node.Address < healths[j].Node.Address })
+	return healths
+}
+
+// NodeHealth - The health of the node, ErrNotFound when it was never checked
+func (this *DisGoverService) NodeHealth(address string) (*types.NodeHealth, error) {
+	this.healthMutex.RLock()
+	defer this.healthMutex.RUnlock()
+	health, ok := this.health[address]
+	if !ok {
+		return nil, types.ErrNotFound
+	}
+	return copyHealth(health), nil
+}
+
+// CheckHealth - Pings every known node once, evicted nodes included so they are restored when they answer again
+func (this *DisGoverService) CheckHealth() {
+	var waitGroup sync.WaitGroup
+	for _, node := range this.knownNodes() {
+		waitGroup.Add(1)
+		go func(node *types.Node) {
+			defer waitGroup.Done()
+			start := this.clock.Now()
+			err := this.peerPingGrpc(node)
+			check := types.HealthCheck{Time: this.clock.Now(), Ok: err == nil, Latency: int64(this.clock.Now().Sub(start) / time.Millisecond)}
+			if err != nil {
+				check.Latency = 0
+				check.Error = err.Error()
+			}
+			this.recordCheck(node, check)
+		}(node)
+	}
+	waitGroup.Wait()
+}
+
+// PingGrpc - Answers a health check
+func (this *DisGoverService) PingGrpc(ctx context.Context, ping *proto.Ping) (*proto.Ping, error) {
+	if ping.Authentication == nil || !isValidProtoNode(ping.Node) {
+		return nil, errors.New("invalid ping")
+	}
+	node := convertToDomainNode(ping.Node)
+
+	// Same network and chain?
+	if !types.IsNetwork(node.NetworkId, this.ThisNode.NetworkId) || node.GenesisHash != this.ThisNode.GenesisHash {
+		return nil, errors.New(fmt.Sprintf("node is on another network or chain [networkId=%s, genesisHash=%s]", node.NetworkId, node.GenesisHash))
+	}
+	err := convertToDomainAuthentication(ping.Authentication).Verify(this.db.GetCache(), node.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to authenticate you [error=%s]", err.Error()))
+	}
+	this.verifyPeer(node)
+
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Ping{Authentication: convertToProtoAuthentication(authentication), Node: convertToProtoNode(this.ThisNode)}, nil
+}
+
+// peerPingGrpc - Pings the node, fails unless the node itself answers
+func (this *DisGoverService) peerPingGrpc(node *types.Node) error {
+	client, closeClient, err := this.transport.NewClient(node)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return err
+	}
+	response, err := client.PingGrpc(ctx, &proto.Ping{Authentication: convertToProtoAuthentication(authentication), Node: convertToProtoNode(this.ThisNode)})
+	if err != nil {
+		return err
+	}
+	if response.Authentication == nil {
+		return errors.New("unable to authenticate peer")
+	}
+	return convertToDomainAuthentication(response.Authentication).Verify(this.db.GetCache(), node.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+}
+
+// recordCheck - Records the check of the node, evicts a node that failed too many checks in a row and restores it once
+// it answers, then publishes any availability change. Eviction only changes the routing of this node, the delegates
+// consensus counts are left alone.
+func (this *DisGoverService) recordCheck(node *types.Node, check types.HealthCheck) {
+	this.healthMutex.Lock()
+	health, ok := this.health[node.Address]
+	if !ok {
+		health = types.NewNodeHealth(nil)
+		this.health[node.Address] = health
+	}
+	if !health.Evicted {
+		checked := *node
+		health.Node = &checked
+	}
+	changed := health.Record(check)
+	evict := health.ShouldEvict() && !this.isSeed(node.Address)
+	restore := check.Ok && health.Evicted
+	if evict {
+		health.Evicted = true
+	}
+	if restore {
+		health.Evicted = false
+	}
+	available := health.Available
+	checked := *health.Node
+	handlers := append([]AvailabilityHandler{}, this.availabilityHandlers...)
+	this.healthMutex.Unlock()
+
+	if evict {
+		this.evict(&checked)
+	}
+	if restore {
+		this.restore(&checked)
+	}
+	if changed {
+		utils.Info(fmt.Sprintf("node availability changed [address=%s, available=%t]", checked.Address, available))
+		for _, handler := range handlers {
+			handler(&checked, available)
+		}
+	}
+}
+
+// evict - Drops the node from the k-buckets, the health table keeps it so it is checked again
+func (this *DisGoverService) evict(node *types.Node) {
+	utils.Warn(fmt.Sprintf("evicting node [address=%s, failures=%d]", node.Address, types.HealthEvictionThreshold))
+	this.removePeer(node.Address)
+}
+
+// restore - Adds an evicted node back to the k-buckets once it answers
+func (this *DisGoverService) restore(node *types.Node) {
+	utils.Info(fmt.Sprintf("restoring node [address=%s]", node.Address))
+	this.addPeer(node)
+}
+
+// knownNodes - The seeds, cached delegates, peers and evicted nodes, this node excluded
+func (this *DisGoverService) knownNodes() []*types.Node {
+	nodes := make(map[string]*types.Node)
+	for _, seed := range this.config.Seeds {
+		nodes[seed.Address] = seed
+	}
+	delegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
+	if err != nil {
+		utils.Error(err)
+	}
+	for _, delegate := range delegates {
+		nodes[delegate.Address] = delegate
+	}
+	for _, peer := range this.Peers() {
+		if _, ok := nodes[peer.Address]; !ok {
+			nodes[peer.Address] = peer
+		}
+	}
+	this.healthMutex.RLock()
+	for address, health := range this.health {
+		if health.Evicted {
+			nodes[address] = health.Node
+		}
+	}
+	this.healthMutex.RUnlock()
+
+	known := make([]*types.Node, 0, len(nodes))
+	for address, node := range nodes {
+		if address != this.ThisNode.Address && node.GrpcEndpoint != nil {
+			known = append(known, node)
+		}
+	}
+	return known
+}
+
+// healthWorker
+func (this *DisGoverService) healthWorker() {
+	interval := time.Duration(this.config.HealthCheckInterval) * time.Millisecond
+	for {
+		select {
+		case <-this.clock.After(interval):
+			this.CheckHealth()
+		case <-this.stop:
+			return
+		}
+	}
+}
+
+// isSeed
+func (this *DisGoverService) isSeed(address string) bool {
+	for _, seed := range this.config.Seeds {
+		if seed.Address == address {
+			return true
+		}
+	}
+	return false
+}
+
+// delegates - Of the chain now (see types.ToDelegateAddresses) so proposals add and remove them, those of the genesis
+// or the config until this node persisted the genesis
+func (this *DisGoverService) delegates() []string {
+	if len(this.delegateAddresses) == 0 {
+		return this.delegateAddresses
+	}
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	parameters, err := types.ToParametersAt(txn, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			utils.Error(err)
+		}
+		return this.delegateAddresses
+	}
+	return parameters.Delegates
+}
+
+// isDelegate - Of the chain, or registered with the seeds as one when neither the genesis nor the config lists
+// delegates
+func (this *DisGoverService) isDelegate(address string) bool {
+	delegates := this.delegates()
+	if len(delegates) == 0 {
+		node, err := types.ToNodeFromCache(this.db.GetCache(), address)
+		return err == nil && node != nil && node.Type == types.TypeDelegate
+	}
+	for _, delegateAddress := range delegates {
+		if delegateAddress == address {
+			return true
+		}
+	}
+	return false
+}
+
+// copyHealth - So callers never share the history being recorded
+func copyHealth(health *types.NodeHealth) *types.NodeHealth {
+	copied := *health
+	node := *health.Node
+	copied.Node = &node
+	copied.History = append([]types.HealthCheck{}, health.History...)
+	return &copied
+}
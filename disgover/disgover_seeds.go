@@ -0,0 +1,192 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// ReplicateGrpc - Persists the nodes another seed registered and answers with the nodes this seed registered
+func (this *DisGoverService) ReplicateGrpc(ctx context.Context, replicate *proto.Replicate) (*proto.Replicate, error) {
+
+	// Is this node a seed?
+	if this.ThisNode.Type != types.TypeSeed {
+		return nil, errors.New("you replicated to a non-seed node")
+	}
+
+	// Verify seed node is authentic?
+	err := this.verifySeedNode(replicate.Authentication)
+	if err != nil {
+		return nil, err
+	}
+	if replicate.GenesisHash != this.ThisNode.GenesisHash {
+		return nil, errors.New(fmt.Sprintf("seed node has another genesis [hash=%s, expected=%s]", replicate.GenesisHash, this.ThisNode.GenesisHash))
+	}
+
+	// Answer with what this seed had before merging.
+	nodes, err := this.registeredNodes()
+	if err != nil {
+		return nil, err
+	}
+	protoNodes := make([]*proto.Node, 0, len(nodes))
+	for _, node := range nodes {
+		protoNodes = append(protoNodes, convertToProtoNode(node))
+	}
+	this.mergeNodes(replicate.Nodes)
+
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	utils.Info(fmt.Sprintf("received replication [nodes=%d]", len(replicate.Nodes)))
+	return &proto.Replicate{Authentication: convertToProtoAuthentication(authentication), Nodes: protoNodes, GenesisHash: this.ThisNode.GenesisHash}, nil
+}
+
+// peerReplicateGrpc - Sends the nodes to every other seed and merges the nodes they answer with, a seed that does not
+// answer is skipped
+func (this *DisGoverService) peerReplicateGrpc(nodes []*types.Node) {
+	protoNodes := make([]*proto.Node, 0, len(nodes))
+	for _, node := range nodes {
+		protoNodes = append(protoNodes, convertToProtoNode(node))
+	}
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+
+	for _, seed := range this.config.Seeds {
+		if seed.Address == this.ThisNode.Address {
+			continue
+		}
+		response, err := this.replicateTo(seed, &proto.Replicate{Authentication: convertToProtoAuthentication(authentication), Nodes: protoNodes, GenesisHash: this.ThisNode.GenesisHash})
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to replicate to seed node [address=%s, error=%s]", seed.Address, err.Error()))
+			continue
+		}
+		this.mergeNodes(response.Nodes)
+	}
+}
+
+// replicateTo
+func (this *DisGoverService) replicateTo(seed *types.Node, replicate *proto.Replicate) (*proto.Replicate, error) {
+	client, closeClient, err := this.transport.NewClient(seed)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	response, err := client.ReplicateGrpc(ctx, replicate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify seed node is authentic?
+	if response.Authentication == nil {
+		return nil, errors.New("unable to authenticate seed node")
+	}
+	err = convertToDomainAuthentication(response.Authentication).Verify(this.db.GetCache(), seed.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	if response.GenesisHash != this.ThisNode.GenesisHash {
+		return nil, errors.New(fmt.Sprintf("seed node has another genesis [hash=%s, expected=%s]", response.GenesisHash, this.ThisNode.GenesisHash))
+	}
+	return response, nil
+}
+
+// mergeNodes - Persists and caches the nodes this seed does not know yet or that a seed registered more recently
+func (this *DisGoverService) mergeNodes(protoNodes []*proto.Node) {
+	txn := this.db.NewTxn(true)
+	defer txn.Discard()
+
+	merged := 0
+	for _, protoNode := range protoNodes {
+		if !isValidProtoNode(protoNode) || protoNode.Address == this.ThisNode.Address {
+			continue
+		}
+		node := convertToDomainNode(protoNode)
+		if cached, err := types.ToNodeFromCache(this.db.GetCache(), node.Address); err == nil {
+			if cached.Registered >= node.Registered {
+				continue
+			}
+			node.Status = cached.Status
+			node.StatusTime = cached.StatusTime
+		}
+		err := node.Set(txn, this.db.GetCache())
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		this.addPeer(node)
+		merged++
+	}
+	err := txn.Commit(nil)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	if merged > 0 {
+		utils.Info(fmt.Sprintf("merged replicated nodes [nodes=%d]", merged))
+	}
+}
+
+// registeredNodes - Every node persisted by this node that has not expired
+func (this *DisGoverService) registeredNodes() ([]*types.Node, error) {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	nodes, err := types.ToNodesByType(txn, "")
+	if err != nil {
+		return nil, err
+	}
+	return this.unexpiredNodes(nodes), nil
+}
+
+// persistedDelegates - The delegates this node persisted the last time a seed or delegate told it about them, skips
+// the ones no seed registered for PersistedNodeTtl
+func (this *DisGoverService) persistedDelegates() ([]*types.Node, error) {
+	txn := this.db.NewTxn(false)
+	defer txn.Discard()
+	nodes, err := types.ToNodesByType(txn, types.TypeDelegate)
+	if err != nil {
+		return nil, err
+	}
+	return this.unexpiredNodes(nodes), nil
+}
+
+// unexpiredNodes
+func (this *DisGoverService) unexpiredNodes(nodes []*types.Node) []*types.Node {
+	now := this.clock.Now()
+	result := make([]*types.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.IsExpired(now) {
+			utils.Debug(fmt.Sprintf("skipping expired node [address=%s]", node.Address))
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
@@ -19,6 +19,7 @@ package disgover
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	//"github.com/dispatchlabs/disgo/commons/services"
 	"github.com/dispatchlabs/disgo/commons/services"
@@ -27,9 +28,6 @@ import (
 	"github.com/libp2p/go-libp2p-kbucket"
 	"github.com/libp2p/go-libp2p-peer"
 	"github.com/libp2p/go-libp2p-peerstore"
-	"time"
-	"os"
-	"io/ioutil"
 )
 
 var disGoverServiceInstance *DisGoverService
@@ -38,31 +36,76 @@ var disGoverServiceOnce sync.Once
 // GetDisGoverService
 func GetDisGoverService() *DisGoverService {
 	disGoverServiceOnce.Do(func() {
-		disGoverServiceInstance = &DisGoverService{
-			ThisNode: &types.Node{
-				Address:      types.GetAccount().Address,
-				GrpcEndpoint: types.GetConfig().GrpcEndpoint,
-				HttpEndpoint: types.GetConfig().HttpEndpoint,
-				Type:         types.TypeNode,
-			},
-			// lruCache: lCache,
-			kdht: kbucket.NewRoutingTable(
-				1000,
-				kbucket.ConvertPeerID(peer.ID(types.GetAccount().Address)),
-				1000,
-				peerstore.NewMetrics(),
-			),
-			running: false,
-		}
+		disGoverServiceInstance = NewDisGoverService(services.GetDbService(), types.GetAccount(), types.GetConfig(), utils.Events(), utils.NewSystemClock())
 	})
 	return disGoverServiceInstance
 }
 
+// NewDisGoverService - DisGover service with its own DB, keys, config, events and clock (eg; one per simulated node)
+func NewDisGoverService(db *services.DbService, account *types.Account, config *types.Config, events *utils.EventManager, clock utils.Clock) *DisGoverService {
+	this := &DisGoverService{
+		ThisNode: &types.Node{
+			Address:      account.Address,
+			GrpcEndpoint: config.GrpcEndpoint,
+			HttpEndpoint: config.HttpEndpoint,
+			Type:         types.TypeNode,
+		},
+		// lruCache: lCache,
+		kdht: kbucket.NewRoutingTable(
+			types.DhtBucketSize,
+			kbucket.ConvertPeerID(peer.ID(account.Address)),
+			1000,
+			peerstore.NewMetrics(),
+		),
+		peers:             map[string]*types.Node{},
+		verifying:         map[string]bool{},
+		health:            map[string]*types.NodeHealth{},
+		db:                db,
+		account:           account,
+		config:            config,
+		events:            events,
+		clock:             clock,
+		running:           false,
+		rollouts:          map[string]*types.Rollout{},
+		rolloutSignals:    map[string]chan bool{},
+		transfers:         map[string]*types.Transfer{},
+		softwareDirectory: ".",
+		stop:              make(chan bool),
+	}
+	this.kdht.PeerRemoved = this.peerRemoved
+	this.reboot = this.exit
+	return this
+}
+
 // DisGoverService
 type DisGoverService struct {
-	ThisNode *types.Node
-	kdht     *kbucket.RoutingTable
-	running  bool
+	ThisNode             *types.Node
+	kdht                 *kbucket.RoutingTable
+	peers                map[string]*types.Node // Of the k-buckets, by address
+	verifying            map[string]bool        // Nodes pinged before they are added to the k-buckets, by address
+	peersMutex           sync.RWMutex
+	working              int32 // Joining the DHT and peer verifications not done yet
+	health               map[string]*types.NodeHealth // Of every node checked, by address
+	healthMutex          sync.RWMutex
+	availabilityHandlers []AvailabilityHandler
+	rollouts             map[string]*types.Rollout // Started by this seed, by manifest hash
+	rolloutSignals       map[string]chan bool      // Signalled on every acknowledgement, by manifest hash
+	rolloutsMutex        sync.RWMutex
+	transfers            map[string]*types.Transfer // Of release software this node downloaded, by software hash
+	transfersMutex       sync.RWMutex
+	downloads            int32  // Streams of software this node is serving
+	softwareDirectory    string // Where disgo and update artifacts are installed
+	reboot               func()
+	delegateAddresses    []string // Of the genesis, or the config, until this node persisted the genesis
+	running              bool
+	stop                 chan bool // Closed to stop the workers
+	stopOnce             sync.Once
+	transport            DisGoverTransport
+	db                   *services.DbService
+	account              *types.Account
+	config               *types.Config
+	events               *utils.EventManager
+	clock                utils.Clock
 }
 
 // IsRunning - Returns the status if service is running
@@ -70,81 +113,103 @@ func (this *DisGoverService) IsRunning() bool {
 	return this.running
 }
 
+// Working - Joining the DHT, peer verifications and a seed's updates not done yet, zero when nothing is sent until the clock moves on
+// (eg; a simulated network has settled)
+func (this *DisGoverService) Working() int {
+	return int(atomic.LoadInt32(&this.working))
+}
+
+// Stop - Stops the workers
+func (this *DisGoverService) Stop() {
+	this.stopOnce.Do(func() {
+		this.running = false
+		close(this.stop)
+	})
+}
+
 // Go - Starts, Init and Runs the service
 func (this *DisGoverService) Go() {
 	this.running = true
 
+	// Which chain?
+	genesis, err := types.ToGenesisFromConfig(this.config)
+	if err != nil {
+		this.db.Close()
+		utils.Fatal("unable to read genesis", err)
+	}
+	this.ThisNode.GenesisHash = genesis.Hash()
+	this.ThisNode.NetworkId = genesis.ChainId
+	this.delegateAddresses = this.config.DelegateAddresses
+	if len(genesis.Delegates) > 0 {
+		this.delegateAddresses = genesis.Delegates
+	}
+
 	// Check if we are a seed.
-	for _, seed := range types.GetConfig().Seeds {
-		if seed.Address == types.GetAccount().Address {
+	for _, seed := range this.config.Seeds {
+		if seed.Address == this.account.Address {
 			this.ThisNode.Type = types.TypeSeed
 			break
 		}
 	}
-	if types.GetConfig().Seeds == nil || len(types.GetConfig().Seeds) == 0 {
+	if this.config.Seeds == nil || len(this.config.Seeds) == 0 {
 		this.ThisNode.Type = types.TypeSeed
 	}
 
-	// Cache delegates?
+	// Persist delegates? When no seed answers boot from the delegates persisted last time.
 	if this.ThisNode.Type != types.TypeSeed {
 		delegates, err := this.peerPingSeedGrpc()
 		if err != nil {
-			utils.Error(err)
-			services.GetDbService().Close()
-			seeds := types.GetConfig().Seeds
-			utils.Fatal(fmt.Sprintf("unable to connect to seed node (%s:%d)...please try again later", seeds[0].GrpcEndpoint.Host, seeds[0].GrpcEndpoint.Port))
+			utils.Warn(err)
+			delegates, err = this.persistedDelegates()
+			if err != nil || len(delegates) == 0 {
+				this.db.Close()
+				utils.Fatal("unable to connect to any seed node and no persisted peers...please try again later")
+			}
+			utils.Info(fmt.Sprintf("booting from persisted peers [delegates=%d]", len(delegates)))
 		}
+		this.persistDelegates(delegates)
 		for _, delegate := range delegates {
-			delegate.Cache(services.GetCache())
 			if delegate.Address == this.ThisNode.Address {
 				this.ThisNode.Type = delegate.Type
 			}
 		}
+	} else {
+
+		// Exchange registrations with the other seeds.
+		nodes, err := this.registeredNodes()
+		if err != nil {
+			utils.Error(err)
+		}
+		this.peerReplicateGrpc(nodes)
+	}
+
+	// Join the DHT in the background, the lookup of this node fills the k-buckets closest to it.
+	for _, seed := range this.config.Seeds {
+		this.addPeer(seed)
+	}
+	delegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
+	if err != nil {
+		utils.Error(err)
+	}
+	for _, delegate := range delegates {
+		this.addPeer(delegate)
+	}
+	atomic.AddInt32(&this.working, 1)
+	go this.refreshWorker()
+
+	// Start health checks.
+	if this.config.HealthCheckInterval > 0 {
+		go this.healthWorker()
 	}
 
+	// Booting into a release?
+	this.checkUpdate()
+
 	// Start update thread.
 	if this.ThisNode.Type == types.TypeSeed {
 		go this.updateWorker()
 	}
 
 	utils.Info(fmt.Sprintf("running as %s", this.ThisNode.Type))
-	utils.Events().Raise(types.Events.DisGoverServiceInitFinished)
-}
-
-// updateWorker
-func (this DisGoverService) updateWorker() {
-	for {
-		timer := time.NewTimer(30 * time.Second)
-		select {
-		case <-timer.C:
-
-			// Any files to update?
-			updateDirectory := "." + string(os.PathSeparator) + "update"
-			files, err := ioutil.ReadDir(updateDirectory)
-			if err != nil {
-				continue
-			}
-
-			for _, file := range files {
-				// Read file?
-				fileName := updateDirectory + string(os.PathSeparator) + file.Name()
-				bytes, err := ioutil.ReadFile(fileName)
-				if err != nil {
-					utils.Error(fmt.Sprintf("unable to read file %s", file.Name()), err)
-					continue
-				}
-
-				utils.Info(fmt.Sprintf("found software to update [file=%s]", fileName))
-
-				// Update software.
-				this.peerUpdateSoftwareGrpc(file.Name(), bytes)
-
-				// Delete file.
-				err = os.Remove(fileName)
-				if err != nil {
-					utils.Warn(fmt.Sprintf("unable to delete file %s", fileName), err)
-				}
-			}
-		}
-	}
+	this.events.Raise(types.Events.DisGoverServiceInitFinished)
 }
@@ -0,0 +1,312 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Transfers - Of every release's software this node downloaded, sorted by file name
+func (this *DisGoverService) Transfers() []*types.Transfer {
+	this.transfersMutex.RLock()
+	defer this.transfersMutex.RUnlock()
+	transfers := make([]*types.Transfer, 0, len(this.transfers))
+	for _, transfer := range this.transfers {
+		transfers = append(transfers, copyTransfer(transfer))
+	}
+	sort.Slice(transfers, func(i, j int) bool { return transfers[i].FileName < transfers[j].FileName })
+	return transfers
+}
+
+// Transfer - By software hash, ErrNotFound when this node never downloaded it
+func (this *DisGoverService) Transfer(softwareHash string) (*types.Transfer, error) {
+	this.transfersMutex.RLock()
+	defer this.transfersMutex.RUnlock()
+	transfer, ok := this.transfers[softwareHash]
+	if !ok {
+		return nil, types.ErrNotFound
+	}
+	return copyTransfer(transfer), nil
+}
+
+// DownloadGrpc - Streams the chunks of software this node has, starting at the chunk asked for
+func (this *DisGoverService) DownloadGrpc(download *proto.Download, stream proto.DisgoverGrpc_DownloadGrpcServer) error {
+	if download.Authentication == nil {
+		return errors.New("invalid download")
+	}
+	authentication := convertToDomainAuthentication(download.Authentication)
+	address, err := authentication.GetDerivedAddress()
+	if err != nil {
+		return err
+	}
+	err = authentication.Verify(this.db.GetCache(), address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return errors.New(fmt.Sprintf("unable to authenticate you [error=%s]", err.Error()))
+	}
+	if !this.isSeed(address) && !this.isDelegate(address) {
+		return errors.New("only delegates and seeds download software")
+	}
+	_, err = hex.DecodeString(download.SoftwareHash)
+	if err != nil || download.SoftwareHash == "" {
+		return errors.New("invalid software hash")
+	}
+	if download.ChunkSize <= 0 || download.ChunkSize > types.UpdateMaxChunkSize || download.FromChunk < 0 {
+		return errors.New(fmt.Sprintf("invalid chunk [chunkSize=%d, fromChunk=%d]", download.ChunkSize, download.FromChunk))
+	}
+
+	// Every stream holds a chunk in memory.
+	if atomic.AddInt32(&this.downloads, 1) > types.UpdateMaxDownloads {
+		atomic.AddInt32(&this.downloads, -1)
+		return errors.New(fmt.Sprintf("serving too many downloads [maximum=%d]", types.UpdateMaxDownloads))
+	}
+	defer atomic.AddInt32(&this.downloads, -1)
+
+	file, err := os.Open(this.artifactFileName(download.SoftwareHash))
+	if err != nil {
+		return errors.New(fmt.Sprintf("software not found [hash=%s]", download.SoftwareHash))
+	}
+	defer file.Close()
+	_, err = file.Seek(download.FromChunk*download.ChunkSize, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	utils.Debug(fmt.Sprintf("sharing software [address=%s, hash=%s, fromChunk=%d]", address, download.SoftwareHash, download.FromChunk))
+	buffer := make([]byte, download.ChunkSize)
+	for index := download.FromChunk; ; index++ {
+		size, err := io.ReadFull(file, buffer)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		err = stream.Send(&proto.Chunk{Index: index, Data: buffer[:size]})
+		if err != nil {
+			return err
+		}
+		if size < len(buffer) {
+			return nil
+		}
+	}
+}
+
+// download - Downloads the release's software chunk by chunk from the sources in turn, resuming from the chunks saved
+// before. The complete software is kept for this node to share
+func (this *DisGoverService) download(manifest types.Manifest, sources []*types.Node) ([]byte, error) {
+	fileName := this.artifactFileName(manifest.SoftwareHash)
+
+	// Downloaded before?
+	software, err := ioutil.ReadFile(fileName)
+	if err == nil && manifest.Verify(software) == nil {
+		this.endTransfer(manifest.SoftwareHash, nil)
+		return software, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(fileName), 0755)
+	if err != nil {
+		this.endTransfer(manifest.SoftwareHash, err)
+		return nil, err
+	}
+	part, err := os.OpenFile(fileName+".part", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		this.endTransfer(manifest.SoftwareHash, err)
+		return nil, err
+	}
+	defer part.Close()
+
+	// Resume after the last whole chunk, a chunk cut short is downloaded again.
+	info, err := part.Stat()
+	if err != nil {
+		this.endTransfer(manifest.SoftwareHash, err)
+		return nil, err
+	}
+	received := int(info.Size() / manifest.ChunkSize)
+	if received > manifest.Chunks() {
+		received = 0
+	}
+	err = part.Truncate(int64(received) * manifest.ChunkSize)
+	if err != nil {
+		this.endTransfer(manifest.SoftwareHash, err)
+		return nil, err
+	}
+	this.updateTransfer(manifest.SoftwareHash, func(transfer *types.Transfer) {
+		transfer.Received = received
+		transfer.ResumedFrom = received
+	})
+	if received > 0 {
+		utils.Info(fmt.Sprintf("resuming download [file=%s, chunk=%d, chunks=%d]", manifest.FileName, received, manifest.Chunks()))
+	}
+
+	for attempt := 0; attempt < types.UpdateDownloadAttempts && received < manifest.Chunks(); attempt++ {
+		for _, source := range sources {
+			if received == manifest.Chunks() {
+				break
+			}
+			if source.Address == this.ThisNode.Address {
+				continue
+			}
+			from := received
+			received, err = this.peerDownloadGrpc(source, manifest, part, received)
+			this.updateTransfer(manifest.SoftwareHash, func(transfer *types.Transfer) {
+				if received > from {
+					transfer.Sources = append(transfer.Sources, source.Address)
+				}
+				if err != nil {
+					transfer.Failures++
+				}
+			})
+			if err != nil {
+				utils.Warn(fmt.Sprintf("source did not send every chunk [address=%s, chunk=%d, chunks=%d]", source.Address, received, manifest.Chunks()), err)
+			}
+		}
+	}
+	if received < manifest.Chunks() {
+		err = errors.New(fmt.Sprintf("no source sent every chunk [received=%d, chunks=%d]", received, manifest.Chunks()))
+		this.endTransfer(manifest.SoftwareHash, err)
+		return nil, err
+	}
+
+	// Every chunk matched, the whole software has to as well.
+	software, err = ioutil.ReadFile(fileName + ".part")
+	if err == nil {
+		err = manifest.Verify(software)
+	}
+	if err != nil {
+		os.Remove(fileName + ".part")
+		this.endTransfer(manifest.SoftwareHash, err)
+		return nil, err
+	}
+	err = os.Rename(fileName+".part", fileName)
+	if err != nil {
+		this.endTransfer(manifest.SoftwareHash, err)
+		return nil, err
+	}
+	this.endTransfer(manifest.SoftwareHash, nil)
+	return software, nil
+}
+
+// peerDownloadGrpc - Saves every chunk the source streams from the chunk given, returns the chunks saved so far
+func (this *DisGoverService) peerDownloadGrpc(source *types.Node, manifest types.Manifest, part *os.File, from int) (int, error) {
+	client, closeClient, err := this.transport.NewClient(source)
+	if err != nil {
+		return from, err
+	}
+	defer closeClient()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(manifest.Chunks()-from)*types.UpdateChunkTimeout)
+	defer cancel()
+
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return from, err
+	}
+	stream, err := client.DownloadGrpc(ctx, &proto.Download{Authentication: convertToProtoAuthentication(authentication), SoftwareHash: manifest.SoftwareHash, ChunkSize: manifest.ChunkSize, FromChunk: int64(from)})
+	if err != nil {
+		return from, err
+	}
+	received := from
+	for received < manifest.Chunks() {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return received, errors.New("source ended before sending every chunk")
+		}
+		if err != nil {
+			return received, err
+		}
+		if chunk.Index != int64(received) {
+			return received, errors.New(fmt.Sprintf("unexpected chunk [index=%d, expected=%d]", chunk.Index, received))
+		}
+		err = manifest.VerifyChunk(received, chunk.Data)
+		if err != nil {
+			return received, err
+		}
+		_, err = part.WriteAt(chunk.Data, int64(received)*manifest.ChunkSize)
+		if err != nil {
+			return received, err
+		}
+		received++
+		this.updateTransfer(manifest.SoftwareHash, func(transfer *types.Transfer) { transfer.Received = received })
+	}
+	return received, nil
+}
+
+// saveArtifact - Keeps the software for this node to share
+func (this *DisGoverService) saveArtifact(manifest *types.Manifest, software []byte) error {
+	fileName := this.artifactFileName(manifest.SoftwareHash)
+	err := os.MkdirAll(filepath.Dir(fileName), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, software, 0644)
+}
+
+// artifactFileName
+func (this *DisGoverService) artifactFileName(softwareHash string) string {
+	return filepath.Join(this.softwareDirectory, "artifacts", softwareHash)
+}
+
+// startTransfer - False when the software is already downloading
+func (this *DisGoverService) startTransfer(manifest *types.Manifest) bool {
+	this.transfersMutex.Lock()
+	defer this.transfersMutex.Unlock()
+	if transfer, ok := this.transfers[manifest.SoftwareHash]; ok && !transfer.Complete && transfer.Error == "" {
+		return false
+	}
+	this.transfers[manifest.SoftwareHash] = &types.Transfer{SoftwareHash: manifest.SoftwareHash, FileName: manifest.FileName, Chunks: manifest.Chunks(), Sources: make([]string, 0)}
+	return true
+}
+
+// updateTransfer
+func (this *DisGoverService) updateTransfer(softwareHash string, update func(transfer *types.Transfer)) {
+	this.transfersMutex.Lock()
+	defer this.transfersMutex.Unlock()
+	if transfer, ok := this.transfers[softwareHash]; ok {
+		update(transfer)
+	}
+}
+
+// endTransfer
+func (this *DisGoverService) endTransfer(softwareHash string, err error) {
+	this.updateTransfer(softwareHash, func(transfer *types.Transfer) {
+		if err != nil {
+			transfer.Error = err.Error()
+			return
+		}
+		transfer.Received = transfer.Chunks
+		transfer.Complete = true
+	})
+}
+
+// copyTransfer
+func copyTransfer(transfer *types.Transfer) *types.Transfer {
+	copied := *transfer
+	copied.Sources = append([]string{}, transfer.Sources...)
+	return &copied
+}
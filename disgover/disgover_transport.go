@@ -0,0 +1,62 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"fmt"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"google.golang.org/grpc"
+)
+
+// DisGoverTransport - How a node serves its peers and reaches them, the returned func closes the client
+type DisGoverTransport interface {
+	Serve(server proto.DisgoverGrpcServer)
+	NewClient(node *types.Node) (proto.DisgoverGrpcClient, func(), error)
+}
+
+// WithTransport - Runs the DisGover service over the given transport
+func (this *DisGoverService) WithTransport(transport DisGoverTransport) *DisGoverService {
+	this.transport = transport
+	transport.Serve(this)
+	return this
+}
+
+// NewGrpcTransport
+func NewGrpcTransport() DisGoverTransport {
+	return &grpcTransport{}
+}
+
+// grpcTransport
+type grpcTransport struct {
+}
+
+// Serve
+func (this *grpcTransport) Serve(server proto.DisgoverGrpcServer) {
+	proto.RegisterDisgoverGrpcServer(services.GetGrpcService().Server, server)
+}
+
+// NewClient
+func (this *grpcTransport) NewClient(node *types.Node) (proto.DisgoverGrpcClient, func(), error) {
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", node.GrpcEndpoint.Host, node.GrpcEndpoint.Port), grpc.WithInsecure())
+	if err != nil {
+		return nil, nil, err
+	}
+	return proto.NewDisgoverGrpcClient(conn), func() { conn.Close() }, nil
+}
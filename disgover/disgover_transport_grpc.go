@@ -18,21 +18,14 @@ package disgover
 
 import (
 	"fmt"
-
+	"sync/atomic"
 	"time"
 
-	"github.com/dispatchlabs/disgo/commons/services"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	proto "github.com/dispatchlabs/disgo/disgover/proto"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
-	"os"
-	"github.com/dispatchlabs/disgo/commons/crypto"
-	"encoding/hex"
-	"io/ioutil"
-	"github.com/jasonlvhit/gocron"
 )
 
 //TODO: we are going to drop delegates if we fail to communicate with them.  The exepctation will be that the seed will tell us when they come back on line
@@ -40,8 +33,7 @@ import (
 
 // WithGrpc - Runs the DisGover service with GRPC transport
 func (this *DisGoverService) WithGrpc() *DisGoverService {
-	proto.RegisterDisgoverGrpcServer(services.GetGrpcService().Server, this)
-	return this
+	return this.WithTransport(NewGrpcTransport())
 }
 
 // PingSeedGrpc
@@ -54,6 +46,16 @@ func (this *DisGoverService) PingSeedGrpc(ctx context.Context, pingSeed *proto.P
 
 	node := convertToDomainNode(pingSeed.Node)
 	authentication := convertToDomainAuthentication(pingSeed.Authentication)
+
+	// Same network and chain?
+	if !types.IsNetwork(node.NetworkId, this.ThisNode.NetworkId) || !types.IsNetwork(authentication.NetworkId, this.ThisNode.NetworkId) {
+		utils.Warn(fmt.Sprintf("node is on another network [address=%s, networkId=%s]", node.Address, node.NetworkId))
+		return nil, errors.New(fmt.Sprintf("network id mismatch [networkId=%s, expected=%s]", node.NetworkId, this.ThisNode.NetworkId))
+	}
+	if node.GenesisHash != this.ThisNode.GenesisHash {
+		utils.Warn(fmt.Sprintf("node has another genesis [address=%s, genesisHash=%s]", node.Address, node.GenesisHash))
+		return nil, errors.New(fmt.Sprintf("genesis hash mismatch [hash=%s, expected=%s]", node.GenesisHash, this.ThisNode.GenesisHash))
+	}
 	authenticationAddress, err := authentication.GetDerivedAddress()
 	if err != nil {
 		return nil, errors.New(fmt.Sprintf("unable to authenticate you [error=%s]", err.Error()))
@@ -63,20 +65,21 @@ func (this *DisGoverService) PingSeedGrpc(ctx context.Context, pingSeed *proto.P
 	}
 
 	// Persist and cache node.
-	txn := services.NewTxn(true)
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
 
 	// If delegate addresses is not set all nodes other than seed become a delegate (making it easy for testing and production).
-	if len(types.GetConfig().DelegateAddresses) == 0 {
+	delegateAddresses := this.delegates()
+	if len(delegateAddresses) == 0 {
 		node.Type = types.TypeDelegate
 	} else {
-		for _, delegateAddress := range types.GetConfig().DelegateAddresses {
+		for _, delegateAddress := range delegateAddresses {
 
 			// Is this a delegate node?
 			if delegateAddress == node.Address {
 
 				// Is this an authentic delegate?
-				err := authentication.Verify(services.GetCache(), node.Address)
+				err := authentication.Verify(this.db.GetCache(), node.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
 				if err != nil {
 					utils.Warn(fmt.Sprintf("unable to authenticate delegate [address=%s]", node.Address))
 					return nil, errors.New("unable to authenticate you as a delegate")
@@ -86,10 +89,12 @@ func (this *DisGoverService) PingSeedGrpc(ctx context.Context, pingSeed *proto.P
 			}
 		}
 	}
-	node.Set(txn, services.GetCache())
+	node.Registered = utils.ToMilliSeconds(this.clock.Now())
+	node.Set(txn, this.db.GetCache())
+	this.addPeer(node)
 
 	// Get cached delegates.
-	cDelegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	cDelegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
 	if err != nil {
 		return nil, err
 	}
@@ -123,248 +128,172 @@ func (this *DisGoverService) PingSeedGrpc(ctx context.Context, pingSeed *proto.P
 	utils.Info(fmt.Sprintf("received ping [address=%s, host=%s, port=%d, delegates=%d]", node.Address, node.GrpcEndpoint.Host, node.GrpcEndpoint.Port, len(delegates)))
 
 	// New authentication.
-	authentication, err = types.NewAuthentication()
+	authentication, err = types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
 	if err != nil {
 		utils.Error(err)
 		return nil, err
 	}
 
-	// Update all peers.
+	// Replicate to the other seeds and update all peers.
+	atomic.AddInt32(&this.working, 1)
 	go func() {
+		defer atomic.AddInt32(&this.working, -1)
+		this.peerReplicateGrpc([]*types.Node{node})
 		time.Sleep(500 * time.Millisecond)
 		this.peerUpdateGrpc()
 	}()
 
-	return &proto.Update{Authentication: convertToProtoAuthentication(authentication), Delegates: nodes}, nil
+	return &proto.Update{Authentication: convertToProtoAuthentication(authentication), Delegates: nodes, GenesisHash: this.ThisNode.GenesisHash}, nil
 }
 
-// peerPingSeedGrpc
+// peerPingSeedGrpc - Pings every seed and merges the delegates they answer with, fails only when no seed answers
 func (this *DisGoverService) peerPingSeedGrpc() ([]*types.Node, error) {
-
 	var delegates = make([]*types.Node, 0)
-	for _, seedEndpoint := range types.GetConfig().Seeds {
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", seedEndpoint.GrpcEndpoint.Host, seedEndpoint.GrpcEndpoint.Port), grpc.WithInsecure())
-		if err != nil {
-			utils.Fatal(fmt.Sprintf("cannot dial seed [host=%s, port=%d]", seedEndpoint.GrpcEndpoint.Host, seedEndpoint.GrpcEndpoint.Port), err)
-			return nil, err
-		}
-		client := proto.NewDisgoverGrpcClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-
-		// New authentication.
-		authentication, err := types.NewAuthentication()
-		if err != nil {
-			return nil, err
-		}
-
-		// Ping seed.
-		protoNode := convertToProtoNode(this.ThisNode)
-		response, err := client.PingSeedGrpc(ctx, &proto.PingSeed{Authentication: convertToProtoAuthentication(authentication), Node: protoNode})
-		if err != nil {
-			conn.Close()
-			cancel()
-			utils.Error(err)
-			return nil, err
-		}
-		conn.Close()
-		cancel()
-
-		// Response?
-		if response == nil {
-			utils.Error("unable to ping seed node")
-			return nil, errors.New("unable to ping seed node")
-		}
-
-		// Verify seed node is authentic?
-		err = this.verifySeedNode(response.Authentication)
+	var addresses = make(map[string]bool)
+	answered := 0
+	for _, seed := range this.config.Seeds {
+		seedDelegates, err := this.pingSeed(seed)
 		if err != nil {
-			return nil, err
+			utils.Warn(fmt.Sprintf("unable to ping seed node [host=%s, port=%d, error=%s]", seed.GrpcEndpoint.Host, seed.GrpcEndpoint.Port, err.Error()))
+			continue
 		}
-
-		utils.Info(fmt.Sprintf("pinged seed node [delegates=%d]", len(response.Delegates)))
-
-		for _, delegate := range response.Delegates {
-			delegates = append(delegates, convertToDomainNode(delegate))
+		answered++
+		for _, delegate := range seedDelegates {
+			if !addresses[delegate.Address] {
+				addresses[delegate.Address] = true
+				delegates = append(delegates, delegate)
+			}
 		}
-		return delegates, nil
 	}
-
-	return nil, errors.New("unable to ping any seed delegates")
+	if answered == 0 {
+		return nil, errors.New("unable to ping any seed nodes")
+	}
+	utils.Info(fmt.Sprintf("pinged seed nodes [seeds=%d, delegates=%d]", answered, len(delegates)))
+	return delegates, nil
 }
 
-// UpdateGrpc
-func (this *DisGoverService) UpdateGrpc(ctx context.Context, update *proto.Update) (*proto.Empty, error) {
+// pingSeed
+func (this *DisGoverService) pingSeed(seed *types.Node) ([]*types.Node, error) {
+	client, closeClient, err := this.transport.NewClient(seed)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	// Verify seed node is authentic?
-	err := this.verifySeedNode(update.Authentication)
+	// New authentication.
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
 	if err != nil {
-		return &proto.Empty{}, err
+		return nil, err
 	}
 
-	// Cache delegates.
-	for _, delegate := range update.Delegates {
-		convertToDomainNode(delegate).Cache(services.GetCache())
-		utils.Info(fmt.Sprintf("delegates updated [count=%d] %s : %s:%d", len(update.Delegates), delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port))
+	// Ping seed.
+	protoNode := convertToProtoNode(this.ThisNode)
+	response, err := client.PingSeedGrpc(ctx, &proto.PingSeed{Authentication: convertToProtoAuthentication(authentication), Node: protoNode})
+	if err != nil {
+		return nil, err
 	}
-	return &proto.Empty{}, nil
-}
 
-// peerUpdateGrpc
-func (this *DisGoverService) peerUpdateGrpc() {
+	// Response?
+	if response == nil {
+		return nil, errors.New("unable to ping seed node")
+	}
 
-	// Get delegates in cache.
-	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	// Verify seed node is authentic?
+	err = this.verifySeedNode(response.Authentication)
 	if err != nil {
-		utils.Error(err)
-		return
+		return nil, err
 	}
-	var protoDelegates = make([]*proto.Node, 0)
-	for _, delegate := range delegates {
-		protoDelegates = append(protoDelegates, convertToProtoNode(delegate))
+	if response.GenesisHash != this.ThisNode.GenesisHash {
+		return nil, errors.New(fmt.Sprintf("seed node has another genesis [hash=%s, expected=%s]", response.GenesisHash, this.ThisNode.GenesisHash))
 	}
 
-	// New authentication.
-	authentication, err := types.NewAuthentication()
-	if err != nil {
-		utils.Error(err)
-		return
+	var delegates = make([]*types.Node, 0)
+	for _, delegate := range response.Delegates {
+		delegates = append(delegates, convertToDomainNode(delegate))
 	}
+	return delegates, nil
+}
 
-	txn := services.NewTxn(true)
+// persistDelegates - Persists and caches the delegates so the node can boot from them when no seed answers, keeps the availability health checks recorded
+func (this *DisGoverService) persistDelegates(delegates []*types.Node) {
+	txn := this.db.NewTxn(true)
 	defer txn.Discard()
-
 	for _, delegate := range delegates {
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), grpc.WithInsecure())
-		if err != nil {
-			utils.Error(fmt.Sprintf("cannot dial node [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
-			err = delegate.Unset(txn, services.GetCache())
-			if err != nil {
-				utils.Error(err)
-			}
-			continue
+		if cached, err := types.ToNodeFromCache(this.db.GetCache(), delegate.Address); err == nil {
+			delegate.Status = cached.Status
+			delegate.StatusTime = cached.StatusTime
 		}
-		client := proto.NewDisgoverGrpcClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-
-		// Update.
-		_, err = client.UpdateGrpc(ctx, &proto.Update{Authentication: convertToProtoAuthentication(authentication), Delegates: protoDelegates})
+		err := delegate.Set(txn, this.db.GetCache())
 		if err != nil {
 			utils.Error(err)
 		}
-		conn.Close()
-		cancel()
+	}
+	err := txn.Commit(nil)
+	if err != nil {
+		utils.Error(err)
 	}
 }
 
-// UpdateSoftwareGrpc
-func (this *DisGoverService) UpdateSoftwareGrpc(ctx context.Context, softwareUpdate *proto.SoftwareUpdate) (*proto.Empty, error) {
+// UpdateGrpc
+func (this *DisGoverService) UpdateGrpc(ctx context.Context, update *proto.Update) (*proto.Empty, error) {
 
 	// Verify seed node is authentic?
-	err := this.verifySeedNode(softwareUpdate.Authentication)
+	err := this.verifySeedNode(update.Authentication)
 	if err != nil {
-		utils.Error(err)
 		return &proto.Empty{}, err
 	}
-
-	// Valid software?
-	err = this.verifySoftwareUpdate(softwareUpdate)
-	if err != nil {
-		utils.Error("unable to verify software update", err)
-		return &proto.Empty{}, err
+	if update.GenesisHash != this.ThisNode.GenesisHash {
+		return &proto.Empty{}, errors.New(fmt.Sprintf("seed node has another genesis [hash=%s, expected=%s]", update.GenesisHash, this.ThisNode.GenesisHash))
 	}
 
-	// Disgo software update?
-	if softwareUpdate.FileName == "disgo" {
-		fileName := "." + string(os.PathSeparator) + "disgo"
-		err = ioutil.WriteFile(fileName, softwareUpdate.Software, 0777)
-		if err != nil {
-			utils.Error(fmt.Sprintf("unable to save file %s", fileName), err)
-			return &proto.Empty{}, err
-		}
-		utils.Info(fmt.Sprintf("software updated from seed node [file=%s, scheduledReboot=%s]", fileName, softwareUpdate.ScheduledReboot))
-
-		// Schedule the reboot.
-		go func() {
-			gocron.Every(1).Day().At(softwareUpdate.ScheduledReboot).Do(func() {
-				gocron.Clear()
-				services.GetDbService().Close()
-				utils.Info("rebooting with new version of disgo...")
-				os.Exit(0)
-			})
-			<-gocron.Start()
-		}()
-	} else {
-		fileName := "." + string(os.PathSeparator) + "disgo-update"
-		err = ioutil.WriteFile(fileName, softwareUpdate.Software, 0777)
-		if err != nil {
-			utils.Error(fmt.Sprintf("unable to save file %s", fileName), err)
-			return &proto.Empty{}, err
-		}
-		utils.Info(fmt.Sprintf("software updated from seed node [file=%s]", fileName))
+	// Persist delegates.
+	delegates := make([]*types.Node, 0, len(update.Delegates))
+	for _, delegate := range update.Delegates {
+		delegates = append(delegates, convertToDomainNode(delegate))
+		utils.Info(fmt.Sprintf("delegates updated [count=%d] %s : %s:%d", len(update.Delegates), delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port))
 	}
-
+	this.persistDelegates(delegates)
 	return &proto.Empty{}, nil
 }
 
-// peerUpdateSoftwareGrpc
-func (this *DisGoverService) peerUpdateSoftwareGrpc(fileName string, software []byte) {
+// peerUpdateGrpc
+func (this *DisGoverService) peerUpdateGrpc() {
 
 	// Get delegates in cache.
-	delegates, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	delegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
 	if err != nil {
 		utils.Error(err)
 		return
 	}
-
-	// New authentication.
-	authentication, err := types.NewAuthentication()
-	if err != nil {
-		utils.Error(err)
-		return
+	var protoDelegates = make([]*proto.Node, 0)
+	for _, delegate := range delegates {
+		protoDelegates = append(protoDelegates, convertToProtoNode(delegate))
 	}
 
-	// Create hash and signature.
-	hashBytes := crypto.NewHash(software)
-	hash := hex.EncodeToString(hashBytes[:])
-
-	// Create signature of the hash.
-	privateKeyBytes, err := hex.DecodeString(types.GetAccount().PrivateKey)
-	if err != nil {
-		utils.Error(err)
-		return
-	}
-	signatureBytes, err := crypto.NewSignature(privateKeyBytes, hashBytes[:])
+	// New authentication.
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
 	if err != nil {
 		utils.Error(err)
 		return
 	}
-	signature := hex.EncodeToString(signatureBytes)
 
-	txn := services.NewTxn(true)
-	defer txn.Discard()
-	reboot := time.Now()
-	reboot = reboot.Add(2 * time.Minute)
 	for _, delegate := range delegates {
-		maxSize := 1024 * 1024 * 1024 // TODO: Do we need this (ServerOptions sets this)?
-		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxSize), grpc.MaxCallSendMsgSize(maxSize)), grpc.WithInsecure())
+		client, closeClient, err := this.transport.NewClient(delegate)
 		if err != nil {
 			utils.Error(fmt.Sprintf("cannot dial node [host=%s, port=%d]", delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
-			err = delegate.Unset(txn, services.GetCache())
-			if err != nil {
-				utils.Error(err)
-			}
+			this.recordCheck(delegate, types.HealthCheck{Time: this.clock.Now(), Ok: false, Error: err.Error()})
 			continue
 		}
-		client := proto.NewDisgoverGrpcClient(conn)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 
-		// Update software.
-		_, err = client.UpdateSoftwareGrpc(ctx, &proto.SoftwareUpdate{Authentication: convertToProtoAuthentication(authentication), Hash: hash, FileName: fileName, Software: software, Signature: signature, ScheduledReboot: reboot.Format("15:04")})
+		// Update.
+		_, err = client.UpdateGrpc(ctx, &proto.Update{Authentication: convertToProtoAuthentication(authentication), Delegates: protoDelegates, GenesisHash: this.ThisNode.GenesisHash})
 		if err != nil {
-			utils.Warn(fmt.Sprintf("unable to update sofware [address=%s, host=%s, port=%d]", delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
-			continue
+			utils.Error(err)
 		}
-		conn.Close()
+		closeClient()
 		cancel()
 	}
 }
@@ -384,9 +313,9 @@ func (this *DisGoverService) verifySeedNode(protoAuthenticate *proto.Authenticat
 		return err
 	}
 
-	for _, seedNode := range types.GetConfig().Seeds {
+	for _, seedNode := range this.config.Seeds {
 		if seedNode.Address == authenticationAddress {
-			err = authentication.Verify(services.GetCache(), seedNode.Address)
+			err = authentication.Verify(this.db.GetCache(), seedNode.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
 			if err != nil {
 				return errors.New(fmt.Sprintf("you are not an authorized seed node [err=%s]", err.Error()))
 			}
@@ -398,40 +327,6 @@ func (this *DisGoverService) verifySeedNode(protoAuthenticate *proto.Authenticat
 	return errors.New("you are not an authorized seed node")
 }
 
-// verifySoftwareUpdate
-func (this *DisGoverService) verifySoftwareUpdate(softwareUpdate *proto.SoftwareUpdate) error {
-
-	// Create hash.
-	hashBytes := crypto.NewHash(softwareUpdate.Software)
-	hash := hex.EncodeToString(hashBytes[:])
-
-	// Hash valid?
-	if hash != softwareUpdate.Hash {
-		return errors.New("invalid hash")
-	}
-
-	// Valid address?
-	signatureBytes, err := hex.DecodeString(softwareUpdate.Signature)
-	if err != nil {
-		return errors.New("unable to decode signature")
-	}
-	publicKeyBytes, err := crypto.ToPublicKey(hashBytes[:], signatureBytes)
-	if err != nil {
-		return errors.New("unable to generate public key from hash and signature")
-	}
-
-	// Is the computed address match a seed address?
-	computedAddress := hex.EncodeToString(crypto.ToAddress(publicKeyBytes))
-	for _, seedNode := range types.GetConfig().Seeds {
-		if seedNode.Address == computedAddress {
-			return nil
-		}
-	}
-
-	utils.Warn("attempted update software by a non-authorized seed node")
-	return errors.New("you are not an authorized seed node to update software")
-}
-
 /*
  *  Simple conversion functions from / to proto generated objects and domain level objects
  */
@@ -446,7 +341,10 @@ func convertToDomainNode(node *proto.Node) *types.Node {
 			Host: node.HttpEndpoint.Host,
 			Port: node.HttpEndpoint.Port,
 		},
-		Type: node.Type,
+		Type:        node.Type,
+		GenesisHash: node.GenesisHash,
+		NetworkId:   node.NetworkId,
+		Registered:  node.Registered,
 	}
 }
 
@@ -465,7 +363,10 @@ func convertToProtoNode(node *types.Node) *proto.Node {
 			Host: node.HttpEndpoint.Host,
 			Port: node.HttpEndpoint.Port,
 		},
-		Type: node.Type,
+		Type:        node.Type,
+		GenesisHash: node.GenesisHash,
+		NetworkId:   node.NetworkId,
+		Registered:  node.Registered,
 	}
 }
 
@@ -475,6 +376,7 @@ func convertToDomainAuthentication(authentication *proto.Authentication) *types.
 		Hash:      authentication.Hash,
 		Time:      authentication.Time,
 		Signature: authentication.Signature,
+		NetworkId: authentication.NetworkId,
 	}
 }
 
@@ -487,5 +389,63 @@ func convertToProtoAuthentication(authentication *types.Authentication) *proto.A
 		Hash:      authentication.Hash,
 		Time:      authentication.Time,
 		Signature: authentication.Signature,
+		NetworkId: authentication.NetworkId,
 	}
-}
\ No newline at end of file
+}
+func convertToDomainManifest(manifest *proto.Manifest) *types.Manifest {
+	return &types.Manifest{
+		Version:        manifest.Version,
+		Platform:       manifest.Platform,
+		FileName:       manifest.FileName,
+		SoftwareHash:   manifest.SoftwareHash,
+		MinimumVersion: manifest.MinimumVersion,
+		Size:           manifest.Size,
+		ChunkSize:      manifest.ChunkSize,
+		ChunkHashes:    append([]string{}, manifest.ChunkHashes...),
+		Time:           manifest.Time,
+		Hash:           manifest.Hash,
+		Signature:      manifest.Signature,
+	}
+}
+
+func convertToProtoManifest(manifest *types.Manifest) *proto.Manifest {
+	return &proto.Manifest{
+		Version:        manifest.Version,
+		Platform:       manifest.Platform,
+		FileName:       manifest.FileName,
+		SoftwareHash:   manifest.SoftwareHash,
+		MinimumVersion: manifest.MinimumVersion,
+		Size:           manifest.Size,
+		ChunkSize:      manifest.ChunkSize,
+		ChunkHashes:    append([]string{}, manifest.ChunkHashes...),
+		Time:           manifest.Time,
+		Hash:           manifest.Hash,
+		Signature:      manifest.Signature,
+	}
+}
+
+func convertToDomainUpdateAck(ack *proto.UpdateAck) *types.UpdateAck {
+	return &types.UpdateAck{
+		ManifestHash: ack.ManifestHash,
+		Address:      ack.Address,
+		Status:       ack.Status,
+		Version:      ack.Version,
+		Error:        ack.Error,
+		Time:         ack.Time,
+		Hash:         ack.Hash,
+		Signature:    ack.Signature,
+	}
+}
+
+func convertToProtoUpdateAck(ack *types.UpdateAck) *proto.UpdateAck {
+	return &proto.UpdateAck{
+		ManifestHash: ack.ManifestHash,
+		Address:      ack.Address,
+		Status:       ack.Status,
+		Version:      ack.Version,
+		Error:        ack.Error,
+		Time:         ack.Time,
+		Hash:         ack.Hash,
+		Signature:    ack.Signature,
+	}
+}
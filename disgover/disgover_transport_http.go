@@ -19,15 +19,75 @@ package disgover
 import (
 	"fmt"
 	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/gorilla/mux"
 	"io/ioutil"
 	"net/http"
 )
 
 func (this *DisGoverService) WithHttp() *DisGoverService {
 	services.GetHttpRouter().HandleFunc("/v1/ping", this.pingPongHandler).Methods("POST")
+	services.GetHttpRouter().HandleFunc("/v1/peers", this.getPeersHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/peers/{address}", this.getPeerHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/health", this.getHealthHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/health/{address}", this.getNodeHealthHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/rollouts", this.getRolloutsHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/rollouts/{hash}", this.getRolloutHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/transfers", this.getTransfersHandler).Methods("GET")
 	return this
 }
 
+// getPeersHandler
+func (this *DisGoverService) getPeersHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetPeers()
+	responseWriter.Header().Set("content-type", "application/json")
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getPeerHandler
+func (this *DisGoverService) getPeerHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetPeer(vars["address"])
+	responseWriter.Header().Set("content-type", "application/json")
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getHealthHandler
+func (this *DisGoverService) getHealthHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetHealth()
+	responseWriter.Header().Set("content-type", "application/json")
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getNodeHealthHandler
+func (this *DisGoverService) getNodeHealthHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetNodeHealth(vars["address"])
+	responseWriter.Header().Set("content-type", "application/json")
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getRolloutsHandler
+func (this *DisGoverService) getRolloutsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetRollouts()
+	responseWriter.Header().Set("content-type", "application/json")
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getRolloutHandler
+func (this *DisGoverService) getRolloutHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetRollout(vars["hash"])
+	responseWriter.Header().Set("content-type", "application/json")
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getTransfersHandler
+func (this *DisGoverService) getTransfersHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetTransfers()
+	responseWriter.Header().Set("content-type", "application/json")
+	responseWriter.Write([]byte(response.String()))
+}
+
 func (this *DisGoverService) pingPongHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	body, _ := ioutil.ReadAll(request.Body)
 
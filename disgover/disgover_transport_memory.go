@@ -0,0 +1,235 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"io"
+
+	"github.com/dispatchlabs/disgo/commons/transport"
+	"github.com/dispatchlabs/disgo/commons/types"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	protobuf "github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const memoryService = "disgover"
+
+// NewMemoryTransport - In process transport over a shared network, address is this node's address
+func NewMemoryTransport(network *transport.Network, address string) DisGoverTransport {
+	return &memoryTransport{network: network, address: address}
+}
+
+// memoryTransport
+type memoryTransport struct {
+	network *transport.Network
+	address string
+}
+
+// Serve
+func (this *memoryTransport) Serve(server proto.DisgoverGrpcServer) {
+	this.network.Register(memoryService, this.address, server)
+}
+
+// NewClient
+func (this *memoryTransport) NewClient(node *types.Node) (proto.DisgoverGrpcClient, func(), error) {
+	return &memoryClient{network: this.network, from: this.address, to: node.Address}, func() {}, nil
+}
+
+// memoryClient - Messages are copied on the way in and out so peers never share memory
+type memoryClient struct {
+	network *transport.Network
+	from    string
+	to      string
+}
+
+// call
+func (this *memoryClient) call(ctx context.Context, in protobuf.Message, invoke func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error)) (protobuf.Message, error) {
+	var out protobuf.Message
+	err := this.network.Call(ctx, memoryService, this.from, this.to, func(server interface{}) error {
+		var err error
+		out, err = invoke(server.(proto.DisgoverGrpcServer), protobuf.Clone(in))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return protobuf.Clone(out), nil
+}
+
+// PingSeedGrpc
+func (this *memoryClient) PingSeedGrpc(ctx context.Context, in *proto.PingSeed, opts ...grpc.CallOption) (*proto.Update, error) {
+	out, err := this.call(ctx, in, func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.PingSeedGrpc(ctx, in.(*proto.PingSeed))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Update), nil
+}
+
+// UpdateGrpc
+func (this *memoryClient) UpdateGrpc(ctx context.Context, in *proto.Update, opts ...grpc.CallOption) (*proto.Empty, error) {
+	out, err := this.call(ctx, in, func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.UpdateGrpc(ctx, in.(*proto.Update))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Empty), nil
+}
+
+// UpdateSoftwareGrpc
+func (this *memoryClient) UpdateSoftwareGrpc(ctx context.Context, in *proto.SoftwareUpdate, opts ...grpc.CallOption) (*proto.Empty, error) {
+	out, err := this.call(ctx, in, func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.UpdateSoftwareGrpc(ctx, in.(*proto.SoftwareUpdate))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Empty), nil
+}
+
+// ReleaseGrpc
+func (this *memoryClient) ReleaseGrpc(ctx context.Context, in *proto.Release, opts ...grpc.CallOption) (*proto.UpdateAck, error) {
+	out, err := this.call(ctx, in, func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.ReleaseGrpc(ctx, in.(*proto.Release))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.UpdateAck), nil
+}
+
+// DownloadGrpc - The server streams on its own goroutine, every chunk crosses the network on its own
+func (this *memoryClient) DownloadGrpc(ctx context.Context, in *proto.Download, opts ...grpc.CallOption) (proto.DisgoverGrpc_DownloadGrpcClient, error) {
+	server, err := this.network.Lookup(ctx, memoryService, this.from, this.to)
+	if err != nil {
+		return nil, err
+	}
+	stream := &memoryDownloadStream{ctx: ctx, chunks: make(chan *proto.Chunk)}
+	send := func(chunk *proto.Chunk) error {
+		err := this.network.Transit(ctx, this.to, this.from)
+		if err != nil {
+			return err
+		}
+		select {
+		case stream.chunks <- protobuf.Clone(chunk).(*proto.Chunk):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	go func() {
+		stream.err = server.(proto.DisgoverGrpcServer).DownloadGrpc(protobuf.Clone(in).(*proto.Download), &memoryDownloadServer{ctx: ctx, send: send})
+		close(stream.chunks)
+	}()
+	return stream, nil
+}
+
+// memoryDownloadStream - Client side of a download, the server's error is set before the chunks are closed
+type memoryDownloadStream struct {
+	ctx    context.Context
+	chunks chan *proto.Chunk
+	err    error
+}
+
+// Recv
+func (this *memoryDownloadStream) Recv() (*proto.Chunk, error) {
+	select {
+	case chunk, ok := <-this.chunks:
+		if ok {
+			return chunk, nil
+		}
+		if this.err != nil {
+			return nil, this.err
+		}
+		return nil, io.EOF
+	case <-this.ctx.Done():
+		return nil, this.ctx.Err()
+	}
+}
+
+func (this *memoryDownloadStream) Header() (metadata.MD, error) { return nil, nil }
+func (this *memoryDownloadStream) Trailer() metadata.MD         { return nil }
+func (this *memoryDownloadStream) CloseSend() error             { return nil }
+func (this *memoryDownloadStream) Context() context.Context     { return this.ctx }
+func (this *memoryDownloadStream) SendMsg(m interface{}) error  { return nil }
+func (this *memoryDownloadStream) RecvMsg(m interface{}) error  { return nil }
+
+// memoryDownloadServer - Server side of a download
+type memoryDownloadServer struct {
+	ctx  context.Context
+	send func(chunk *proto.Chunk) error
+}
+
+// Send
+func (this *memoryDownloadServer) Send(chunk *proto.Chunk) error {
+	return this.send(chunk)
+}
+
+func (this *memoryDownloadServer) SetHeader(metadata.MD) error  { return nil }
+func (this *memoryDownloadServer) SendHeader(metadata.MD) error { return nil }
+func (this *memoryDownloadServer) SetTrailer(metadata.MD)       {}
+func (this *memoryDownloadServer) Context() context.Context     { return this.ctx }
+func (this *memoryDownloadServer) SendMsg(m interface{}) error  { return nil }
+func (this *memoryDownloadServer) RecvMsg(m interface{}) error  { return nil }
+
+// AckUpdateGrpc
+func (this *memoryClient) AckUpdateGrpc(ctx context.Context, in *proto.UpdateAck, opts ...grpc.CallOption) (*proto.Empty, error) {
+	out, err := this.call(ctx, in, func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.AckUpdateGrpc(ctx, in.(*proto.UpdateAck))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Empty), nil
+}
+
+// FindNodeGrpc
+func (this *memoryClient) FindNodeGrpc(ctx context.Context, in *proto.FindNode, opts ...grpc.CallOption) (*proto.Nodes, error) {
+	out, err := this.call(ctx, in, func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.FindNodeGrpc(ctx, in.(*proto.FindNode))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Nodes), nil
+}
+
+// ReplicateGrpc
+func (this *memoryClient) ReplicateGrpc(ctx context.Context, in *proto.Replicate, opts ...grpc.CallOption) (*proto.Replicate, error) {
+	out, err := this.call(ctx, in, func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.ReplicateGrpc(ctx, in.(*proto.Replicate))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Replicate), nil
+}
+
+// PingGrpc
+func (this *memoryClient) PingGrpc(ctx context.Context, in *proto.Ping, opts ...grpc.CallOption) (*proto.Ping, error) {
+	out, err := this.call(ctx, in, func(server proto.DisgoverGrpcServer, in protobuf.Message) (protobuf.Message, error) {
+		return server.PingGrpc(ctx, in.(*proto.Ping))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*proto.Ping), nil
+}
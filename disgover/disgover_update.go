@@ -0,0 +1,796 @@
+/*
+ *    This file is part of Disgover library.
+ *
+ *    The Disgover library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgover library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgover library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package disgover
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	proto "github.com/dispatchlabs/disgo/disgover/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// updateMarker - Written when disgo is installed, read on every boot until the release is healthy or rolled back
+type updateMarker struct {
+	Manifest   types.Manifest `json:"manifest"`
+	Attempts   int            `json:"attempts"`             // Boots into the release
+	RolledBack string         `json:"rolledBack,omitempty"` // Why CountBoot rolled it back, acknowledged by the restored disgo
+}
+
+// release - Dropped by an operator in the seed's update directory next to the software
+type release struct {
+	Version        string `json:"version"`
+	Platform       string `json:"platform"` // This seed's platform when empty
+	MinimumVersion string `json:"minimumVersion"`
+	FileName       string `json:"fileName"`
+}
+
+// WithSoftwareDirectory - Installs disgo and update artifacts in the directory, the working directory by default
+func (this *DisGoverService) WithSoftwareDirectory(directory string) *DisGoverService {
+	this.softwareDirectory = directory
+	return this
+}
+
+// WithReboot - Called to boot into an installed or restored disgo, by default the process exits for its supervisor to
+// start it again
+func (this *DisGoverService) WithReboot(reboot func()) *DisGoverService {
+	this.reboot = reboot
+	return this
+}
+
+// Rollouts - Started by this seed, oldest first
+func (this *DisGoverService) Rollouts() []*types.Rollout {
+	this.rolloutsMutex.RLock()
+	defer this.rolloutsMutex.RUnlock()
+	rollouts := make([]*types.Rollout, 0, len(this.rollouts))
+	for _, rollout := range this.rollouts {
+		rollouts = append(rollouts, copyRollout(rollout))
+	}
+	sort.Slice(rollouts, func(i, j int) bool { return rollouts[i].Manifest.Time < rollouts[j].Manifest.Time })
+	return rollouts
+}
+
+// Rollout - By manifest hash, ErrNotFound when this seed never started it
+func (this *DisGoverService) Rollout(hash string) (*types.Rollout, error) {
+	this.rolloutsMutex.RLock()
+	defer this.rolloutsMutex.RUnlock()
+	rollout, ok := this.rollouts[hash]
+	if !ok {
+		return nil, types.ErrNotFound
+	}
+	return copyRollout(rollout), nil
+}
+
+// RollOut - Rolls the release signed by this seed out to the delegates stage by stage, returns once it started
+func (this *DisGoverService) RollOut(manifest *types.Manifest, software []byte) (*types.Rollout, error) {
+	if this.ThisNode.Type != types.TypeSeed {
+		return nil, errors.New("only a seed rolls out releases")
+	}
+	signer, err := manifest.Signer()
+	if err != nil {
+		return nil, err
+	}
+	if signer != this.account.Address {
+		return nil, errors.New("manifest is not signed by this seed")
+	}
+	err = manifest.Verify(software)
+	if err != nil {
+		return nil, err
+	}
+	err = this.saveArtifact(manifest, software)
+	if err != nil {
+		return nil, err
+	}
+	stages := this.config.UpdateStages
+	if len(stages) == 0 {
+		stages = []int{100}
+	}
+
+	this.rolloutsMutex.Lock()
+	defer this.rolloutsMutex.Unlock()
+	if _, ok := this.rollouts[manifest.Hash]; ok {
+		return nil, errors.New(fmt.Sprintf("release is already rolling out [hash=%s]", manifest.Hash))
+	}
+	rollout := &types.Rollout{Manifest: *manifest, Stages: stages, Status: types.RolloutInProgress, Acks: map[string]types.UpdateAck{}}
+	this.rollouts[manifest.Hash] = rollout
+	signal := make(chan bool, 1)
+	this.rolloutSignals[manifest.Hash] = signal
+	utils.Info(fmt.Sprintf("rolling out release [version=%s, platform=%s, file=%s, stages=%v]", manifest.Version, manifest.Platform, manifest.FileName, stages))
+	go this.rollOut(*manifest, stages, signal)
+	return copyRollout(rollout), nil
+}
+
+// rollOut - Announces the release to every delegate of a stage, the next stage starts once they all came back healthy.
+// The delegates of the stages before share the software with the stage, the seed is the last source
+func (this *DisGoverService) rollOut(manifest types.Manifest, stages []int, signal chan bool) {
+	delegates, err := types.ToNodesByTypeFromCache(this.db.GetCache(), types.TypeDelegate)
+	if err != nil {
+		this.endRollout(manifest.Hash, types.RolloutHalted, err.Error())
+		return
+	}
+	sort.Slice(delegates, func(i, j int) bool { return delegates[i].Address < delegates[j].Address })
+
+	rolled := 0
+	for i, percent := range stages {
+		size := types.StageSize(percent, len(delegates))
+		if size <= rolled {
+			continue
+		}
+		stage := delegates[rolled:size]
+		this.updateRollout(manifest.Hash, func(rollout *types.Rollout) { rollout.Stage = i })
+		utils.Info(fmt.Sprintf("rolling out stage [version=%s, stage=%d, percent=%d, delegates=%d]", manifest.Version, i, percent, len(stage)))
+		for _, delegate := range stage {
+			sources := append([]*types.Node{}, delegates[:rolled]...)
+			rand.Shuffle(len(sources), func(i, j int) { sources[i], sources[j] = sources[j], sources[i] })
+			sources = append(sources, this.ThisNode)
+			ack, err := this.peerReleaseGrpc(delegate, &manifest, sources)
+			if err != nil {
+				utils.Warn(fmt.Sprintf("unable to update software [address=%s, host=%s, port=%d]", delegate.Address, delegate.GrpcEndpoint.Host, delegate.GrpcEndpoint.Port), err)
+				ack = &types.UpdateAck{ManifestHash: manifest.Hash, Address: delegate.Address, Status: types.UpdateFailed, Error: err.Error(), Time: utils.ToMilliSeconds(this.clock.Now())}
+			}
+			this.recordAck(ack)
+		}
+		reason := this.waitForStage(manifest, stage, signal)
+		if reason != "" {
+			this.endRollout(manifest.Hash, types.RolloutHalted, reason)
+			return
+		}
+		rolled = size
+	}
+	this.endRollout(manifest.Hash, types.RolloutCompleted, "")
+}
+
+// waitForStage - Waits for every delegate of the stage to acknowledge it is healthy then checks they all still answer,
+// returns why the rollout halts otherwise
+func (this *DisGoverService) waitForStage(manifest types.Manifest, stage []*types.Node, signal chan bool) string {
+	timeout := types.DefaultUpdateStageTimeout
+	if this.config.UpdateStageTimeout > 0 {
+		timeout = time.Duration(this.config.UpdateStageTimeout) * time.Millisecond
+	}
+	expired := this.clock.After(timeout)
+	for {
+		rollout, err := this.Rollout(manifest.Hash)
+		if err != nil {
+			return err.Error()
+		}
+		pending := 0
+		for _, delegate := range stage {
+			ack, ok := rollout.Acks[delegate.Address]
+			switch {
+			case !ok, ack.Status == types.UpdateDownloading:
+				pending++
+			case ack.Status == types.UpdateHealthy:
+			case ack.Status == types.UpdatePushed:
+
+				// Only the health checks below tell whether it came back.
+			case ack.Status == types.UpdateInstalled:
+
+				// Only disgo reboots, other artifacts are done once saved.
+				if manifest.Installs() {
+					pending++
+				}
+			default:
+				return fmt.Sprintf("delegate did not update [address=%s, status=%s, error=%s]", delegate.Address, ack.Status, ack.Error)
+			}
+		}
+		if pending == 0 {
+			break
+		}
+		select {
+		case <-signal:
+		case <-expired:
+			return fmt.Sprintf("timed out waiting for %d delegates to come back healthy", pending)
+		}
+	}
+
+	// Does the stage still answer?
+	this.CheckHealth()
+	for _, delegate := range stage {
+		health, err := this.NodeHealth(delegate.Address)
+		if err != nil || !health.Available {
+			return fmt.Sprintf("delegate is unavailable after updating [address=%s]", delegate.Address)
+		}
+	}
+	return ""
+}
+
+// updateRollout
+func (this *DisGoverService) updateRollout(hash string, update func(rollout *types.Rollout)) {
+	this.rolloutsMutex.Lock()
+	defer this.rolloutsMutex.Unlock()
+	if rollout, ok := this.rollouts[hash]; ok {
+		update(rollout)
+	}
+}
+
+// endRollout
+func (this *DisGoverService) endRollout(hash, status, reason string) {
+	this.updateRollout(hash, func(rollout *types.Rollout) {
+		rollout.Status = status
+		rollout.Reason = reason
+	})
+	if status == types.RolloutHalted {
+		utils.Warn(fmt.Sprintf("rollout halted [hash=%s, reason=%s]", hash, reason))
+		return
+	}
+	utils.Info(fmt.Sprintf("rollout completed [hash=%s]", hash))
+}
+
+// recordAck - Records the acknowledgement with the rollout of its release, false when this seed is not rolling it out
+func (this *DisGoverService) recordAck(ack *types.UpdateAck) bool {
+	this.rolloutsMutex.Lock()
+	defer this.rolloutsMutex.Unlock()
+	rollout, ok := this.rollouts[ack.ManifestHash]
+	if !ok {
+		return false
+	}
+
+	// Acknowledgements can arrive out of order, a delegate never goes back to an earlier step.
+	if previous, ok := rollout.Acks[ack.Address]; ok && updateStep(ack.Status) > 0 && updateStep(ack.Status) < updateStep(previous.Status) {
+		return true
+	}
+	rollout.Acks[ack.Address] = *ack
+	select {
+	case this.rolloutSignals[ack.ManifestHash] <- true:
+	default:
+	}
+	return true
+}
+
+// ReleaseGrpc - Accepts a release signed by a seed and downloads its software from the sources in the background,
+// acknowledges whether it is downloading or rejected
+func (this *DisGoverService) ReleaseGrpc(ctx context.Context, release *proto.Release) (*proto.UpdateAck, error) {
+
+	// Verify seed node is authentic?
+	err := this.verifySeedNode(release.Authentication)
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+	if release.Manifest == nil {
+		return nil, errors.New("missing manifest")
+	}
+	manifest := convertToDomainManifest(release.Manifest)
+	sources := make([]*types.Node, 0, len(release.Sources))
+	for _, source := range release.Sources {
+		if isValidProtoNode(source) {
+			sources = append(sources, convertToDomainNode(source))
+		}
+	}
+
+	// Valid release?
+	err = this.verifyRelease(manifest)
+	if err != nil {
+		utils.Warn(fmt.Sprintf("rejected software update [version=%s, hash=%s]", manifest.Version, manifest.Hash), err)
+		return this.newProtoUpdateAck(manifest.Hash, types.UpdateRejected, err)
+	}
+	if this.startTransfer(manifest) {
+		utils.Info(fmt.Sprintf("downloading software update [version=%s, file=%s, chunks=%d, sources=%d]", manifest.Version, manifest.FileName, manifest.Chunks(), len(sources)))
+		go this.update(*manifest, sources)
+	}
+	return this.newProtoUpdateAck(manifest.Hash, types.UpdateDownloading, nil)
+}
+
+// update - Downloads and installs the release, acknowledges how it went to the seeds
+func (this *DisGoverService) update(manifest types.Manifest, sources []*types.Node) {
+	software, err := this.download(manifest, sources)
+	if err != nil {
+		utils.Error(fmt.Sprintf("unable to download software update [file=%s]", manifest.FileName), err)
+		this.peerAckUpdateGrpc(manifest.Hash, types.UpdateFailed, err)
+		return
+	}
+	err = this.install(&manifest, software)
+	if err != nil {
+		utils.Error(fmt.Sprintf("unable to install software update [file=%s]", manifest.FileName), err)
+		this.peerAckUpdateGrpc(manifest.Hash, types.UpdateFailed, err)
+		return
+	}
+	utils.Info(fmt.Sprintf("software updated [version=%s, file=%s]", manifest.Version, manifest.FileName))
+
+	// Reboot into the release, the delay runs from before the acknowledgement.
+	if !manifest.Installs() {
+		this.peerAckUpdateGrpc(manifest.Hash, types.UpdateInstalled, nil)
+		return
+	}
+	reboot := this.clock.After(types.UpdateRebootDelay)
+	this.peerAckUpdateGrpc(manifest.Hash, types.UpdateInstalled, nil)
+	<-reboot
+	utils.Info("rebooting with new version of disgo...")
+	this.reboot()
+}
+
+// peerReleaseGrpc - Announces the release to the delegate, returns its authenticated acknowledgement. The software is
+// pushed whole to a delegate without ReleaseGrpc.
+func (this *DisGoverService) peerReleaseGrpc(delegate *types.Node, manifest *types.Manifest, sources []*types.Node) (*types.UpdateAck, error) {
+	client, closeClient, err := this.transport.NewClient(delegate)
+	if err != nil {
+		this.recordCheck(delegate, types.HealthCheck{Time: this.clock.Now(), Ok: false, Error: err.Error()})
+		return nil, err
+	}
+	defer closeClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	protoSources := make([]*proto.Node, 0, len(sources))
+	for _, source := range sources {
+		protoSources = append(protoSources, convertToProtoNode(source))
+	}
+	protoAck, err := client.ReleaseGrpc(ctx, &proto.Release{Authentication: convertToProtoAuthentication(authentication), Manifest: convertToProtoManifest(manifest), Sources: protoSources})
+	if status.Code(err) == codes.Unimplemented {
+		return this.peerUpdateSoftwareGrpc(client, delegate, manifest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if protoAck.Authentication == nil {
+		return nil, errors.New("unable to authenticate delegate")
+	}
+	err = convertToDomainAuthentication(protoAck.Authentication).Verify(this.db.GetCache(), delegate.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	ack := convertToDomainUpdateAck(protoAck)
+	if ack.Address != delegate.Address || ack.ManifestHash != manifest.Hash {
+		return nil, errors.New("acknowledgement is not for this release")
+	}
+	err = ack.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return ack, nil
+}
+
+// peerUpdateSoftwareGrpc - Pushes the software whole to a delegate without ReleaseGrpc, signed the way it verifies. It
+// reboots at the scheduled minute and cannot acknowledge anything.
+func (this *DisGoverService) peerUpdateSoftwareGrpc(client proto.DisgoverGrpcClient, delegate *types.Node, manifest *types.Manifest) (*types.UpdateAck, error) {
+	software, err := ioutil.ReadFile(this.artifactFileName(manifest.SoftwareHash))
+	if err != nil {
+		return nil, err
+	}
+	hash := crypto.NewHash(software)
+	privateKeyBytes, err := hex.DecodeString(this.account.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.NewSignature(privateKeyBytes, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	reboot := this.clock.Now().Add(types.UpdatePushRebootDelay)
+	_, err = client.UpdateSoftwareGrpc(ctx, &proto.SoftwareUpdate{
+		Authentication:  convertToProtoAuthentication(authentication),
+		Hash:            hex.EncodeToString(hash[:]),
+		FileName:        manifest.FileName,
+		Software:        software,
+		Signature:       hex.EncodeToString(signature),
+		ScheduledReboot: reboot.Format("15:04"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	utils.Info(fmt.Sprintf("pushed software to delegate without ReleaseGrpc [address=%s, version=%s]", delegate.Address, manifest.Version))
+	return &types.UpdateAck{ManifestHash: manifest.Hash, Address: delegate.Address, Status: types.UpdatePushed, Time: utils.ToMilliSeconds(this.clock.Now())}, nil
+}
+
+// UpdateSoftwareGrpc - Installs software a seed without ReleaseGrpc pushed whole, signed by the seed
+func (this *DisGoverService) UpdateSoftwareGrpc(ctx context.Context, softwareUpdate *proto.SoftwareUpdate) (*proto.Empty, error) {
+
+	// Verify seed node is authentic?
+	err := this.verifySeedNode(softwareUpdate.Authentication)
+	if err != nil {
+		utils.Error(err)
+		return nil, err
+	}
+
+	// Valid software?
+	hash := crypto.NewHash(softwareUpdate.Software)
+	if hex.EncodeToString(hash[:]) != softwareUpdate.Hash {
+		return nil, errors.New("invalid hash")
+	}
+	signatureBytes, err := hex.DecodeString(softwareUpdate.Signature)
+	if err != nil {
+		return nil, errors.New("unable to decode signature")
+	}
+	publicKeyBytes, err := crypto.ToPublicKey(hash[:], signatureBytes)
+	if err != nil {
+		return nil, errors.New("unable to generate public key from hash and signature")
+	}
+	if !this.isSeed(hex.EncodeToString(crypto.ToAddress(publicKeyBytes))) {
+		return nil, errors.New("software is not signed by a seed")
+	}
+
+	manifest := &types.Manifest{FileName: filepath.Base(softwareUpdate.FileName), SoftwareHash: softwareUpdate.Hash, Hash: softwareUpdate.Hash}
+	if manifest.Installs() {
+		marker, err := readMarker(this.softwareDirectory)
+		if err != nil {
+			return nil, err
+		}
+		if marker != nil {
+			return nil, errors.New(fmt.Sprintf("another release is being installed [version=%s]", marker.Manifest.Version))
+		}
+	}
+	err = this.install(manifest, softwareUpdate.Software)
+	if err != nil {
+		utils.Error(fmt.Sprintf("unable to install software update [file=%s]", manifest.FileName), err)
+		return nil, err
+	}
+	utils.Info(fmt.Sprintf("software updated from seed node [file=%s]", manifest.FileName))
+	if manifest.Installs() {
+		reboot := this.clock.After(types.UpdateRebootDelay)
+		go func() {
+			<-reboot
+			utils.Info("rebooting with new version of disgo...")
+			this.reboot()
+		}()
+	}
+	return &proto.Empty{}, nil
+}
+
+// AckUpdateGrpc - Records a delegate's acknowledgement of a release this seed rolls out, the acknowledgement must be
+// signed by the delegate it is about
+func (this *DisGoverService) AckUpdateGrpc(ctx context.Context, protoAck *proto.UpdateAck) (*proto.Empty, error) {
+	if protoAck.Authentication == nil {
+		return nil, errors.New("invalid acknowledgement")
+	}
+	err := convertToDomainAuthentication(protoAck.Authentication).Verify(this.db.GetCache(), protoAck.Address, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to authenticate you [error=%s]", err.Error()))
+	}
+	ack := convertToDomainUpdateAck(protoAck)
+	err = ack.Verify()
+	if err != nil {
+		return nil, err
+	}
+	if this.recordAck(ack) {
+		utils.Info(fmt.Sprintf("update acknowledged [address=%s, status=%s, version=%s]", ack.Address, ack.Status, ack.Version))
+	}
+	return &proto.Empty{}, nil
+}
+
+// peerAckUpdateGrpc - Tells every seed how the release went, only the seed rolling it out records it
+func (this *DisGoverService) peerAckUpdateGrpc(manifestHash, status string, ackErr error) {
+	protoAck, err := this.newProtoUpdateAck(manifestHash, status, ackErr)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	for _, seed := range this.config.Seeds {
+		if seed.Address == this.ThisNode.Address {
+			continue
+		}
+		client, closeClient, err := this.transport.NewClient(seed)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("cannot dial seed [host=%s, port=%d]", seed.GrpcEndpoint.Host, seed.GrpcEndpoint.Port), err)
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err = client.AckUpdateGrpc(ctx, protoAck)
+		closeClient()
+		cancel()
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to acknowledge update [address=%s]", seed.Address), err)
+		}
+	}
+}
+
+// newProtoUpdateAck - Acknowledgement signed and authenticated by this node, with the version it runs
+func (this *DisGoverService) newProtoUpdateAck(manifestHash, status string, ackErr error) (*proto.UpdateAck, error) {
+	authentication, err := types.NewAuthenticationWithAccount(this.account, this.ThisNode.NetworkId, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	ack := &types.UpdateAck{ManifestHash: manifestHash, Address: this.ThisNode.Address, Status: status, Version: types.Version, Time: utils.ToMilliSeconds(this.clock.Now())}
+	if ackErr != nil {
+		ack.Error = ackErr.Error()
+	}
+	err = ack.Sign(this.account.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	protoAck := convertToProtoUpdateAck(ack)
+	protoAck.Authentication = convertToProtoAuthentication(authentication)
+	return protoAck, nil
+}
+
+// verifyRelease - The release is signed by a seed and applies to this node, its software is verified once downloaded
+func (this *DisGoverService) verifyRelease(manifest *types.Manifest) error {
+	signer, err := manifest.Signer()
+	if err != nil {
+		return err
+	}
+	if !this.isSeed(signer) {
+		return errors.New("manifest is not signed by a seed")
+	}
+	err = manifest.Applies(types.Version, types.Platform())
+	if err != nil {
+		return err
+	}
+	err = manifest.VerifyChunks()
+	if err != nil {
+		return err
+	}
+	if manifest.Installs() {
+		marker, err := readMarker(this.softwareDirectory)
+		if err != nil {
+			return err
+		}
+		if marker != nil {
+			return errors.New(fmt.Sprintf("another release is being installed [version=%s]", marker.Manifest.Version))
+		}
+	}
+	return nil
+}
+
+// install - Saves the software, disgo is swapped in keeping the running binary to roll back to
+func (this *DisGoverService) install(manifest *types.Manifest, software []byte) error {
+	if !manifest.Installs() {
+		return ioutil.WriteFile(filepath.Join(this.softwareDirectory, filepath.Base(manifest.FileName)), software, 0755)
+	}
+
+	binary := filepath.Join(this.softwareDirectory, "disgo")
+	err := ioutil.WriteFile(binary+".new", software, 0755)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(binary, binary+".previous")
+	if err != nil && !os.IsNotExist(err) {
+		os.Remove(binary + ".new")
+		return err
+	}
+	err = os.Rename(binary+".new", binary)
+	if err != nil {
+		os.Rename(binary+".previous", binary)
+		return err
+	}
+	return writeMarker(this.softwareDirectory, &updateMarker{Manifest: *manifest})
+}
+
+// CountBoot - Counts a boot into the release installed in the directory, called first thing in main so a release that
+// fails before DisGover runs still counts. Restores the disgo that ran before once the release booted too many times,
+// true when the process must exit for its supervisor to start the restored disgo.
+func CountBoot(directory string) (bool, error) {
+	marker, err := readMarker(directory)
+	if err != nil || marker == nil || marker.RolledBack != "" {
+		return false, err
+	}
+	marker.Attempts++
+	if marker.Attempts > types.UpdateBootAttempts {
+		reason := fmt.Sprintf("failed to boot %d times", types.UpdateBootAttempts)
+		utils.Warn(fmt.Sprintf("rolling back release [version=%s, reason=%s]", marker.Manifest.Version, reason))
+		binary := filepath.Join(directory, "disgo")
+		err = os.Rename(binary+".previous", binary)
+		if err != nil {
+			os.Remove(markerFileName(directory))
+			return false, errors.Wrap(err, "unable to restore previous disgo")
+		}
+		marker.RolledBack = reason
+		return true, writeMarker(directory, marker)
+	}
+	utils.Info(fmt.Sprintf("booting into release [version=%s, attempt=%d]", marker.Manifest.Version, marker.Attempts))
+	return false, writeMarker(directory, marker)
+}
+
+// checkUpdate - A release booted into (see CountBoot) is healthy once DAPoS runs and a seed answers this node, it is
+// rolled back when that does not happen in time. Acknowledges a release CountBoot rolled back.
+func (this *DisGoverService) checkUpdate() {
+	marker, err := readMarker(this.softwareDirectory)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	if marker == nil {
+		return
+	}
+	if marker.RolledBack != "" {
+		os.Remove(markerFileName(this.softwareDirectory))
+		go this.peerAckUpdateGrpc(marker.Manifest.Hash, types.UpdateRolledBack, errors.New(marker.RolledBack))
+		return
+	}
+
+	running := make(chan bool, 1)
+	this.events.On(types.Events.DAPoSServiceInitFinished, func() {
+		select {
+		case running <- true:
+		default:
+		}
+	})
+	go func() {
+		expired := this.clock.After(types.UpdateHealthTimeout)
+		select {
+		case <-running:
+		case <-expired:
+			this.rollBack(marker, "DAPoS did not run in time")
+			return
+		}
+		for !this.reachesSeed() {
+			select {
+			case <-this.clock.After(types.DefaultHealthCheckInterval):
+			case <-expired:
+				this.rollBack(marker, "no seed answered in time")
+				return
+			}
+		}
+		err := os.Remove(markerFileName(this.softwareDirectory))
+		if err != nil {
+			utils.Error(err)
+		}
+		utils.Info(fmt.Sprintf("release is healthy [version=%s]", marker.Manifest.Version))
+		this.peerAckUpdateGrpc(marker.Manifest.Hash, types.UpdateHealthy, nil)
+	}()
+}
+
+// reachesSeed - Whether a seed answers a ping from this node
+func (this *DisGoverService) reachesSeed() bool {
+	for _, seed := range this.config.Seeds {
+		if seed.Address != this.ThisNode.Address && this.peerPingGrpc(seed) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// rollBack - Restores the disgo that ran before the release and reboots into it
+func (this *DisGoverService) rollBack(marker *updateMarker, reason string) {
+	utils.Warn(fmt.Sprintf("rolling back release [version=%s, reason=%s]", marker.Manifest.Version, reason))
+	binary := filepath.Join(this.softwareDirectory, "disgo")
+	err := os.Rename(binary+".previous", binary)
+	os.Remove(markerFileName(this.softwareDirectory))
+	if err != nil {
+		utils.Error("unable to restore previous disgo", err)
+		this.peerAckUpdateGrpc(marker.Manifest.Hash, types.UpdateFailed, err)
+		return
+	}
+	this.peerAckUpdateGrpc(marker.Manifest.Hash, types.UpdateRolledBack, errors.New(reason))
+	utils.Info("rebooting with previous version of disgo...")
+	this.reboot()
+}
+
+// markerFileName
+func markerFileName(directory string) string {
+	return filepath.Join(directory, "update.json")
+}
+
+// readMarker - Nil when no release is being installed
+func readMarker(directory string) (*updateMarker, error) {
+	bytes, err := ioutil.ReadFile(markerFileName(directory))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	marker := &updateMarker{}
+	err = json.Unmarshal(bytes, marker)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read update marker")
+	}
+	return marker, nil
+}
+
+// writeMarker
+func writeMarker(directory string, marker *updateMarker) error {
+	bytes, err := json.Marshal(marker)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(markerFileName(directory), bytes, 0644)
+}
+
+// exit - Reboots by exiting, disgo runs under a supervisor that starts it again
+func (this *DisGoverService) exit() {
+	this.db.Close()
+	os.Exit(0)
+}
+
+// updateWorker - Rolls out the release an operator describes in update/release.json
+func (this *DisGoverService) updateWorker() {
+	updateDirectory := filepath.Join(this.softwareDirectory, "update")
+	releaseFileName := filepath.Join(updateDirectory, "release.json")
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+
+		// Any release to roll out?
+		bytes, err := ioutil.ReadFile(releaseFileName)
+		if err != nil {
+			continue
+		}
+		err = this.rollOutRelease(updateDirectory, bytes)
+		if err != nil {
+			utils.Error("unable to roll out release", err)
+		}
+
+		// Delete release.
+		err = os.Remove(releaseFileName)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to delete file %s", releaseFileName), err)
+		}
+	}
+}
+
+// rollOutRelease - Signs a manifest of the release's software and rolls it out
+func (this *DisGoverService) rollOutRelease(updateDirectory string, bytes []byte) error {
+	description := &release{}
+	err := json.Unmarshal(bytes, description)
+	if err != nil {
+		return err
+	}
+	if description.Platform == "" {
+		description.Platform = types.Platform()
+	}
+	fileName := filepath.Join(updateDirectory, filepath.Base(description.FileName))
+	software, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	utils.Info(fmt.Sprintf("found software to update [file=%s, version=%s]", fileName, description.Version))
+	defer os.Remove(fileName)
+
+	manifest, err := types.NewManifest(this.account.PrivateKey, description.Version, description.Platform, filepath.Base(description.FileName), description.MinimumVersion, software, utils.ToMilliSeconds(this.clock.Now()))
+	if err != nil {
+		return err
+	}
+	_, err = this.RollOut(manifest, software)
+	return err
+}
+
+// updateStep - Order of the statuses a delegate goes through updating, 0 for the ones that end it
+func updateStep(status string) int {
+	switch status {
+	case types.UpdateDownloading:
+		return 1
+	case types.UpdateInstalled:
+		return 2
+	case types.UpdateHealthy:
+		return 3
+	}
+	return 0
+}
+
+// copyRollout - So callers never share the acknowledgements being recorded
+func copyRollout(rollout *types.Rollout) *types.Rollout {
+	copied := *rollout
+	copied.Stages = append([]int{}, rollout.Stages...)
+	copied.Acks = make(map[string]types.UpdateAck, len(rollout.Acks))
+	for address, ack := range rollout.Acks {
+		copied.Acks[address] = ack
+	}
+	return &copied
+}
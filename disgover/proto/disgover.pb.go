@@ -33,7 +33,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_disgover_c7822d8cb2ad3fb3, []int{0}
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -57,6 +57,7 @@ type Authentication struct {
 	Hash                 string   `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
 	Time                 int64    `protobuf:"varint,3,opt,name=Time,proto3" json:"Time,omitempty"`
 	Signature            string   `protobuf:"bytes,4,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	NetworkId            string   `protobuf:"bytes,5,opt,name=NetworkId,proto3" json:"NetworkId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -66,7 +67,7 @@ func (m *Authentication) Reset()         { *m = Authentication{} }
 func (m *Authentication) String() string { return proto.CompactTextString(m) }
 func (*Authentication) ProtoMessage()    {}
 func (*Authentication) Descriptor() ([]byte, []int) {
-	return fileDescriptor_disgover_c7822d8cb2ad3fb3, []int{1}
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{1}
 }
 func (m *Authentication) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Authentication.Unmarshal(m, b)
@@ -107,6 +108,13 @@ func (m *Authentication) GetSignature() string {
 	return ""
 }
 
+func (m *Authentication) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
 type Endpoint struct {
 	Host                 string   `protobuf:"bytes,1,opt,name=Host,proto3" json:"Host,omitempty"`
 	Port                 int64    `protobuf:"varint,2,opt,name=Port,proto3" json:"Port,omitempty"`
@@ -119,7 +127,7 @@ func (m *Endpoint) Reset()         { *m = Endpoint{} }
 func (m *Endpoint) String() string { return proto.CompactTextString(m) }
 func (*Endpoint) ProtoMessage()    {}
 func (*Endpoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_disgover_c7822d8cb2ad3fb3, []int{2}
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{2}
 }
 func (m *Endpoint) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Endpoint.Unmarshal(m, b)
@@ -158,6 +166,9 @@ type Node struct {
 	GrpcEndpoint         *Endpoint `protobuf:"bytes,2,opt,name=GrpcEndpoint,proto3" json:"GrpcEndpoint,omitempty"`
 	HttpEndpoint         *Endpoint `protobuf:"bytes,3,opt,name=HttpEndpoint,proto3" json:"HttpEndpoint,omitempty"`
 	Type                 string    `protobuf:"bytes,4,opt,name=Type,proto3" json:"Type,omitempty"`
+	GenesisHash          string    `protobuf:"bytes,5,opt,name=GenesisHash,proto3" json:"GenesisHash,omitempty"`
+	NetworkId            string    `protobuf:"bytes,6,opt,name=NetworkId,proto3" json:"NetworkId,omitempty"`
+	Registered           int64     `protobuf:"varint,7,opt,name=Registered,proto3" json:"Registered,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -167,7 +178,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_disgover_c7822d8cb2ad3fb3, []int{3}
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{3}
 }
 func (m *Node) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Node.Unmarshal(m, b)
@@ -215,6 +226,27 @@ func (m *Node) GetType() string {
 	return ""
 }
 
+func (m *Node) GetGenesisHash() string {
+	if m != nil {
+		return m.GenesisHash
+	}
+	return ""
+}
+
+func (m *Node) GetNetworkId() string {
+	if m != nil {
+		return m.NetworkId
+	}
+	return ""
+}
+
+func (m *Node) GetRegistered() int64 {
+	if m != nil {
+		return m.Registered
+	}
+	return 0
+}
+
 type PingSeed struct {
 	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
 	Node                 *Node           `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
@@ -227,7 +259,7 @@ func (m *PingSeed) Reset()         { *m = PingSeed{} }
 func (m *PingSeed) String() string { return proto.CompactTextString(m) }
 func (*PingSeed) ProtoMessage()    {}
 func (*PingSeed) Descriptor() ([]byte, []int) {
-	return fileDescriptor_disgover_c7822d8cb2ad3fb3, []int{4}
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{4}
 }
 func (m *PingSeed) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingSeed.Unmarshal(m, b)
@@ -264,6 +296,7 @@ func (m *PingSeed) GetNode() *Node {
 type Update struct {
 	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
 	Delegates            []*Node         `protobuf:"bytes,2,rep,name=Delegates,proto3" json:"Delegates,omitempty"`
+	GenesisHash          string          `protobuf:"bytes,3,opt,name=GenesisHash,proto3" json:"GenesisHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -273,7 +306,7 @@ func (m *Update) Reset()         { *m = Update{} }
 func (m *Update) String() string { return proto.CompactTextString(m) }
 func (*Update) ProtoMessage()    {}
 func (*Update) Descriptor() ([]byte, []int) {
-	return fileDescriptor_disgover_c7822d8cb2ad3fb3, []int{5}
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{5}
 }
 func (m *Update) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Update.Unmarshal(m, b)
@@ -307,6 +340,131 @@ func (m *Update) GetDelegates() []*Node {
 	return nil
 }
 
+func (m *Update) GetGenesisHash() string {
+	if m != nil {
+		return m.GenesisHash
+	}
+	return ""
+}
+
+type Manifest struct {
+	Version              string   `protobuf:"bytes,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	Platform             string   `protobuf:"bytes,2,opt,name=Platform,proto3" json:"Platform,omitempty"`
+	FileName             string   `protobuf:"bytes,3,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	SoftwareHash         string   `protobuf:"bytes,4,opt,name=SoftwareHash,proto3" json:"SoftwareHash,omitempty"`
+	MinimumVersion       string   `protobuf:"bytes,5,opt,name=MinimumVersion,proto3" json:"MinimumVersion,omitempty"`
+	Time                 int64    `protobuf:"varint,6,opt,name=Time,proto3" json:"Time,omitempty"`
+	Hash                 string   `protobuf:"bytes,7,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Signature            string   `protobuf:"bytes,8,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	ChunkSize            int64    `protobuf:"varint,9,opt,name=ChunkSize,proto3" json:"ChunkSize,omitempty"`
+	ChunkHashes          []string `protobuf:"bytes,10,rep,name=ChunkHashes,proto3" json:"ChunkHashes,omitempty"`
+	Size                 int64    `protobuf:"varint,11,opt,name=Size,proto3" json:"Size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Manifest) Reset()         { *m = Manifest{} }
+func (m *Manifest) String() string { return proto.CompactTextString(m) }
+func (*Manifest) ProtoMessage()    {}
+func (*Manifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{6}
+}
+func (m *Manifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Manifest.Unmarshal(m, b)
+}
+func (m *Manifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Manifest.Marshal(b, m, deterministic)
+}
+func (dst *Manifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Manifest.Merge(dst, src)
+}
+func (m *Manifest) XXX_Size() int {
+	return xxx_messageInfo_Manifest.Size(m)
+}
+func (m *Manifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_Manifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Manifest proto.InternalMessageInfo
+
+func (m *Manifest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Manifest) GetPlatform() string {
+	if m != nil {
+		return m.Platform
+	}
+	return ""
+}
+
+func (m *Manifest) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *Manifest) GetSoftwareHash() string {
+	if m != nil {
+		return m.SoftwareHash
+	}
+	return ""
+}
+
+func (m *Manifest) GetMinimumVersion() string {
+	if m != nil {
+		return m.MinimumVersion
+	}
+	return ""
+}
+
+func (m *Manifest) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Manifest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Manifest) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+func (m *Manifest) GetChunkSize() int64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *Manifest) GetChunkHashes() []string {
+	if m != nil {
+		return m.ChunkHashes
+	}
+	return nil
+}
+
+func (m *Manifest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 type SoftwareUpdate struct {
 	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
 	Hash                 string          `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
@@ -323,7 +481,7 @@ func (m *SoftwareUpdate) Reset()         { *m = SoftwareUpdate{} }
 func (m *SoftwareUpdate) String() string { return proto.CompactTextString(m) }
 func (*SoftwareUpdate) ProtoMessage()    {}
 func (*SoftwareUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_disgover_c7822d8cb2ad3fb3, []int{6}
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{7}
 }
 func (m *SoftwareUpdate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SoftwareUpdate.Unmarshal(m, b)
@@ -385,6 +543,470 @@ func (m *SoftwareUpdate) GetScheduledReboot() string {
 	return ""
 }
 
+type Release struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Manifest             *Manifest       `protobuf:"bytes,2,opt,name=Manifest,proto3" json:"Manifest,omitempty"`
+	Sources              []*Node         `protobuf:"bytes,3,rep,name=Sources,proto3" json:"Sources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Release) Reset()         { *m = Release{} }
+func (m *Release) String() string { return proto.CompactTextString(m) }
+func (*Release) ProtoMessage()    {}
+func (*Release) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{8}
+}
+func (m *Release) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Release.Unmarshal(m, b)
+}
+func (m *Release) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Release.Marshal(b, m, deterministic)
+}
+func (dst *Release) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Release.Merge(dst, src)
+}
+func (m *Release) XXX_Size() int {
+	return xxx_messageInfo_Release.Size(m)
+}
+func (m *Release) XXX_DiscardUnknown() {
+	xxx_messageInfo_Release.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Release proto.InternalMessageInfo
+
+func (m *Release) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Release) GetManifest() *Manifest {
+	if m != nil {
+		return m.Manifest
+	}
+	return nil
+}
+
+func (m *Release) GetSources() []*Node {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+type Download struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	SoftwareHash         string          `protobuf:"bytes,2,opt,name=SoftwareHash,proto3" json:"SoftwareHash,omitempty"`
+	ChunkSize            int64           `protobuf:"varint,3,opt,name=ChunkSize,proto3" json:"ChunkSize,omitempty"`
+	FromChunk            int64           `protobuf:"varint,4,opt,name=FromChunk,proto3" json:"FromChunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Download) Reset()         { *m = Download{} }
+func (m *Download) String() string { return proto.CompactTextString(m) }
+func (*Download) ProtoMessage()    {}
+func (*Download) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{9}
+}
+func (m *Download) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Download.Unmarshal(m, b)
+}
+func (m *Download) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Download.Marshal(b, m, deterministic)
+}
+func (dst *Download) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Download.Merge(dst, src)
+}
+func (m *Download) XXX_Size() int {
+	return xxx_messageInfo_Download.Size(m)
+}
+func (m *Download) XXX_DiscardUnknown() {
+	xxx_messageInfo_Download.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Download proto.InternalMessageInfo
+
+func (m *Download) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Download) GetSoftwareHash() string {
+	if m != nil {
+		return m.SoftwareHash
+	}
+	return ""
+}
+
+func (m *Download) GetChunkSize() int64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *Download) GetFromChunk() int64 {
+	if m != nil {
+		return m.FromChunk
+	}
+	return 0
+}
+
+type Chunk struct {
+	Index                int64    `protobuf:"varint,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Chunk) Reset()         { *m = Chunk{} }
+func (m *Chunk) String() string { return proto.CompactTextString(m) }
+func (*Chunk) ProtoMessage()    {}
+func (*Chunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{10}
+}
+func (m *Chunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Chunk.Unmarshal(m, b)
+}
+func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Chunk.Marshal(b, m, deterministic)
+}
+func (dst *Chunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Chunk.Merge(dst, src)
+}
+func (m *Chunk) XXX_Size() int {
+	return xxx_messageInfo_Chunk.Size(m)
+}
+func (m *Chunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_Chunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Chunk proto.InternalMessageInfo
+
+func (m *Chunk) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Chunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type UpdateAck struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	ManifestHash         string          `protobuf:"bytes,2,opt,name=ManifestHash,proto3" json:"ManifestHash,omitempty"`
+	Address              string          `protobuf:"bytes,3,opt,name=Address,proto3" json:"Address,omitempty"`
+	Status               string          `protobuf:"bytes,4,opt,name=Status,proto3" json:"Status,omitempty"`
+	Version              string          `protobuf:"bytes,5,opt,name=Version,proto3" json:"Version,omitempty"`
+	Error                string          `protobuf:"bytes,6,opt,name=Error,proto3" json:"Error,omitempty"`
+	Time                 int64           `protobuf:"varint,7,opt,name=Time,proto3" json:"Time,omitempty"`
+	Hash                 string          `protobuf:"bytes,8,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Signature            string          `protobuf:"bytes,9,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UpdateAck) Reset()         { *m = UpdateAck{} }
+func (m *UpdateAck) String() string { return proto.CompactTextString(m) }
+func (*UpdateAck) ProtoMessage()    {}
+func (*UpdateAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{11}
+}
+func (m *UpdateAck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAck.Unmarshal(m, b)
+}
+func (m *UpdateAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAck.Marshal(b, m, deterministic)
+}
+func (dst *UpdateAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAck.Merge(dst, src)
+}
+func (m *UpdateAck) XXX_Size() int {
+	return xxx_messageInfo_UpdateAck.Size(m)
+}
+func (m *UpdateAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAck proto.InternalMessageInfo
+
+func (m *UpdateAck) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *UpdateAck) GetManifestHash() string {
+	if m != nil {
+		return m.ManifestHash
+	}
+	return ""
+}
+
+func (m *UpdateAck) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *UpdateAck) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *UpdateAck) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *UpdateAck) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *UpdateAck) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *UpdateAck) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *UpdateAck) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type FindNode struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Node                 *Node           `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	Target               string          `protobuf:"bytes,3,opt,name=Target,proto3" json:"Target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FindNode) Reset()         { *m = FindNode{} }
+func (m *FindNode) String() string { return proto.CompactTextString(m) }
+func (*FindNode) ProtoMessage()    {}
+func (*FindNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{12}
+}
+func (m *FindNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindNode.Unmarshal(m, b)
+}
+func (m *FindNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindNode.Marshal(b, m, deterministic)
+}
+func (dst *FindNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindNode.Merge(dst, src)
+}
+func (m *FindNode) XXX_Size() int {
+	return xxx_messageInfo_FindNode.Size(m)
+}
+func (m *FindNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindNode proto.InternalMessageInfo
+
+func (m *FindNode) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *FindNode) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *FindNode) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type Nodes struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Nodes                []*Node         `protobuf:"bytes,2,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Nodes) Reset()         { *m = Nodes{} }
+func (m *Nodes) String() string { return proto.CompactTextString(m) }
+func (*Nodes) ProtoMessage()    {}
+func (*Nodes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{13}
+}
+func (m *Nodes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Nodes.Unmarshal(m, b)
+}
+func (m *Nodes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Nodes.Marshal(b, m, deterministic)
+}
+func (dst *Nodes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Nodes.Merge(dst, src)
+}
+func (m *Nodes) XXX_Size() int {
+	return xxx_messageInfo_Nodes.Size(m)
+}
+func (m *Nodes) XXX_DiscardUnknown() {
+	xxx_messageInfo_Nodes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Nodes proto.InternalMessageInfo
+
+func (m *Nodes) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Nodes) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type Ping struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Node                 *Node           `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Ping) Reset()         { *m = Ping{} }
+func (m *Ping) String() string { return proto.CompactTextString(m) }
+func (*Ping) ProtoMessage()    {}
+func (*Ping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{14}
+}
+func (m *Ping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ping.Unmarshal(m, b)
+}
+func (m *Ping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ping.Marshal(b, m, deterministic)
+}
+func (dst *Ping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ping.Merge(dst, src)
+}
+func (m *Ping) XXX_Size() int {
+	return xxx_messageInfo_Ping.Size(m)
+}
+func (m *Ping) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ping.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ping proto.InternalMessageInfo
+
+func (m *Ping) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Ping) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+type Replicate struct {
+	Authentication       *Authentication `protobuf:"bytes,1,opt,name=Authentication,proto3" json:"Authentication,omitempty"`
+	Nodes                []*Node         `protobuf:"bytes,2,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+	GenesisHash          string          `protobuf:"bytes,3,opt,name=GenesisHash,proto3" json:"GenesisHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Replicate) Reset()         { *m = Replicate{} }
+func (m *Replicate) String() string { return proto.CompactTextString(m) }
+func (*Replicate) ProtoMessage()    {}
+func (*Replicate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_disgover_50c642b2fa1b5199, []int{15}
+}
+func (m *Replicate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Replicate.Unmarshal(m, b)
+}
+func (m *Replicate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Replicate.Marshal(b, m, deterministic)
+}
+func (dst *Replicate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Replicate.Merge(dst, src)
+}
+func (m *Replicate) XXX_Size() int {
+	return xxx_messageInfo_Replicate.Size(m)
+}
+func (m *Replicate) XXX_DiscardUnknown() {
+	xxx_messageInfo_Replicate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Replicate proto.InternalMessageInfo
+
+func (m *Replicate) GetAuthentication() *Authentication {
+	if m != nil {
+		return m.Authentication
+	}
+	return nil
+}
+
+func (m *Replicate) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *Replicate) GetGenesisHash() string {
+	if m != nil {
+		return m.GenesisHash
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "disgover.Empty")
 	proto.RegisterType((*Authentication)(nil), "disgover.Authentication")
@@ -392,7 +1014,16 @@ func init() {
 	proto.RegisterType((*Node)(nil), "disgover.Node")
 	proto.RegisterType((*PingSeed)(nil), "disgover.PingSeed")
 	proto.RegisterType((*Update)(nil), "disgover.Update")
+	proto.RegisterType((*Manifest)(nil), "disgover.Manifest")
 	proto.RegisterType((*SoftwareUpdate)(nil), "disgover.SoftwareUpdate")
+	proto.RegisterType((*Release)(nil), "disgover.Release")
+	proto.RegisterType((*Download)(nil), "disgover.Download")
+	proto.RegisterType((*Chunk)(nil), "disgover.Chunk")
+	proto.RegisterType((*UpdateAck)(nil), "disgover.UpdateAck")
+	proto.RegisterType((*FindNode)(nil), "disgover.FindNode")
+	proto.RegisterType((*Nodes)(nil), "disgover.Nodes")
+	proto.RegisterType((*Ping)(nil), "disgover.Ping")
+	proto.RegisterType((*Replicate)(nil), "disgover.Replicate")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -410,6 +1041,12 @@ type DisgoverGrpcClient interface {
 	PingSeedGrpc(ctx context.Context, in *PingSeed, opts ...grpc.CallOption) (*Update, error)
 	UpdateGrpc(ctx context.Context, in *Update, opts ...grpc.CallOption) (*Empty, error)
 	UpdateSoftwareGrpc(ctx context.Context, in *SoftwareUpdate, opts ...grpc.CallOption) (*Empty, error)
+	ReleaseGrpc(ctx context.Context, in *Release, opts ...grpc.CallOption) (*UpdateAck, error)
+	AckUpdateGrpc(ctx context.Context, in *UpdateAck, opts ...grpc.CallOption) (*Empty, error)
+	DownloadGrpc(ctx context.Context, in *Download, opts ...grpc.CallOption) (DisgoverGrpc_DownloadGrpcClient, error)
+	FindNodeGrpc(ctx context.Context, in *FindNode, opts ...grpc.CallOption) (*Nodes, error)
+	ReplicateGrpc(ctx context.Context, in *Replicate, opts ...grpc.CallOption) (*Replicate, error)
+	PingGrpc(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Ping, error)
 }
 
 type disgoverGrpcClient struct {
@@ -447,11 +1084,94 @@ func (c *disgoverGrpcClient) UpdateSoftwareGrpc(ctx context.Context, in *Softwar
 	return out, nil
 }
 
+func (c *disgoverGrpcClient) ReleaseGrpc(ctx context.Context, in *Release, opts ...grpc.CallOption) (*UpdateAck, error) {
+	out := new(UpdateAck)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/ReleaseGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disgoverGrpcClient) AckUpdateGrpc(ctx context.Context, in *UpdateAck, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/AckUpdateGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disgoverGrpcClient) DownloadGrpc(ctx context.Context, in *Download, opts ...grpc.CallOption) (DisgoverGrpc_DownloadGrpcClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DisgoverGrpc_serviceDesc.Streams[0], "/disgover.DisgoverGrpc/DownloadGrpc", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disgoverGrpcDownloadGrpcClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DisgoverGrpc_DownloadGrpcClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type disgoverGrpcDownloadGrpcClient struct {
+	grpc.ClientStream
+}
+
+func (x *disgoverGrpcDownloadGrpcClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disgoverGrpcClient) FindNodeGrpc(ctx context.Context, in *FindNode, opts ...grpc.CallOption) (*Nodes, error) {
+	out := new(Nodes)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/FindNodeGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disgoverGrpcClient) ReplicateGrpc(ctx context.Context, in *Replicate, opts ...grpc.CallOption) (*Replicate, error) {
+	out := new(Replicate)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/ReplicateGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disgoverGrpcClient) PingGrpc(ctx context.Context, in *Ping, opts ...grpc.CallOption) (*Ping, error) {
+	out := new(Ping)
+	err := c.cc.Invoke(ctx, "/disgover.DisgoverGrpc/PingGrpc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisgoverGrpcServer is the server API for DisgoverGrpc service.
 type DisgoverGrpcServer interface {
 	PingSeedGrpc(context.Context, *PingSeed) (*Update, error)
 	UpdateGrpc(context.Context, *Update) (*Empty, error)
 	UpdateSoftwareGrpc(context.Context, *SoftwareUpdate) (*Empty, error)
+	ReleaseGrpc(context.Context, *Release) (*UpdateAck, error)
+	AckUpdateGrpc(context.Context, *UpdateAck) (*Empty, error)
+	DownloadGrpc(*Download, DisgoverGrpc_DownloadGrpcServer) error
+	FindNodeGrpc(context.Context, *FindNode) (*Nodes, error)
+	ReplicateGrpc(context.Context, *Replicate) (*Replicate, error)
+	PingGrpc(context.Context, *Ping) (*Ping, error)
 }
 
 func RegisterDisgoverGrpcServer(s *grpc.Server, srv DisgoverGrpcServer) {
@@ -512,6 +1232,117 @@ func _DisgoverGrpc_UpdateSoftwareGrpc_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DisgoverGrpc_ReleaseGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Release)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisgoverGrpcServer).ReleaseGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disgover.DisgoverGrpc/ReleaseGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisgoverGrpcServer).ReleaseGrpc(ctx, req.(*Release))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisgoverGrpc_AckUpdateGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAck)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisgoverGrpcServer).AckUpdateGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disgover.DisgoverGrpc/AckUpdateGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisgoverGrpcServer).AckUpdateGrpc(ctx, req.(*UpdateAck))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisgoverGrpc_DownloadGrpc_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Download)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisgoverGrpcServer).DownloadGrpc(m, &disgoverGrpcDownloadGrpcServer{stream})
+}
+
+type DisgoverGrpc_DownloadGrpcServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type disgoverGrpcDownloadGrpcServer struct {
+	grpc.ServerStream
+}
+
+func (x *disgoverGrpcDownloadGrpcServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DisgoverGrpc_FindNodeGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisgoverGrpcServer).FindNodeGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disgover.DisgoverGrpc/FindNodeGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisgoverGrpcServer).FindNodeGrpc(ctx, req.(*FindNode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisgoverGrpc_ReplicateGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Replicate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisgoverGrpcServer).ReplicateGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disgover.DisgoverGrpc/ReplicateGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisgoverGrpcServer).ReplicateGrpc(ctx, req.(*Replicate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisgoverGrpc_PingGrpc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ping)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisgoverGrpcServer).PingGrpc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disgover.DisgoverGrpc/PingGrpc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisgoverGrpcServer).PingGrpc(ctx, req.(*Ping))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DisgoverGrpc_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "disgover.DisgoverGrpc",
 	HandlerType: (*DisgoverGrpcServer)(nil),
@@ -528,40 +1359,96 @@ var _DisgoverGrpc_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateSoftwareGrpc",
 			Handler:    _DisgoverGrpc_UpdateSoftwareGrpc_Handler,
 		},
+		{
+			MethodName: "ReleaseGrpc",
+			Handler:    _DisgoverGrpc_ReleaseGrpc_Handler,
+		},
+		{
+			MethodName: "AckUpdateGrpc",
+			Handler:    _DisgoverGrpc_AckUpdateGrpc_Handler,
+		},
+		{
+			MethodName: "FindNodeGrpc",
+			Handler:    _DisgoverGrpc_FindNodeGrpc_Handler,
+		},
+		{
+			MethodName: "ReplicateGrpc",
+			Handler:    _DisgoverGrpc_ReplicateGrpc_Handler,
+		},
+		{
+			MethodName: "PingGrpc",
+			Handler:    _DisgoverGrpc_PingGrpc_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadGrpc",
+			Handler:       _DisgoverGrpc_DownloadGrpc_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "disgover.proto",
 }
 
-func init() { proto.RegisterFile("disgover.proto", fileDescriptor_disgover_c7822d8cb2ad3fb3) }
-
-var fileDescriptor_disgover_c7822d8cb2ad3fb3 = []byte{
-	// 419 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xae, 0xeb, 0x34, 0x4d, 0xa6, 0x91, 0x8b, 0xf6, 0xb4, 0x8a, 0x38, 0x44, 0x7b, 0xca, 0x01,
-	0x55, 0xc2, 0x48, 0x9c, 0x89, 0xd4, 0x42, 0x4f, 0x55, 0xb5, 0x06, 0xee, 0x6e, 0x76, 0x70, 0x56,
-	0x4a, 0xbd, 0x2b, 0x7b, 0x03, 0xf4, 0x7d, 0x78, 0x0c, 0x9e, 0x87, 0xe7, 0x40, 0x1e, 0x7b, 0xfd,
-	0x47, 0xb8, 0xe5, 0x36, 0xf3, 0xcd, 0x7c, 0xb3, 0xf3, 0xf3, 0x2d, 0x44, 0x4a, 0x97, 0x99, 0xf9,
-	0x8e, 0xc5, 0x8d, 0x2d, 0x8c, 0x33, 0x6c, 0xe6, 0x7d, 0x71, 0x09, 0x17, 0x77, 0xcf, 0xd6, 0xbd,
-	0x88, 0xaf, 0x10, 0x6d, 0x0e, 0x6e, 0x87, 0xb9, 0xd3, 0xdb, 0xd4, 0x69, 0x93, 0x33, 0x06, 0x93,
-	0xfb, 0xb4, 0xdc, 0xf1, 0xf3, 0x55, 0xb0, 0x9e, 0x4b, 0xb2, 0x2b, 0xec, 0xb3, 0x7e, 0x46, 0x1e,
-	0xae, 0x82, 0x75, 0x28, 0xc9, 0x66, 0xaf, 0x61, 0x9e, 0xe8, 0x2c, 0x4f, 0xdd, 0xa1, 0x40, 0x3e,
-	0xa1, 0xe4, 0x0e, 0x10, 0x31, 0xcc, 0xee, 0x72, 0x65, 0x8d, 0xce, 0x1d, 0x55, 0x34, 0xa5, 0xe3,
-	0x41, 0x53, 0xd1, 0x94, 0x84, 0x3d, 0x9a, 0xc2, 0xd1, 0x2b, 0xa1, 0x24, 0x5b, 0xfc, 0x0a, 0x60,
-	0xf2, 0x60, 0x14, 0x32, 0x0e, 0x97, 0x1b, 0xa5, 0x0a, 0x2c, 0xcb, 0x86, 0xe3, 0x5d, 0xf6, 0x1e,
-	0x16, 0x9f, 0x0a, 0xbb, 0xf5, 0xa5, 0x89, 0x7e, 0x15, 0xb3, 0x9b, 0x76, 0x50, 0x1f, 0x91, 0x83,
-	0xbc, 0x8a, 0x77, 0xef, 0x9c, 0x6d, 0x79, 0xe1, 0xff, 0x79, 0xfd, 0x3c, 0x1a, 0xfc, 0xc5, 0xfa,
-	0xf9, 0xc8, 0x16, 0x16, 0x66, 0x8f, 0x3a, 0xcf, 0x12, 0x44, 0xc5, 0x3e, 0x8c, 0xd7, 0x47, 0x0d,
-	0x5f, 0xc5, 0xbc, 0xab, 0x3c, 0x8c, 0xcb, 0xf1, 0xba, 0x45, 0x3d, 0x73, 0x33, 0x49, 0xd4, 0xf1,
-	0x2a, 0x54, 0x52, 0x4c, 0xfc, 0x84, 0xe9, 0x17, 0xab, 0x52, 0x87, 0x27, 0x78, 0xef, 0x0d, 0xcc,
-	0x6f, 0x71, 0x8f, 0x59, 0xea, 0xb0, 0xe4, 0xe7, 0xab, 0xf0, 0xc8, 0xa3, 0x5d, 0x82, 0xf8, 0x13,
-	0x40, 0x94, 0x98, 0x6f, 0xee, 0x47, 0x5a, 0xe0, 0xc9, 0x5a, 0x38, 0xa6, 0xb0, 0x25, 0xcc, 0x3e,
-	0xea, 0x3d, 0x3e, 0xa4, 0x8d, 0xca, 0xe6, 0xb2, 0xf5, 0xab, 0x98, 0xef, 0x81, 0x0e, 0xb1, 0x90,
-	0xad, 0x3f, 0x54, 0xe1, 0xc5, 0x48, 0x85, 0x6c, 0x0d, 0xd7, 0xc9, 0x76, 0x87, 0xea, 0xb0, 0x47,
-	0x25, 0xf1, 0xc9, 0x18, 0xc7, 0xa7, 0x94, 0x33, 0x86, 0xe3, 0xdf, 0x01, 0x2c, 0x6e, 0x9b, 0xfe,
-	0x2b, 0xe5, 0x54, 0x8a, 0xf1, 0x57, 0x26, 0xbf, 0xa7, 0x15, 0x8f, 0x2f, 0x5f, 0x75, 0x58, 0xbd,
-	0x1c, 0x71, 0xc6, 0xde, 0x02, 0xd4, 0x36, 0xb1, 0xfe, 0xc9, 0x58, 0x5e, 0xf7, 0x34, 0x47, 0x3f,
-	0xf0, 0x8c, 0x6d, 0x80, 0xd5, 0x41, 0x3f, 0x15, 0x51, 0x7b, 0xfb, 0x1c, 0x5e, 0xe0, 0x48, 0x89,
-	0xa7, 0x29, 0x7d, 0xf0, 0x77, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x96, 0xdd, 0xc7, 0xdd, 0xf2,
-	0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("disgover.proto", fileDescriptor_disgover_50c642b2fa1b5199) }
+
+var fileDescriptor_disgover_50c642b2fa1b5199 = []byte{
+	// 905 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x4d, 0x8f, 0x23, 0x35,
+	0x13, 0x4e, 0xa7, 0xf3, 0xd1, 0x5d, 0xc9, 0x66, 0xdf, 0xd7, 0xac, 0x50, 0x2b, 0x5a, 0xa1, 0xc8,
+	0x42, 0x68, 0x0e, 0xab, 0x11, 0x3b, 0x08, 0x38, 0x70, 0x21, 0x62, 0x66, 0x3f, 0x0e, 0x3b, 0x1a,
+	0x39, 0x0b, 0xf7, 0xde, 0x74, 0x4d, 0xa6, 0x95, 0xa4, 0xdd, 0xb2, 0x1d, 0x86, 0xe5, 0xcc, 0x01,
+	0x7e, 0x00, 0x07, 0xb8, 0x73, 0xe3, 0x57, 0xf0, 0x63, 0xf8, 0x1d, 0xc8, 0x76, 0xbb, 0xbf, 0xa6,
+	0x07, 0x2e, 0xd1, 0xdc, 0x5c, 0x4f, 0xf9, 0xb1, 0xcb, 0x8f, 0xab, 0xca, 0x86, 0x59, 0x92, 0xca,
+	0x0d, 0xff, 0x1e, 0xc5, 0x69, 0x2e, 0xb8, 0xe2, 0x24, 0x70, 0x36, 0x1d, 0xc3, 0xf0, 0x62, 0x9f,
+	0xab, 0xf7, 0x54, 0xc1, 0x6c, 0x79, 0x50, 0x37, 0x98, 0xa9, 0x74, 0x1d, 0xab, 0x94, 0x67, 0x84,
+	0xc0, 0xe0, 0x55, 0x2c, 0x6f, 0xa2, 0xfe, 0xc2, 0x3b, 0x09, 0x99, 0x19, 0x6b, 0xec, 0x6d, 0xba,
+	0xc7, 0xc8, 0x5f, 0x78, 0x27, 0x3e, 0x33, 0x63, 0xf2, 0x14, 0xc2, 0x55, 0xba, 0xc9, 0x62, 0x75,
+	0x10, 0x18, 0x0d, 0xcc, 0xe4, 0x0a, 0xd0, 0xde, 0x4b, 0x54, 0xb7, 0x5c, 0x6c, 0x5f, 0x27, 0xd1,
+	0xd0, 0x7a, 0x4b, 0x80, 0x9e, 0x41, 0x70, 0x91, 0x25, 0x39, 0x4f, 0x33, 0x65, 0xf6, 0xe3, 0x52,
+	0x45, 0x5e, 0xb1, 0x1f, 0x97, 0x06, 0xbb, 0xe2, 0x42, 0x99, 0x18, 0x7c, 0x66, 0xc6, 0xf4, 0xa7,
+	0x3e, 0x0c, 0x2e, 0x79, 0x82, 0x24, 0x82, 0xf1, 0x32, 0x49, 0x04, 0x4a, 0x59, 0x70, 0x9c, 0x49,
+	0xbe, 0x80, 0xe9, 0x4b, 0x91, 0xaf, 0xdd, 0xd2, 0x86, 0x3e, 0x39, 0x23, 0xa7, 0xa5, 0x0c, 0xce,
+	0xc3, 0x1a, 0xf3, 0x34, 0xef, 0x95, 0x52, 0x79, 0xc9, 0xf3, 0xef, 0xe7, 0xd5, 0xe7, 0x19, 0x59,
+	0xde, 0xe7, 0xee, 0xf4, 0x66, 0x4c, 0x16, 0x30, 0x79, 0x89, 0x19, 0xca, 0x54, 0x1a, 0x15, 0xed,
+	0xd1, 0xeb, 0x50, 0x53, 0x9a, 0x51, 0x4b, 0x1a, 0xf2, 0x11, 0x00, 0xc3, 0x4d, 0x2a, 0x15, 0x0a,
+	0x4c, 0xa2, 0xb1, 0x11, 0xa0, 0x86, 0xd0, 0x1c, 0x82, 0xab, 0x34, 0xdb, 0xac, 0x10, 0x13, 0xf2,
+	0x75, 0xfb, 0xf2, 0x8c, 0x20, 0x93, 0xb3, 0xa8, 0x8a, 0xbc, 0xe9, 0x67, 0xed, 0xcb, 0xa6, 0x56,
+	0xd3, 0x42, 0xa9, 0x59, 0xc5, 0xd3, 0x28, 0x33, 0x3e, 0xfa, 0xbb, 0x07, 0xa3, 0x6f, 0xf3, 0x24,
+	0x56, 0x78, 0x84, 0x0d, 0x9f, 0x41, 0x78, 0x8e, 0x3b, 0xdc, 0xc4, 0x0a, 0x65, 0xd4, 0x5f, 0xf8,
+	0x1d, 0xbb, 0x56, 0x13, 0xda, 0x62, 0xfa, 0x77, 0xc4, 0xa4, 0x7f, 0xf5, 0x21, 0x78, 0x13, 0x67,
+	0xe9, 0x35, 0x4a, 0xa5, 0x33, 0xe3, 0x3b, 0x14, 0xd2, 0xc5, 0x15, 0x32, 0x67, 0x92, 0x39, 0x04,
+	0x57, 0xbb, 0x58, 0x5d, 0x73, 0xb1, 0x2f, 0x12, 0xbb, 0xb4, 0xb5, 0xef, 0x45, 0xba, 0xc3, 0xcb,
+	0xb8, 0x48, 0xf0, 0x90, 0x95, 0x36, 0xa1, 0x30, 0x5d, 0xf1, 0x6b, 0x75, 0x1b, 0x0b, 0x34, 0x11,
+	0xd8, 0x9b, 0x6e, 0x60, 0xe4, 0x13, 0x98, 0xbd, 0x49, 0xb3, 0x74, 0x7f, 0xd8, 0xbb, 0xcd, 0xed,
+	0xa5, 0xb7, 0xd0, 0xb2, 0x88, 0x46, 0xb5, 0x22, 0x72, 0xc5, 0x36, 0xae, 0x15, 0x5b, 0xa3, 0xb0,
+	0x82, 0x8e, 0xc2, 0xfa, 0xe6, 0xe6, 0x90, 0x6d, 0x57, 0xe9, 0x8f, 0x18, 0x85, 0x66, 0xa9, 0x0a,
+	0xd0, 0x82, 0x19, 0x43, 0x2f, 0x84, 0x32, 0x82, 0x85, 0xaf, 0x05, 0xab, 0x41, 0x7a, 0x47, 0x43,
+	0x9d, 0xd8, 0x28, 0xf4, 0x98, 0xfe, 0xed, 0xc1, 0xcc, 0x1d, 0xe9, 0x68, 0x37, 0xdd, 0xd5, 0x47,
+	0xfe, 0x4d, 0xea, 0x39, 0x04, 0x2e, 0x06, 0x23, 0xf3, 0x94, 0x95, 0x76, 0x53, 0x92, 0x61, 0x5b,
+	0x92, 0x13, 0x78, 0xbc, 0x5a, 0xdf, 0x60, 0x72, 0xd8, 0x61, 0xc2, 0xf0, 0x1d, 0xe7, 0xaa, 0x28,
+	0xab, 0x36, 0x4c, 0xff, 0xf0, 0x60, 0xcc, 0x70, 0x87, 0xb1, 0x3c, 0xc6, 0x09, 0x4f, 0xab, 0xd4,
+	0xbb, 0xdb, 0x6a, 0x9c, 0x87, 0x55, 0xe9, 0x79, 0x02, 0xe3, 0x15, 0x3f, 0x88, 0x35, 0xca, 0xc8,
+	0xef, 0xcc, 0x7c, 0xe7, 0xa6, 0x7f, 0x7a, 0x10, 0x9c, 0xf3, 0xdb, 0x6c, 0xc7, 0xe3, 0xe3, 0x54,
+	0x79, 0x33, 0x8b, 0xfb, 0x1d, 0x59, 0xdc, 0xc8, 0x2b, 0xbf, 0x9d, 0x57, 0x4f, 0x21, 0x7c, 0x21,
+	0xf8, 0xde, 0x00, 0xe6, 0x76, 0x7c, 0x56, 0x01, 0xf4, 0x39, 0x0c, 0xcd, 0x80, 0x3c, 0x81, 0xe1,
+	0xeb, 0x2c, 0xc1, 0x1f, 0x4c, 0x84, 0x3e, 0xb3, 0x86, 0xce, 0x84, 0xf3, 0x58, 0xc5, 0x66, 0xdb,
+	0x29, 0x33, 0x63, 0xfa, 0x5b, 0x1f, 0x42, 0x9b, 0x6a, 0xcb, 0xf5, 0xf6, 0x38, 0x47, 0x74, 0x3a,
+	0xd7, 0x8f, 0x58, 0xc7, 0xea, 0x0f, 0x87, 0xdf, 0x7c, 0x38, 0x3e, 0x84, 0xd1, 0x4a, 0xc5, 0xea,
+	0x20, 0x8b, 0x02, 0x2f, 0xac, 0x7a, 0x43, 0x19, 0x36, 0x1b, 0xca, 0x13, 0x18, 0x5e, 0x08, 0xc1,
+	0x45, 0x91, 0x69, 0xd6, 0x28, 0x4b, 0x7c, 0xdc, 0x51, 0xe2, 0xc1, 0x7d, 0x25, 0x1e, 0xb6, 0xf2,
+	0x99, 0xfe, 0xec, 0xe9, 0x32, 0xc9, 0x12, 0xf3, 0xda, 0x3d, 0x48, 0x8f, 0xd7, 0x02, 0xbc, 0x8d,
+	0xc5, 0x06, 0x55, 0xa1, 0x4c, 0x61, 0x51, 0x0e, 0x43, 0xed, 0x97, 0x47, 0x08, 0xe3, 0xe3, 0x62,
+	0xa9, 0x7b, 0xba, 0xbe, 0x75, 0xd2, 0x1d, 0x0c, 0xf4, 0xf3, 0xf6, 0x40, 0x4f, 0xdb, 0xaf, 0x1e,
+	0x84, 0x0c, 0xf3, 0x9d, 0xe6, 0xe0, 0x43, 0x9d, 0xf1, 0xbf, 0x5f, 0xb5, 0xb3, 0x5f, 0x06, 0x30,
+	0x3d, 0x2f, 0xa8, 0xfa, 0xa7, 0xa2, 0x7f, 0x28, 0xee, 0xd5, 0x37, 0x76, 0xad, 0xd1, 0x38, 0x7c,
+	0xfe, 0xbf, 0x0a, 0xb3, 0x95, 0x45, 0x7b, 0xe4, 0x39, 0x80, 0x1d, 0x1b, 0xd6, 0x9d, 0x19, 0xf3,
+	0xc7, 0x15, 0x62, 0xff, 0x83, 0x3d, 0xb2, 0x04, 0x62, 0x9d, 0xae, 0x3d, 0x18, 0x6a, 0x4d, 0x83,
+	0xe6, 0x4b, 0xd1, 0xb5, 0xc4, 0x97, 0x30, 0x29, 0xba, 0xac, 0xe1, 0xfe, 0xbf, 0x9a, 0x51, 0xc0,
+	0xf3, 0x0f, 0xda, 0x91, 0x2c, 0xd7, 0x5b, 0x43, 0x7c, 0xb4, 0x5c, 0x6f, 0x6b, 0x11, 0x77, 0xcd,
+	0xeb, 0xde, 0x71, 0xea, 0xfa, 0x65, 0x5b, 0x1f, 0x87, 0xd7, 0x69, 0xb6, 0x6d, 0xf5, 0x3e, 0xf5,
+	0xc8, 0xe7, 0x30, 0x75, 0xa5, 0xd6, 0x26, 0x3a, 0xbc, 0x4e, 0xb4, 0x49, 0xda, 0x23, 0x5f, 0xc1,
+	0xa3, 0x32, 0x6f, 0xda, 0x81, 0x96, 0x8e, 0x79, 0x17, 0x48, 0x7b, 0xe4, 0x99, 0xfd, 0xc2, 0x19,
+	0xde, 0xac, 0x79, 0x91, 0xf3, 0x96, 0x4d, 0x7b, 0xef, 0x46, 0xe6, 0xef, 0xfe, 0xd9, 0x3f, 0x03,
+	0x00, 0x64, 0x1a, 0x31, 0xd1, 0xcd, 0x0b, 0x00, 0x00,
 }
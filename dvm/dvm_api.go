@@ -49,7 +49,6 @@ func (dvm *DVMService) DeploySmartContract(tx *commonTypes.Transaction) (*DVMRes
 			ContractMethodExecError:  nil,
 			ContractMethodExecResult: nil,
 
-			Divvy: vmstatehelperimplemtations.DefaultDivvy,
 			// Status:              receipt.Status,
 			// HertzCost:           receipt.GasUsed,
 			// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -73,7 +72,6 @@ func (dvm *DVMService) DeploySmartContract(tx *commonTypes.Transaction) (*DVMRes
 			ContractMethodExecError:  nil,
 			ContractMethodExecResult: nil,
 
-			Divvy: vmstatehelperimplemtations.DefaultDivvy,
 			// Status:              receipt.Status,
 			// HertzCost:           receipt.GasUsed,
 			// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -98,7 +96,6 @@ func (dvm *DVMService) DeploySmartContract(tx *commonTypes.Transaction) (*DVMRes
 			ContractMethodExecError:  nil,
 			ContractMethodExecResult: nil,
 
-			Divvy: vmstatehelperimplemtations.DefaultDivvy,
 			// Status:              receipt.Status,
 			// HertzCost:           receipt.GasUsed,
 			// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -121,7 +118,6 @@ func (dvm *DVMService) DeploySmartContract(tx *commonTypes.Transaction) (*DVMRes
 		ContractMethodExecError:  nil,
 		ContractMethodExecResult: nil,
 
-		Divvy:               vmstatehelperimplemtations.DefaultDivvy,
 		Status:              receipt.Status,
 		HertzCost:           receipt.GasUsed,
 		CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -151,7 +147,6 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 				ContractMethodExecError:  err,
 				ContractMethodExecResult: nil,
 
-				Divvy:  vmstatehelperimplemtations.DefaultDivvy,
 				Status: ethTypes.ReceiptStatusFailed,
 				// HertzCost:           receipt.GasUsed,
 				// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -175,7 +170,6 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 			ContractMethodExecError:  err,
 			ContractMethodExecResult: nil,
 
-			Divvy:  vmstatehelperimplemtations.DefaultDivvy,
 			Status: ethTypes.ReceiptStatusFailed,
 			// HertzCost:           receipt.GasUsed,
 			// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -202,7 +196,6 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 			ContractMethodExecError:  err,
 			ContractMethodExecResult: nil,
 
-			Divvy:  vmstatehelperimplemtations.DefaultDivvy,
 			Status: ethTypes.ReceiptStatusFailed,
 			// HertzCost:           receipt.GasUsed,
 			// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -227,7 +220,6 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 			ContractMethodExecError:  err,
 			ContractMethodExecResult: nil,
 
-			Divvy:  vmstatehelperimplemtations.DefaultDivvy,
 			Status: ethTypes.ReceiptStatusFailed,
 			// HertzCost:           receipt.GasUsed,
 			// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -264,7 +256,6 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 			ContractMethodExecError:  execError,
 			ContractMethodExecResult: nil,
 
-			Divvy:  vmstatehelperimplemtations.DefaultDivvy,
 			Status: ethTypes.ReceiptStatusFailed,
 			// HertzCost:           receipt.GasUsed,
 			// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -289,7 +280,6 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 			ContractMethodExecError:  execError,
 			ContractMethodExecResult: nil,
 
-			Divvy:  vmstatehelperimplemtations.DefaultDivvy,
 			Status: ethTypes.ReceiptStatusFailed,
 			// HertzCost:           receipt.GasUsed,
 			// CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -313,7 +303,6 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 		ContractMethodExecError:  execError,
 		ContractMethodExecResult: execResult,
 
-		Divvy:               vmstatehelperimplemtations.DefaultDivvy,
 		Status:              receipt.Status,
 		HertzCost:           receipt.GasUsed,
 		CumulativeHertzUsed: receipt.CumulativeGasUsed,
@@ -321,3 +310,19 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 		Logs:                receipt.Logs,
 	}, nil
 }
+
+// InstallContract - Writes the code and storage of a contract created by the genesis instead of a deployment, installing
+// the same contract again changes nothing
+func (dvm *DVMService) InstallContract(address string, code []byte, storage map[string]string) error {
+	contractAddress := crypto.GetAddressBytes(address)
+	stateHelper, err := vmstatehelperimplemtations.NewVMStateHelper(contractAddress)
+	if err != nil {
+		return err
+	}
+	stateHelper.EthStateDB.SetCode(contractAddress, code)
+	for key, value := range storage {
+		stateHelper.EthStateDB.SetState(contractAddress, crypto.GetHashBytes(key), crypto.GetHashBytes(value))
+	}
+	_, err = stateHelper.Commit()
+	return err
+}
@@ -23,7 +23,6 @@ type DVMResult struct {
 	ContractMethodExecError  error  // Method Execution error
 	ContractMethodExecResult []byte // Method Execution result - parsable with `jsonABI.Unpack`
 
-	Divvy               int64
 	Status              uint
 	HertzCost           uint64
 	CumulativeHertzUsed uint64
@@ -88,7 +87,6 @@ func (this DVMResult) MarshalJSON() ([]byte, error) {
 		ContractAddress          string `json:"contractAddress"`
 		ContractMethod           string `json:"ContractMethod"`
 		ContractMethodExecResult string `json:"contractMethodExecResult"`
-		Divvy                    int64  `json:"divvy"`
 		HertzCost                uint64 `json:"hertzCost"`
 	}{
 		From:                     crypto.Encode(this.From[:]),
@@ -96,7 +94,6 @@ func (this DVMResult) MarshalJSON() ([]byte, error) {
 		ContractAddress:          crypto.Encode(this.ContractAddress[:]),
 		ContractMethod:           this.ContractMethod,
 		ContractMethodExecResult: methodResult,
-		Divvy:                    this.Divvy,
 		HertzCost:                this.HertzCost,
 	})
 }
@@ -47,7 +47,6 @@ var (
 	DefaultGas      = big.NewInt(1000000000000)
 	DefaultGasPrice = big.NewInt(0)
 	DefaultGasLimit = 1000000000000
-	DefaultDivvy    = int64(0)
 )
 
 // VMStateHelper - Helps load and save Smart Contract storage state
@@ -41,6 +41,7 @@ import (
 // WithHttp -
 func (this *LocalAPIService) WithHttp() *LocalAPIService {
 	services.GetHttpRouter().HandleFunc("/v1/local/transfer", this.tranferHandler).Methods("POST")
+	services.GetHttpRouter().HandleFunc("/v1/local/batchTransfer", this.batchTransferHandler).Methods("POST")
 	services.GetHttpRouter().HandleFunc("/v1/local/deploy", this.deployHandler).Methods("POST")
 	services.GetHttpRouter().HandleFunc("/v1/local/execute", this.executeHandler).Methods("POST")
 	services.GetHttpRouter().HandleFunc("/v1/local/packageTx", this.getPackageTxHandler).Methods("POST")
@@ -120,6 +121,59 @@ func (this *LocalAPIService) tranferHandler(responseWriter http.ResponseWriter,
 	responseWriter.Write([]byte(response))
 }
 
+func (this *LocalAPIService) batchTransferHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	if !checkAuth(responseWriter, request) {
+		responseWriter.Header().Set("WWW-Authenticate", `realm="Dispatch Local"`)
+		responseWriter.WriteHeader(401)
+		responseWriter.Write([]byte("401 Unauthorized\n"))
+		return
+	}
+	// Read Object from payload
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		utils.Error("unable to read HTTP body of request", err)
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusInternalError, err), http.StatusInternalServerError)
+		return
+	}
+
+	batchTransfer := &BatchTransfer{}
+	err = json.Unmarshal(body, batchTransfer)
+	if err != nil {
+		utils.Error("unable to read HTTP body of request", err)
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusInternalError, err), http.StatusInternalServerError)
+		return
+	}
+	outputs := make([]types.Output, 0, len(batchTransfer.Transfers))
+	for _, transfer := range batchTransfer.Transfers {
+		outputs = append(outputs, types.Output{To: transfer.To, Value: transfer.Amount})
+	}
+
+	// Invoke SDK
+	var delegates = dapos.GetDAPoSService().GetDelegateNodes().Data.([]*types.Node)
+	if len(delegates) <= 0 {
+		utils.Error("no delegates found")
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"no delegates found"}`), http.StatusInternalServerError)
+		return
+	}
+
+	response, err := sdk.BatchTransferTokens(
+		*delegates[0],
+		types.GetAccount().PrivateKey,
+		types.GetAccount().Address,
+		outputs,
+	)
+
+	// Send Reply
+	if err != nil {
+		utils.Error("error executing Local API", err)
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusInternalError, err), http.StatusInternalServerError)
+		return
+	}
+
+	setHeaders(&responseWriter)
+	responseWriter.Write([]byte(response))
+}
+
 func (this *LocalAPIService) deployHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	if !checkAuth(responseWriter, request) {
 		responseWriter.Header().Set("WWW-Authenticate", `realm="Dispatch Local"`)
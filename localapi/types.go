@@ -6,6 +6,11 @@ type Transfer struct {
 	Amount int64  `json:"amount"`
 }
 
+// BatchTransfer -
+type BatchTransfer struct {
+	Transfers []Transfer `json:"transfers"`
+}
+
 // Deploy -
 type Deploy struct {
 	ByteCode string `json:"byteCode"`
@@ -18,13 +18,23 @@
 package main
 
 import (
+	"os"
+
 	"github.com/dispatchlabs/disgo/bootstrap"
 	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/disgover"
 )
 
 func main() {
 	utils.InitMainPackagePath()
 	utils.InitializeLogger()
+	rolledBack, err := disgover.CountBoot(".")
+	if err != nil {
+		utils.Error(err)
+	}
+	if rolledBack {
+		os.Exit(0)
+	}
 	server := bootstrap.NewServer()
 	server.Go()
 }
@@ -94,9 +94,19 @@ func CreateAccount(name_optional ...string) (*types.Account, error) {
 
 // GetAccount - Get account details
 func GetAccount(delegateNode types.Node, address string) (*types.Account, error) {
+	return getAccount(fmt.Sprintf("http://%s:%d/v1/accounts/%s", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port, address))
+}
+
+// GetAccountByName - Get details of the account that holds the name
+func GetAccountByName(delegateNode types.Node, name string) (*types.Account, error) {
+	return getAccount(fmt.Sprintf("http://%s:%d/v1/names/%s", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port, name))
+}
+
+// getAccount
+func getAccount(url string) (*types.Account, error) {
 
 	// Get account
-	httpResponse, err := http.Get(fmt.Sprintf("http://%s:%d/v1/accounts/%s", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port, address))
+	httpResponse, err := http.Get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +170,267 @@ func TransferTokens(delegateNode types.Node, privateKey string, from string, to
 	if err != nil {
 		return "", err
 	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	// Post transaction.
+	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/transactions", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port), "application/json", bytes.NewBuffer([]byte(transaction.String())))
+	if err != nil {
+		return "", err
+	}
+	defer httpResponse.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// Unmarshal response.
+	var response *types.Response
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return "", err
+	}
+
+	// Status?
+	if response.Status != types.StatusPending {
+		return "", errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+
+	return transaction.Hash, nil
+}
+
+// TransferTokensWithFee - Send tokens FROM TO paying the fee to the delegates, get the TX hash as result
+func TransferTokensWithFee(delegateNode types.Node, privateKey string, from string, to string, tokens int64, fee int64) (string, error) {
+	transaction, err := types.NewTransferTokensTransaction(privateKey, from, to, tokens, 0, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	err = transaction.SetFee(fee, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// BatchTransferTokens - Send tokens FROM to many recipients in one transaction
+func BatchTransferTokens(delegateNode types.Node, privateKey string, from string, outputs []types.Output) (string, error) {
+	// Create batch transfer tokens transaction.
+	transaction, err := types.NewBatchTransferTokensTransaction(privateKey, from, outputs, 0, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	// Post transaction.
+	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/transactions", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port), "application/json", bytes.NewBuffer([]byte(transaction.String())))
+	if err != nil {
+		return "", err
+	}
+	defer httpResponse.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return "", err
+	}
+
+	// Unmarshal response.
+	var response *types.Response
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return "", err
+	}
+
+	// Status?
+	if response.Status != types.StatusPending {
+		return "", errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+
+	return transaction.Hash, nil
+}
+
+// CreateMultisigAccount - Create an account spent with threshold of the signers' signatures, get the TX hash and the account's address as result
+func CreateMultisigAccount(delegateNode types.Node, privateKey string, from string, signers []string, threshold int) (string, string, error) {
+	// Create multisig transaction.
+	transaction, err := types.NewCreateMultisigTransaction(privateKey, from, signers, threshold, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	hash, err := SendTransaction(delegateNode, transaction)
+	if err != nil {
+		return "", "", err
+	}
+	return hash, transaction.MultisigAddress(), nil
+}
+
+// LockTokens - Send tokens FROM TO that cannot be spent before the unlock time, get the TX hash as result
+func LockTokens(delegateNode types.Node, privateKey string, from string, to string, tokens int64, unlockTime time.Time) (string, error) {
+	// Create lock tokens transaction.
+	transaction, err := types.NewLockTokensTransaction(privateKey, from, to, tokens, 0, utils.ToMilliSeconds(time.Now()), utils.ToMilliSeconds(unlockTime))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// TransferTokensToName - Send tokens FROM to the account holding the name when the transfer executes, get the TX hash as result
+func TransferTokensToName(delegateNode types.Node, privateKey string, from string, name string, tokens int64) (string, error) {
+	transaction, err := types.NewTransferTokensToNameTransaction(privateKey, from, name, tokens, 0, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// RegisterName - Claim a name for the FROM account, get the TX hash as result
+func RegisterName(delegateNode types.Node, privateKey string, from string, name string) (string, error) {
+	transaction, err := types.NewRegisterNameTransaction(privateKey, from, name, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// TransferName - Hand the FROM account's name to the TO account, get the TX hash as result
+func TransferName(delegateNode types.Node, privateKey string, from string, to string, name string) (string, error) {
+	transaction, err := types.NewTransferNameTransaction(privateKey, from, to, name, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// ReleaseName - Give up the FROM account's name, get the TX hash as result
+func ReleaseName(delegateNode types.Node, privateKey string, from string, name string) (string, error) {
+	transaction, err := types.NewReleaseNameTransaction(privateKey, from, name, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// Stake - Bond tokens of FROM to the delegate, get the TX hash as result
+func Stake(delegateNode types.Node, privateKey string, from string, delegate string, tokens int64) (string, error) {
+	transaction, err := types.NewStakeTransaction(privateKey, from, delegate, tokens, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// Unstake - Unbond tokens of FROM from the delegate, spendable after the unbonding period, get the TX hash as result
+func Unstake(delegateNode types.Node, privateKey string, from string, delegate string, tokens int64) (string, error) {
+	transaction, err := types.NewUnstakeTransaction(privateKey, from, delegate, tokens, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// ProposeParameter - Propose the value of a network parameter from the activation time (milliseconds) on, get the TX hash as result
+func ProposeParameter(delegateNode types.Node, privateKey string, from string, parameter string, value int64, activationTime int64) (string, error) {
+	transaction, err := types.NewProposeParameterTransaction(privateKey, from, parameter, value, activationTime, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// ProposeDelegate - Propose adding or removing (types.ParameterAddDelegate or types.ParameterRemoveDelegate) a delegate from the activation time (milliseconds) on, get the TX hash as result
+func ProposeDelegate(delegateNode types.Node, privateKey string, from string, parameter string, delegate string, activationTime int64) (string, error) {
+	transaction, err := types.NewProposeDelegateTransaction(privateKey, from, parameter, delegate, activationTime, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// Vote - Vote for or against a proposal with the tokens FROM staked, get the TX hash as result
+func Vote(delegateNode types.Node, privateKey string, from string, proposal string, approve bool) (string, error) {
+	transaction, err := types.NewVoteTransaction(privateKey, from, proposal, approve, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return "", err
+	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
+	return SendTransaction(delegateNode, transaction)
+}
+
+// forNetwork - Signs the transaction for the delegate's network, the default network when the delegate has no network id
+func forNetwork(delegateNode types.Node, transaction *types.Transaction, privateKey string) error {
+	if delegateNode.NetworkId == "" {
+		return nil
+	}
+	return transaction.SetNetworkId(delegateNode.NetworkId, privateKey)
+}
+
+// NewMultisigTransfer - An unsigned transfer from a multisig account, pass it to each signer's SignTransaction and then SendTransaction
+// (on another network than the default, SetNetworkId with a blank private key first)
+func NewMultisigTransfer(from string, to string, tokens int64) (*types.Transaction, error) {
+	return types.NewUnsignedTransferTokensTransaction(from, to, tokens, 0, utils.ToMilliSeconds(time.Now()))
+}
+
+// SignTransaction - Adds the signer's signature, does not need a delegate so it can be done offline
+func SignTransaction(transaction *types.Transaction, privateKey string) error {
+	return transaction.Cosign(privateKey)
+}
+
+// SendTransaction - Post a signed transaction, get the TX hash as result
+func SendTransaction(delegateNode types.Node, transaction *types.Transaction) (string, error) {
 
 	// Post transaction.
 	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/transactions", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port), "application/json", bytes.NewBuffer([]byte(transaction.String())))
@@ -196,6 +467,10 @@ func DeploySmartContract(delegateNode types.Node, privateKey string, from string
 	if err != nil {
 		return "", err
 	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
 
 	// Post transaction.
 	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/transactions", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port), "application/json", bytes.NewBuffer([]byte(transaction.String())))
@@ -232,6 +507,10 @@ func ExecuteSmartContractTransaction(delegateNode types.Node, privateKey string,
 	if err != nil {
 		return "", err
 	}
+	err = forNetwork(delegateNode, transaction, privateKey)
+	if err != nil {
+		return "", err
+	}
 
 	// Post transaction.
 	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/transactions", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port), "application/json", bytes.NewBuffer([]byte(transaction.String())))
@@ -311,16 +590,54 @@ func GetTransaction(delegateNode types.Node, hash string) (*types.Transaction, e
 	return transaction, nil
 }
 
-// GetReceipt - Get details about a transaction base on a TX hash
+// GetReceipt - Get details about a transaction base on a TX hash, failed transactions have a receipt too
 func GetReceipt(delegateNode types.Node, hash string) (*types.Receipt, error) {
 
-	// Get transaction.
-	transaction, err := GetTransaction(delegateNode, hash)
+	// Get receipt.
+	httpResponse, err := http.Get(fmt.Sprintf("http://%s:%d/v1/receipts/%s", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port, hash))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	// Ready body.
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal response.
+	var response *types.Response
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status?
+	if response.Status != types.StatusOk {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+
+	// Unmarshal to RawMessage.
+	var jsonMap map[string]json.RawMessage
+	err = json.Unmarshal(body, &jsonMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Data?
+	if jsonMap["data"] == nil {
+		return nil, errors.Errorf("'data' is missing from response")
+	}
+
+	// Unmarshal receipt.
+	var receipt *types.Receipt
+	err = json.Unmarshal(jsonMap["data"], &receipt)
 	if err != nil {
 		return nil, err
 	}
 
-	return &transaction.Receipt, nil
+	return receipt, nil
 }
 
 // GetTransactions - Get details about sent transactions for a node
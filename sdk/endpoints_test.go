@@ -3,6 +3,8 @@ package sdk
 import (
 	"testing"
 	"fmt"
+
+	"github.com/dispatchlabs/disgo/commons/types"
 )
 
 func TestCreateAccount(t *testing.T) {
@@ -21,6 +23,50 @@ func TestCreateAccount(t *testing.T) {
 	fmt.Printf("%v\n", account.ToPrettyJson())
 }
 
+func TestSignTransactionOffline(t *testing.T) {
+	signers := make([]*types.Account, 0)
+	addresses := make([]string, 0)
+	for i := 0; i < 3; i++ {
+		account, err := CreateAccount()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers = append(signers, account)
+		addresses = append(addresses, account.Address)
+	}
+	multisig, err := types.NewCreateMultisigTransaction(signers[0].PrivateKey, signers[0].Address, addresses, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transaction, err := NewMultisigTransfer(multisig.MultisigAddress(), signers[0].Address, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each signer signs its own copy of the JSON.
+	err = SignTransaction(transaction, signers[0].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.VerifySignatures(addresses, 2) == nil {
+		t.Error("one signature met a threshold of two")
+	}
+	transaction, err = types.ToTransactionFromJson([]byte(transaction.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = SignTransaction(transaction, signers[2].PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := transaction.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := transaction.VerifySignatures(addresses, 2); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // func TestGetDelegates(t *testing.T) {
 // 	// Testing WITHOUT seedUrl
 // 	delegates, err := GetDelegates()
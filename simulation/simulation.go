@@ -0,0 +1,638 @@
+/*
+ *    This file is part of Disgo library.
+ *
+ *    The Disgo library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package simulation runs a whole network of nodes inside one process. Every node has its own DB,
+// cache, keys, config and events; they talk over an in-memory transport.Network and share a
+// utils.VirtualClock, the network's latency included, so the consensus timeouts can be driven deterministically.
+package simulation
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/transport"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos"
+	"github.com/dispatchlabs/disgo/disgover"
+	"github.com/pkg/errors"
+)
+
+// GenesisBalance - Tokens given to the simulation's treasury account
+const GenesisBalance = 1000000000
+
+// UnbondingPeriod - Short enough for a test to Execute past it
+const UnbondingPeriod = 10 * time.Minute
+
+// settleRounds - Times in a row every node has to be idle for the simulation to have settled
+const settleRounds = 100
+
+// settleTimeout - Real time Execute lets the nodes settle after each timer
+const settleTimeout = 10 * time.Second
+
+// Node - One simulated node
+type Node struct {
+	Account  *types.Account
+	Config   *types.Config
+	Db       *services.DbService
+	Events   *utils.EventManager
+	DisGover *disgover.DisGoverService
+	DAPoS    *dapos.DAPoSService
+	dir      string
+	clock    *nodeClock
+	reboots  int32
+}
+
+// nodeClock - The simulation's clock as seen by one node, a crashed node's timers never fire
+type nodeClock struct {
+	clock   *utils.VirtualClock
+	mutex   sync.Mutex
+	crashed bool
+}
+
+// Now
+func (this *nodeClock) Now() time.Time {
+	return this.clock.Now()
+}
+
+// After
+func (this *nodeClock) After(d time.Duration) <-chan time.Time {
+	out := make(chan time.Time, 1)
+	if this.isCrashed() {
+		return out
+	}
+	in := this.clock.After(d)
+	go func() {
+		now := <-in
+		if !this.isCrashed() {
+			out <- now
+		}
+	}()
+	return out
+}
+
+// crash
+func (this *nodeClock) crash() {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.crashed = true
+}
+
+// isCrashed
+func (this *nodeClock) isCrashed() bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.crashed
+}
+
+// Simulation
+type Simulation struct {
+	Network   *transport.Network
+	Clock     *utils.VirtualClock
+	Seed      *Node
+	Seeds     []*Node
+	Delegates []*Node
+	Treasury  *types.Account
+	Genesis   *types.Genesis
+	dir       string
+	next      int
+	rebooting sync.WaitGroup
+}
+
+// NewSimulation - Creates a seed and the number of delegates, call Start to boot them
+func NewSimulation(delegates int) (*Simulation, error) {
+	return NewSimulationWithSeeds(1, delegates)
+}
+
+// NewSimulationWithSeeds - Creates the number of seeds and delegates, every node is configured with every seed and
+// Seed is the first of them
+func NewSimulationWithSeeds(seeds int, delegates int) (*Simulation, error) {
+	treasury := NewAccount()
+	genesis := &types.Genesis{
+		ChainId:     types.DefaultChainId,
+		Time:        utils.ToMilliSeconds(time.Now()),
+		Allocations: []types.Allocation{{Address: treasury.Address, Value: GenesisBalance}},
+	}
+	return newSimulation(seeds, delegates, treasury, genesis)
+}
+
+// NewSimulationWithGenesis - Creates a seed and the number of delegates starting from the genesis, the treasury should
+// hold the tokens the tests spend
+func NewSimulationWithGenesis(delegates int, treasury *types.Account, genesis *types.Genesis) (*Simulation, error) {
+	return newSimulation(1, delegates, treasury, genesis)
+}
+
+// newSimulation
+func newSimulation(seeds int, delegates int, treasury *types.Account, genesis *types.Genesis) (*Simulation, error) {
+	dir, err := ioutil.TempDir("", "disgo-simulation-")
+	if err != nil {
+		return nil, err
+	}
+	clock := utils.NewVirtualClock(time.Unix(0, genesis.Time*int64(time.Millisecond)))
+	this := &Simulation{
+		Network:  transport.NewNetworkWithClock(clock),
+		Clock:    clock,
+		Treasury: treasury,
+		Genesis:  genesis,
+		dir:      dir,
+	}
+
+	// Genesis, the delegates and the unbonding period are the simulation's unless it names its own.
+	delegateAccounts := make([]*types.Account, 0, delegates)
+	for i := 0; i < delegates; i++ {
+		delegateAccounts = append(delegateAccounts, NewAccount())
+	}
+	if genesis.Parameters == nil {
+		parameters := types.NewParameters()
+		parameters.UnbondingPeriod = int64(UnbondingPeriod / time.Millisecond)
+		genesis.Parameters = &parameters
+	}
+	if len(genesis.Delegates) == 0 {
+		for _, delegateAccount := range delegateAccounts {
+			genesis.Delegates = append(genesis.Delegates, delegateAccount.Address)
+		}
+	}
+	bytes, err := json.Marshal(genesis)
+	if err != nil {
+		return nil, err
+	}
+	genesisFile := filepath.Join(dir, "genesis.json")
+	err = ioutil.WriteFile(genesisFile, bytes, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	seedAccounts := make([]*types.Account, 0, seeds)
+	seedNodes := make([]*types.Node, 0, seeds)
+	for i := 0; i < seeds; i++ {
+		host := "seed"
+		if i > 0 {
+			host = fmt.Sprintf("seed-%d", i)
+		}
+		seedAccount := NewAccount()
+		seedAccounts = append(seedAccounts, seedAccount)
+		seedNodes = append(seedNodes, &types.Node{
+			Address:      seedAccount.Address,
+			GrpcEndpoint: &types.Endpoint{Host: host, Port: 1973},
+			HttpEndpoint: &types.Endpoint{Host: host, Port: 1975},
+			Type:         types.TypeSeed,
+		})
+	}
+	for _, seedAccount := range seedAccounts {
+		node, err := this.newNode(seedAccount, seedNodes, genesisFile)
+		if err != nil {
+			this.Stop()
+			return nil, err
+		}
+		this.Seeds = append(this.Seeds, node)
+	}
+	this.Seed = this.Seeds[0]
+	for _, delegateAccount := range delegateAccounts {
+		node, err := this.newNode(delegateAccount, seedNodes, genesisFile)
+		if err != nil {
+			this.Stop()
+			return nil, err
+		}
+		this.Delegates = append(this.Delegates, node)
+	}
+	return this, nil
+}
+
+// newNode
+func (this *Simulation) newNode(account *types.Account, seeds []*types.Node, genesisFile string) (*Node, error) {
+	name := fmt.Sprintf("node-%d", this.next)
+	this.next++
+
+	config := types.GetDefaultConfig()
+	config.HttpEndpoint = &types.Endpoint{Host: name, Port: 1975}
+	config.GrpcEndpoint = &types.Endpoint{Host: name, Port: 1973}
+	config.Seeds = seeds
+	config.GenesisFile = genesisFile
+	config.DhtRefreshInterval = 0
+	config.HealthCheckInterval = 0
+	config.MempoolInterval = 0
+	for _, seed := range seeds {
+		if account.Address == seed.Address {
+			config.GrpcEndpoint = seed.GrpcEndpoint
+			config.HttpEndpoint = seed.HttpEndpoint
+		}
+	}
+
+	dir := filepath.Join(this.dir, name)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	node := &Node{Account: account, Config: config, dir: dir}
+	this.boot(node)
+	return node, nil
+}
+
+// boot - Creates the node's services over its DB directory
+func (this *Simulation) boot(node *Node) {
+	node.Db = services.NewDbService(node.dir)
+	node.Events = utils.NewEventManager()
+	node.clock = &nodeClock{clock: this.Clock}
+	node.DisGover = disgover.NewDisGoverService(node.Db, node.Account, node.Config, node.Events, node.clock).WithTransport(disgover.NewMemoryTransport(this.Network, node.Account.Address)).WithSoftwareDirectory(node.dir).WithReboot(func() { this.reboot(node) })
+	node.DAPoS = dapos.NewDAPoSService(node.Db, node.Account, node.Config, node.Events, node.clock, node.DisGover).WithTransport(dapos.NewMemoryTransport(this.Network, node.Account.Address))
+}
+
+// crash - Takes the node off the network and closes its DB once it answered the calls it was serving, nothing it held
+// in memory survives
+func (this *Simulation) crash(node *Node) {
+	if node.clock.isCrashed() {
+		return
+	}
+	node.clock.crash()
+	node.DisGover.Stop()
+	this.Disconnect(node)
+	this.Network.Drain(node.Account.Address)
+	node.Db.Close()
+}
+
+// Disconnect - Takes the node off the network, it keeps running on its own
+func (this *Simulation) Disconnect(node *Node) {
+	this.Network.Unregister("dapos", node.Account.Address)
+	this.Network.Unregister("disgover", node.Account.Address)
+}
+
+// Reconnect - Puts a disconnected node back on the network
+func (this *Simulation) Reconnect(node *Node) {
+	this.Network.Register("dapos", node.Account.Address, node.DAPoS)
+	this.Network.Register("disgover", node.Account.Address, node.DisGover)
+}
+
+// Restart - Crashes the node and boots it again from what it persisted, returns once its DAPoS service is running and
+// the nodes settled
+func (this *Simulation) Restart(node *Node, timeout time.Duration) error {
+	this.crash(node)
+	// Counted the way main does, a rolled back node boots the restored disgo straight away.
+	_, err := disgover.CountBoot(node.dir)
+	if err != nil {
+		utils.Error(err)
+	}
+	this.boot(node)
+
+	finished := make(chan bool, 1)
+	node.Events.On(types.Events.DAPoSServiceInitFinished, func() { finished <- true })
+	node.DAPoS.Go()
+	node.DisGover.Go()
+	select {
+	case <-finished:
+		return this.Settle(timeout)
+	case <-time.After(timeout):
+		return errors.New(fmt.Sprintf("timed out waiting for node to restart [address=%s]", node.Account.Address))
+	}
+}
+
+// reboot - Boots the node again the way its supervisor would once disgo exits, a node rebooting while it boots
+// finishes booting first so its DB is not closed under it
+func (this *Simulation) reboot(node *Node) {
+	booted := make(chan bool, 1)
+	node.Events.On(types.Events.DAPoSServiceInitFinished, func() {
+		select {
+		case booted <- true:
+		default:
+		}
+	})
+	this.rebooting.Add(1)
+	go func() {
+		defer this.rebooting.Done()
+		select {
+		case <-booted:
+		case <-time.After(10 * time.Second):
+		}
+		err := this.Restart(node, time.Minute)
+		if err != nil {
+			utils.Error(err)
+			return
+		}
+		atomic.AddInt32(&node.reboots, 1)
+	}()
+}
+
+// Reboots - Times the node rebooted itself, eg; into a release
+func (this *Node) Reboots() int {
+	return int(atomic.LoadInt32(&this.reboots))
+}
+
+// Start - Boots the seeds then every delegate, returns once every delegate knows every other delegate and every node
+// joined the DHT
+func (this *Simulation) Start(timeout time.Duration) error {
+	finished := make(chan bool, len(this.Nodes()))
+	for _, node := range this.Nodes() {
+		node.Events.On(types.Events.DAPoSServiceInitFinished, func() { finished <- true })
+		node.DAPoS.Go()
+		node.DisGover.Go()
+	}
+
+	deadline := time.Now().Add(timeout)
+	for i := 0; i < len(this.Nodes()); i++ {
+		select {
+		case <-finished:
+		case <-time.After(deadline.Sub(time.Now())):
+			return errors.New("timed out waiting for DAPoS to start")
+		}
+	}
+	for _, node := range this.Delegates {
+		for {
+			delegates, err := types.ToNodesByTypeFromCache(node.Db.GetCache(), types.TypeDelegate)
+			if err != nil {
+				return err
+			}
+			if len(delegates) == len(this.Delegates) {
+				break
+			}
+			if time.Now().After(deadline) {
+				return errors.New(fmt.Sprintf("delegate only found %d delegates [address=%s]", len(delegates), node.Account.Address))
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	return this.Settle(deadline.Sub(time.Now()))
+}
+
+// Stop - Closes every DB and removes the simulation's files once the nodes that reboot booted
+func (this *Simulation) Stop() {
+	this.rebooting.Wait()
+	for _, node := range this.Nodes() {
+		this.crash(node)
+	}
+	os.RemoveAll(this.dir)
+}
+
+// Nodes - The seeds and every delegate
+func (this *Simulation) Nodes() []*Node {
+	nodes := make([]*Node, 0)
+	nodes = append(nodes, this.Seeds...)
+	return append(nodes, this.Delegates...)
+}
+
+// Addresses - Addresses of the given nodes
+func Addresses(nodes ...*Node) []string {
+	addresses := make([]string, 0)
+	for _, node := range nodes {
+		addresses = append(addresses, node.Account.Address)
+	}
+	return addresses
+}
+
+// Transfer - Signs a transfer for the genesis network with the current virtual time and submits it to the delegate
+func (this *Simulation) Transfer(delegate *Node, from *types.Account, to string, value int64) (*types.Transaction, *types.Response, error) {
+	transaction, err := types.NewTransferTokensTransaction(from.PrivateKey, from.Address, to, value, 0, utils.ToMilliSeconds(this.Clock.Now()))
+	if err != nil {
+		return nil, nil, err
+	}
+	err = transaction.SetNetworkId(this.Genesis.ChainId, from.PrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return transaction, delegate.DAPoS.NewTransaction(transaction), nil
+}
+
+// Run - Submits the workload round robin across the delegates
+func (this *Simulation) Run(workload []Transfer) ([]*types.Transaction, error) {
+	transactions := make([]*types.Transaction, 0)
+	for i, transfer := range workload {
+		delegate := this.Delegates[i%len(this.Delegates)]
+		transaction, response, err := this.Transfer(delegate, transfer.From, transfer.To, transfer.Value)
+		if err != nil {
+			return nil, err
+		}
+		if response.Status != types.StatusPending {
+			return nil, errors.New(fmt.Sprintf("transfer was not accepted [status=%s, humanReadableStatus=%s]", response.Status, response.HumanReadableStatus))
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// Settle - Lets the nodes drain their channels until none of them has anything left to do before the virtual clock
+// moves on, work waiting for a message in transit waits for the clock too. Timeout (real time) only guards against
+// a node that never goes idle
+func (this *Simulation) Settle(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for rounds := 0; rounds < settleRounds; {
+		if time.Now().After(deadline) {
+			return errors.New("timed out waiting for gossip to settle")
+		}
+		// Yield to the goroutines the last message or timer woke up.
+		runtime.Gosched()
+		if this.idle() {
+			rounds++
+		} else {
+			rounds = 0
+		}
+	}
+	return nil
+}
+
+// idle - Every running node's services are idle, or wait for messages in transit
+func (this *Simulation) idle() bool {
+	working := 0
+	for _, node := range this.Nodes() {
+		if !node.clock.isCrashed() {
+			working += node.DAPoS.Working() + node.DisGover.Working()
+		}
+	}
+	return int64(working) <= this.Network.InTransit()
+}
+
+// Execute - Advances the virtual clock from one timer to the next for at most max, settling the nodes after each so
+// every queued transaction that is due executes
+func (this *Simulation) Execute(max time.Duration) {
+	end := this.Clock.Now().Add(max)
+	for {
+		next, ok := this.Clock.Next()
+		if !ok || next.After(end) {
+			break
+		}
+		this.Clock.Advance(next.Sub(this.Clock.Now()))
+		err := this.Settle(settleTimeout)
+		if err != nil {
+			utils.Error(err)
+		}
+	}
+}
+
+// Balance - Balance of the address in the node's DB, zero when the account does not exist
+func (this *Node) Balance(address string) (int64, error) {
+	txn := this.Db.NewTxn(false)
+	defer txn.Discard()
+	account, err := types.ToAccountByAddress(txn, address)
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return account.Balance.Int64(), nil
+}
+
+// WaitForBalances - Waits until every bookkeeper holds the expected balances
+func (this *Simulation) WaitForBalances(expected map[string]int64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := this.checkBalances(expected)
+		if err == nil || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// checkBalances
+func (this *Simulation) checkBalances(expected map[string]int64) error {
+	for _, node := range this.Delegates {
+		if !node.Config.IsBookkeeper {
+			continue
+		}
+		for address, value := range expected {
+			balance, err := node.Balance(address)
+			if err != nil {
+				return err
+			}
+			if balance != value {
+				return errors.New(fmt.Sprintf("bookkeeper has not converged [bookkeeper=%s, address=%s, balance=%d, expected=%d]", node.Account.Address, address, balance, value))
+			}
+		}
+	}
+	return nil
+}
+
+// WaitForReceipt - Waits until every bookkeeper has the receipt of the transaction with the status
+func (this *Simulation) WaitForReceipt(hash, status string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := this.checkReceipt(hash, status)
+		if err == nil || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// checkReceipt
+func (this *Simulation) checkReceipt(hash, status string) error {
+	for _, node := range this.Delegates {
+		if !node.Config.IsBookkeeper {
+			continue
+		}
+		receipt, ok := node.DAPoS.GetReceipt(hash).Data.(*types.Receipt)
+		if !ok || receipt.Status != status {
+			return errors.New(fmt.Sprintf("bookkeeper has no receipt with status %s [bookkeeper=%s, hash=%s]", status, node.Account.Address, hash))
+		}
+	}
+	return nil
+}
+
+// WaitForName - Waits until the name resolves to the address on every bookkeeper, an empty address waits until nobody holds it
+func (this *Simulation) WaitForName(name, address string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := this.checkName(name, address)
+		if err == nil || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// checkName
+func (this *Simulation) checkName(name, address string) error {
+	for _, node := range this.Delegates {
+		if !node.Config.IsBookkeeper {
+			continue
+		}
+		holder := ""
+		txn := node.Db.NewTxn(false)
+		account, err := types.ToAccountByName(txn, name)
+		txn.Discard()
+		if err == nil {
+			holder = account.Address
+		} else if err != badger.ErrKeyNotFound {
+			return err
+		}
+		if holder != address {
+			return errors.New(fmt.Sprintf("bookkeeper has not converged [bookkeeper=%s, name=%s, holder=%s, expected=%s]", node.Account.Address, name, holder, address))
+		}
+	}
+	return nil
+}
+
+// WaitForProposal - Waits until the proposal has the status on every bookkeeper
+func (this *Simulation) WaitForProposal(hash, status string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := this.checkProposal(hash, status)
+		if err == nil || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// checkProposal
+func (this *Simulation) checkProposal(hash, status string) error {
+	for _, node := range this.Delegates {
+		if !node.Config.IsBookkeeper {
+			continue
+		}
+		txn := node.Db.NewTxn(false)
+		proposal, err := types.ToProposal(txn, hash)
+		txn.Discard()
+		if err != nil {
+			return err
+		}
+		if proposal.Status != status {
+			return errors.New(fmt.Sprintf("bookkeeper has not converged [bookkeeper=%s, proposal=%s, status=%s, expected=%s]", node.Account.Address, hash, proposal.Status, status))
+		}
+	}
+	return nil
+}
+
+// Transfer - One transfer in a workload
+type Transfer struct {
+	From  *types.Account
+	To    string
+	Value int64
+}
+
+// NewAccount - Account with a new key pair
+func NewAccount() *types.Account {
+	publicKey, privateKey := crypto.GenerateKeyPair()
+	now := time.Now()
+	return &types.Account{
+		Address:    hex.EncodeToString(crypto.ToAddress(publicKey)),
+		PrivateKey: hex.EncodeToString(privateKey),
+		Balance:    big.NewInt(0),
+		Created:    now,
+		Updated:    now,
+	}
+}
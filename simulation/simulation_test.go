@@ -0,0 +1,1634 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dapos"
+	"github.com/dispatchlabs/disgo/dapos/proto"
+	"github.com/dispatchlabs/disgo/disgover"
+	disgoverproto "github.com/dispatchlabs/disgo/disgover/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newStartedSimulation(t *testing.T, delegates int) *Simulation {
+	simulation, err := NewSimulation(delegates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = simulation.Start(10 * time.Second)
+	if err != nil {
+		simulation.Stop()
+		t.Fatal(err)
+	}
+	return simulation
+}
+
+func TestTransfersConverge(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	alice := NewAccount()
+	bob := NewAccount()
+	transactions, err := simulation.Run([]Transfer{
+		{From: simulation.Treasury, To: alice.Address, Value: 1000},
+		{From: simulation.Treasury, To: bob.Address, Value: 500},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+
+	err = simulation.WaitForBalances(map[string]int64{
+		simulation.Treasury.Address: GenesisBalance - 1500,
+		alice.Address:               1000,
+		bob.Address:                 500,
+	}, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, node := range simulation.Delegates {
+		for _, transaction := range transactions {
+			response := node.DAPoS.GetReceipt(transaction.Hash)
+			receipt, ok := response.Data.(*types.Receipt)
+			if !ok || receipt.Status != types.StatusOk {
+				t.Fatalf("expected receipt with status %s [delegate=%s, response=%s]", types.StatusOk, node.Account.Address, response.String())
+			}
+			for _, event := range []string{types.EventReceived, types.EventFirstRumor, types.EventConsensusReached, types.EventQueued, types.EventExecuted, types.EventPersisted} {
+				if !receipt.HasEvent(event) {
+					t.Fatalf("expected receipt timeline with %s [delegate=%s, receipt=%s]", event, node.Account.Address, receipt.String())
+				}
+			}
+		}
+	}
+}
+
+func TestBatchTransferIsAtomic(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	alice := NewAccount()
+	bob := NewAccount()
+	carol := NewAccount()
+	now := utils.ToMilliSeconds(simulation.Clock.Now())
+	batch, err := types.NewBatchTransferTokensTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, []types.Output{{To: alice.Address, Value: 100}, {To: bob.Address, Value: 200}}, 0, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tooLarge, err := types.NewBatchTransferTokensTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, []types.Output{{To: carol.Address, Value: 1}, {To: bob.Address, Value: GenesisBalance}}, 0, now+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, transaction := range []*types.Transaction{batch, tooLarge} {
+		response := simulation.Delegates[i].DAPoS.NewTransaction(transaction)
+		if response.Status != types.StatusPending {
+			t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+		}
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+
+	err = simulation.WaitForBalances(map[string]int64{
+		simulation.Treasury.Address: GenesisBalance - 300,
+		alice.Address:               100,
+		bob.Address:                 200,
+		carol.Address:               0,
+	}, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, address := range []string{alice.Address, bob.Address} {
+		response := simulation.Delegates[0].DAPoS.GetTransactionsByToAddress(address, "1", "10", "")
+		transactions, ok := response.Data.([]*types.Transaction)
+		if !ok || len(transactions) != 1 || transactions[0].Hash != batch.Hash {
+			t.Fatalf("expected the batch transfer for the recipient [address=%s, response=%s]", address, response.String())
+		}
+	}
+}
+
+func TestMultisigRequiresThreshold(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	signers := []*types.Account{NewAccount(), NewAccount(), NewAccount()}
+	addresses := []string{signers[0].Address, signers[1].Address, signers[2].Address}
+	create, err := types.NewCreateMultisigTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, addresses, 2, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response := simulation.Delegates[0].DAPoS.NewTransaction(create); response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+	multisig := create.MultisigAddress()
+	if _, err := simulation.Run([]Transfer{{From: simulation.Treasury, To: multisig, Value: 1000}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	if err := simulation.WaitForBalances(map[string]int64{multisig: 1000}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// One of two signatures.
+	recipient := NewAccount()
+	transaction, err := types.NewUnsignedTransferTokensTransaction(multisig, recipient.Address, 400, 0, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transaction.Cosign(signers[1].PrivateKey)
+	if response := simulation.Delegates[1].DAPoS.NewTransaction(transaction); response.Status != types.StatusInvalidTransaction {
+		t.Fatalf("expected %s [status=%s]", types.StatusInvalidTransaction, response.Status)
+	}
+
+	// Nor when a delegate gossips it.
+	rumor := types.NewRumor(simulation.Delegates[2].Account.PrivateKey, simulation.Delegates[2].Account.Address, transaction.Hash, simulation.Genesis.ChainId, utils.ToMilliSeconds(simulation.Clock.Now()))
+	gossip := types.NewGossip(*transaction)
+	gossip.Rumors = append(gossip.Rumors, *rumor)
+	if _, err := simulation.Delegates[1].DAPoS.GossipGrpc(context.Background(), &proto.Request{Payload: gossip.String()}); err == nil {
+		t.Fatal("expected the gossiped transaction not to verify")
+	}
+
+	// Two of two.
+	transaction.Cosign(signers[2].PrivateKey)
+	if response := simulation.Delegates[1].DAPoS.NewTransaction(transaction); response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s, humanReadableStatus=%s]", types.StatusPending, response.Status, response.HumanReadableStatus)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	err = simulation.WaitForBalances(map[string]int64{multisig: 600, recipient.Address: 400}, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLockedTokensAreReleased(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	alice := NewAccount()
+	bob := NewAccount()
+	unlock := simulation.Clock.Now().Add(10 * time.Minute)
+	lock, err := types.NewLockTokensTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, alice.Address, 500, 0, utils.ToMilliSeconds(simulation.Clock.Now()), utils.ToMilliSeconds(unlock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response := simulation.Delegates[0].DAPoS.NewTransaction(lock); response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+	if _, err := simulation.Run([]Transfer{{From: simulation.Treasury, To: alice.Address, Value: 100}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	if err := simulation.WaitForBalances(map[string]int64{alice.Address: 600}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	account, ok := simulation.Delegates[0].DAPoS.GetAccount(alice.Address).Data.(*types.Account)
+	if !ok || account.SpendableBalance(utils.ToMilliSeconds(simulation.Clock.Now())) != 100 {
+		t.Fatalf("expected 100 spendable tokens [account=%v]", account)
+	}
+
+	// Locked tokens cannot be spent.
+	transaction, response, err := simulation.Transfer(simulation.Delegates[1], alice, bob.Address, 150)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	deadline := time.Now().Add(10 * time.Second)
+	for _, node := range simulation.Delegates {
+		for {
+			receipt, ok := node.DAPoS.GetReceipt(transaction.Hash).Data.(*types.Receipt)
+			if ok && receipt.Status == types.StatusInsufficientTokens {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected receipt with status %s [delegate=%s]", types.StatusInsufficientTokens, node.Account.Address)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// Released once the lock ended.
+	simulation.Execute(15 * time.Minute)
+	for _, node := range simulation.Delegates {
+		for {
+			account, ok := node.DAPoS.GetAccount(alice.Address).Data.(*types.Account)
+			if ok && len(account.Locks) == 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the lock to be released [delegate=%s]", node.Account.Address)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if _, err := simulation.Run([]Transfer{{From: alice, To: bob.Address, Value: 550}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	if err := simulation.WaitForBalances(map[string]int64{alice.Address: 50, bob.Address: 550}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNameRegistry(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	alice := NewAccount()
+	bob := NewAccount()
+	carol := NewAccount()
+	submit := func(transaction *types.Transaction, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response := simulation.Delegates[0].DAPoS.NewTransaction(transaction); response.Status != types.StatusPending {
+			t.Fatalf("expected %s [status=%s, humanReadableStatus=%s]", types.StatusPending, response.Status, response.HumanReadableStatus)
+		}
+	}
+	execute := func() {
+		if err := simulation.Settle(10 * time.Second); err != nil {
+			t.Fatal(err)
+		}
+		simulation.Execute(time.Minute)
+	}
+
+	// First to register gets the name.
+	now := utils.ToMilliSeconds(simulation.Clock.Now())
+	submit(types.NewRegisterNameTransaction(alice.PrivateKey, alice.Address, "alice", now))
+	submit(types.NewRegisterNameTransaction(carol.PrivateKey, carol.Address, "alice", now+1))
+	execute()
+	if err := simulation.WaitForName("alice", alice.Address, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	response := simulation.Delegates[1].DAPoS.GetAccountByName("alice")
+	if account, ok := response.Data.(*types.Account); !ok || account.Address != alice.Address {
+		t.Fatalf("expected the account of alice [response=%s]", response.String())
+	}
+
+	// Sent to whoever holds the name when the transfer executes.
+	submit(types.NewTransferTokensToNameTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, "alice", 100, 0, utils.ToMilliSeconds(simulation.Clock.Now())))
+	execute()
+	if err := simulation.WaitForBalances(map[string]int64{alice.Address: 100}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	submit(types.NewTransferNameTransaction(alice.PrivateKey, alice.Address, bob.Address, "alice", utils.ToMilliSeconds(simulation.Clock.Now())))
+	execute()
+	if err := simulation.WaitForName("alice", bob.Address, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	submit(types.NewTransferTokensToNameTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, "alice", 50, 0, utils.ToMilliSeconds(simulation.Clock.Now())))
+	execute()
+	if err := simulation.WaitForBalances(map[string]int64{alice.Address: 100, bob.Address: 50}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Released names can be registered again.
+	submit(types.NewReleaseNameTransaction(bob.PrivateKey, bob.Address, "alice", utils.ToMilliSeconds(simulation.Clock.Now())))
+	execute()
+	if err := simulation.WaitForName("alice", "", 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	submit(types.NewRegisterNameTransaction(carol.PrivateKey, carol.Address, "alice", utils.ToMilliSeconds(simulation.Clock.Now())))
+	execute()
+	if err := simulation.WaitForName("alice", carol.Address, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFeesRewardTheDelegates(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	alice := NewAccount()
+	transaction, err := types.NewTransferTokensTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, alice.Address, 1000, 0, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := transaction.SetFee(42, simulation.Treasury.PrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if response := simulation.Delegates[0].DAPoS.NewTransaction(transaction); response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+
+	// 42 split four ways, two delegates get the remainder.
+	if err := simulation.WaitForBalances(map[string]int64{simulation.Treasury.Address: GenesisBalance - 1042, alice.Address: 1000}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	var rewarded int64
+	for _, delegate := range simulation.Delegates {
+		balance, err := simulation.Delegates[0].Balance(delegate.Account.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if balance != 10 && balance != 11 {
+			t.Fatalf("expected a share of the fee [delegate=%s, balance=%d]", delegate.Account.Address, balance)
+		}
+		rewarded += balance
+	}
+	if rewarded != 42 {
+		t.Fatalf("expected the whole fee to be paid [rewarded=%d]", rewarded)
+	}
+	for _, node := range simulation.Delegates {
+		receipt, ok := node.DAPoS.GetReceipt(transaction.Hash).Data.(*types.Receipt)
+		if !ok || receipt.Fee != 42 || len(receipt.Rewards) != 4 {
+			t.Fatalf("expected the fee and rewards on the receipt [delegate=%s, receipt=%v]", node.Account.Address, receipt)
+		}
+	}
+
+	// In the delegates' history.
+	response := simulation.Delegates[0].DAPoS.GetTransactionsByToAddress(simulation.Delegates[1].Account.Address, "1", "10", "")
+	transactions, ok := response.Data.([]*types.Transaction)
+	if !ok || len(transactions) != 1 || transactions[0].Hash != transaction.Hash {
+		t.Fatalf("expected the transaction in the delegate's history [response=%s]", response.String())
+	}
+}
+
+func TestStakeWeightsRewards(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	treasury := simulation.Treasury
+	first := simulation.Delegates[0].Account.Address
+	second := simulation.Delegates[1].Account.Address
+	now := utils.ToMilliSeconds(simulation.Clock.Now())
+	for i, transaction := range []*types.Transaction{
+		must(types.NewStakeTransaction(treasury.PrivateKey, treasury.Address, first, 3000, now)),
+		must(types.NewStakeTransaction(treasury.PrivateKey, treasury.Address, second, 1000, now+1)),
+	} {
+		if response := simulation.Delegates[i].DAPoS.NewTransaction(transaction); response.Status != types.StatusPending {
+			t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+		}
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	if err := simulation.WaitForBalances(map[string]int64{treasury.Address: GenesisBalance - 4000}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	delegateStake, ok := simulation.Delegates[2].DAPoS.GetDelegateStake(first).Data.(*types.DelegateStake)
+	if !ok || delegateStake.Total != 3000 || delegateStake.Stakers != 1 {
+		t.Fatalf("expected 3000 staked by one staker [delegateStake=%v]", delegateStake)
+	}
+
+	// Stake goes to the delegates of the genesis only.
+	outsider := must(types.NewStakeTransaction(treasury.PrivateKey, treasury.Address, NewAccount().Address, 500, utils.ToMilliSeconds(simulation.Clock.Now())))
+	if response := simulation.Delegates[0].DAPoS.NewTransaction(outsider); response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	if err := simulation.WaitForReceipt(outsider.Hash, types.StatusInvalidTransaction, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	receipt := simulation.Delegates[0].DAPoS.GetReceipt(outsider.Hash).Data.(*types.Receipt)
+	if !receipt.HasEvent(types.EventQueued) || !receipt.HasEvent(types.EventFailed) || receipt.HasEvent(types.EventPersisted) {
+		t.Fatalf("expected the timeline to end with %s [timeline=%v]", types.EventFailed, receipt.Timeline)
+	}
+
+	// The fee is paid in proportion to the stake.
+	transfer := must(types.NewTransferTokensTransaction(treasury.PrivateKey, treasury.Address, NewAccount().Address, 10, 0, utils.ToMilliSeconds(simulation.Clock.Now())))
+	if err := transfer.SetFee(400, treasury.PrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	if response := simulation.Delegates[3].DAPoS.NewTransaction(transfer); response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	err := simulation.WaitForBalances(map[string]int64{
+		first:                                   300,
+		second:                                  100,
+		simulation.Delegates[2].Account.Address: 0,
+		simulation.Delegates[3].Account.Address: 0,
+	}, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unstaked tokens are back in the balance, locked for the unbonding period.
+	unstake := must(types.NewUnstakeTransaction(treasury.PrivateKey, treasury.Address, second, 1000, utils.ToMilliSeconds(simulation.Clock.Now())))
+	if response := simulation.Delegates[0].DAPoS.NewTransaction(unstake); response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	if err := simulation.WaitForBalances(map[string]int64{treasury.Address: GenesisBalance - 3410}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	account, ok := simulation.Delegates[0].DAPoS.GetAccount(treasury.Address).Data.(*types.Account)
+	if !ok || account.BondedBalance() != 3000 || account.LockedBalance(utils.ToMilliSeconds(simulation.Clock.Now())) != 1000 {
+		t.Fatalf("expected 3000 bonded and 1000 unbonding [account=%v]", account)
+	}
+	simulation.Execute(UnbondingPeriod + 5*time.Minute)
+	deadline := time.Now().Add(10 * time.Second)
+	for _, node := range simulation.Delegates {
+		for {
+			account, ok := node.DAPoS.GetAccount(treasury.Address).Data.(*types.Account)
+			if ok && len(account.Locks) == 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the unbonded tokens to be released [delegate=%s]", node.Account.Address)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestGovernanceChangesParameters(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	treasury := simulation.Treasury
+	voter := NewAccount()
+	submit := func(transactions ...*types.Transaction) {
+		for i, transaction := range transactions {
+			if response := simulation.Delegates[i%len(simulation.Delegates)].DAPoS.NewTransaction(transaction); response.Status != types.StatusPending {
+				t.Fatalf("expected %s [status=%s, hash=%s]", types.StatusPending, response.Status, transaction.Hash)
+			}
+		}
+		if err := simulation.Settle(10 * time.Second); err != nil {
+			t.Fatal(err)
+		}
+		simulation.Execute(time.Minute)
+	}
+	now := func() int64 {
+		return utils.ToMilliSeconds(simulation.Clock.Now())
+	}
+
+	// 5000 staked, the treasury holds a majority of it.
+	late := NewAccount()
+	submit(
+		must(types.NewTransferTokensTransaction(treasury.PrivateKey, treasury.Address, voter.Address, 2000, 0, now())),
+		must(types.NewTransferTokensTransaction(treasury.PrivateKey, treasury.Address, late.Address, 4000, 0, now())),
+	)
+	submit(
+		must(types.NewStakeTransaction(treasury.PrivateKey, treasury.Address, simulation.Delegates[0].Account.Address, 3000, now())),
+		must(types.NewStakeTransaction(voter.PrivateKey, voter.Address, simulation.Delegates[1].Account.Address, 2000, now())),
+	)
+	if err := simulation.WaitForBalances(map[string]int64{treasury.Address: GenesisBalance - 9000, voter.Address: 0}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	activation := now() + int64(types.MinActivationDelay/time.Millisecond)
+	unbonding := must(types.NewProposeParameterTransaction(treasury.PrivateKey, treasury.Address, types.ParameterUnbondingPeriod, 0, activation, now()))
+	hertzPrice := must(types.NewProposeParameterTransaction(voter.PrivateKey, voter.Address, types.ParameterHertzPrice, 5, activation, now()))
+	removed := simulation.Delegates[3].Account.Address
+	removal := must(types.NewProposeDelegateTransaction(treasury.PrivateKey, treasury.Address, types.ParameterRemoveDelegate, removed, activation, now()))
+	submit(unbonding, hertzPrice, removal)
+
+	// Staked after the proposals, it does not weigh.
+	submit(must(types.NewStakeTransaction(late.PrivateKey, late.Address, simulation.Delegates[2].Account.Address, 4000, now())))
+	lateVote := must(types.NewVoteTransaction(late.PrivateKey, late.Address, hertzPrice.Hash, true, now()))
+	submit(
+		must(types.NewVoteTransaction(treasury.PrivateKey, treasury.Address, unbonding.Hash, true, now())),
+		must(types.NewVoteTransaction(treasury.PrivateKey, treasury.Address, hertzPrice.Hash, false, now())),
+		must(types.NewVoteTransaction(voter.PrivateKey, voter.Address, hertzPrice.Hash, true, now())),
+		must(types.NewVoteTransaction(treasury.PrivateKey, treasury.Address, removal.Hash, true, now())),
+		lateVote,
+	)
+	if err := simulation.WaitForReceipt(lateVote.Hash, types.StatusInsufficientTokens, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.WaitForProposal(unbonding.Hash, types.ProposalVoting, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tallied once the voting closed, the approved value is used from the activation on.
+	simulation.Execute(types.MinActivationDelay)
+	if err := simulation.WaitForProposal(unbonding.Hash, types.ProposalApproved, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.WaitForProposal(hertzPrice.Hash, types.ProposalRejected, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Clock.Advance(types.MinActivationDelay)
+	for _, node := range simulation.Delegates {
+		parameters, ok := node.DAPoS.GetParameters().Data.(*types.Parameters)
+		if !ok || parameters.UnbondingPeriod != 0 || parameters.HertzPrice != types.DefaultHertzPrice || len(parameters.Delegates) != 3 {
+			t.Fatalf("expected the approved parameters [delegate=%s, parameters=%v]", node.Account.Address, parameters)
+		}
+	}
+
+	// The removed delegate cannot be staked with.
+	outsider := must(types.NewStakeTransaction(treasury.PrivateKey, treasury.Address, removed, 100, now()))
+	submit(outsider)
+	if err := simulation.WaitForReceipt(outsider.Hash, types.StatusInvalidTransaction, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unstaked tokens are spendable right away.
+	submit(must(types.NewUnstakeTransaction(treasury.PrivateKey, treasury.Address, simulation.Delegates[0].Account.Address, 1000, now())))
+	if err := simulation.WaitForBalances(map[string]int64{treasury.Address: GenesisBalance - 8000}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	account, ok := simulation.Delegates[0].DAPoS.GetAccount(treasury.Address).Data.(*types.Account)
+	if !ok || len(account.Locks) != 0 {
+		t.Fatalf("expected no unbonding lock [account=%v]", account)
+	}
+}
+
+func TestGenesisDocument(t *testing.T) {
+	treasury := NewAccount()
+	vested := NewAccount()
+	now := utils.ToMilliSeconds(time.Now())
+	parameters := types.NewParameters()
+	parameters.HertzPrice = 2
+	genesis := &types.Genesis{
+		ChainId:     "simulation",
+		Time:        now,
+		Allocations: []types.Allocation{{Address: treasury.Address, Name: "treasury", Value: GenesisBalance}},
+		Vesting:     []types.Vesting{{Address: vested.Address, Value: 500, Until: now + int64(10*time.Minute/time.Millisecond)}},
+		Parameters:  &parameters,
+	}
+	simulation, err := NewSimulationWithGenesis(4, treasury, genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simulation.Stop()
+	if err := simulation.Start(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every node joined with the same genesis.
+	for _, node := range simulation.Nodes() {
+		if node.DisGover.ThisNode.GenesisHash != genesis.Hash() {
+			t.Fatalf("expected the genesis hash %s [node=%s, genesisHash=%s]", genesis.Hash(), node.Account.Address, node.DisGover.ThisNode.GenesisHash)
+		}
+	}
+	if err := simulation.WaitForName("treasury", treasury.Address, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.WaitForBalances(map[string]int64{treasury.Address: GenesisBalance, vested.Address: 500}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	for _, node := range simulation.Delegates {
+		parameters, ok := node.DAPoS.GetParameters().Data.(*types.Parameters)
+		if !ok || parameters.HertzPrice != 2 {
+			t.Fatalf("expected the genesis parameters [delegate=%s, parameters=%v]", node.Account.Address, parameters)
+		}
+		account, ok := node.DAPoS.GetAccount(vested.Address).Data.(*types.Account)
+		if !ok || account.LockedBalance(now) != 500 {
+			t.Fatalf("expected the vested tokens to be locked [delegate=%s, account=%v]", node.Account.Address, account)
+		}
+	}
+
+	// Released once the vesting ended.
+	simulation.Execute(15 * time.Minute)
+	deadline := time.Now().Add(10 * time.Second)
+	for _, node := range simulation.Delegates {
+		for {
+			account, ok := node.DAPoS.GetAccount(vested.Address).Data.(*types.Account)
+			if ok && len(account.Locks) == 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the vested tokens to be released [delegate=%s]", node.Account.Address)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestNetworkId(t *testing.T) {
+	treasury := NewAccount()
+	genesis := &types.Genesis{
+		ChainId:     "testnet",
+		Time:        utils.ToMilliSeconds(time.Now()),
+		Allocations: []types.Allocation{{Address: treasury.Address, Value: GenesisBalance}},
+	}
+	simulation, err := NewSimulationWithGenesis(4, treasury, genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simulation.Stop()
+	if err := simulation.Start(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	for _, node := range simulation.Nodes() {
+		if node.DisGover.ThisNode.NetworkId != "testnet" {
+			t.Fatalf("expected the network id of the genesis [node=%s, networkId=%s]", node.Account.Address, node.DisGover.ThisNode.NetworkId)
+		}
+	}
+
+	// Signed for the default network, it cannot be replayed here.
+	alice := NewAccount()
+	transaction := must(types.NewTransferTokensTransaction(treasury.PrivateKey, treasury.Address, alice.Address, 10, 0, utils.ToMilliSeconds(simulation.Clock.Now())))
+	if response := simulation.Delegates[0].DAPoS.NewTransaction(transaction); response.Status != types.StatusInvalidTransaction {
+		t.Fatalf("expected %s [status=%s]", types.StatusInvalidTransaction, response.Status)
+	}
+
+	// Signed for this network.
+	transaction, response, err := simulation.Transfer(simulation.Delegates[0], treasury, alice.Address, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	if err := simulation.WaitForBalances(map[string]int64{alice.Address: 10}, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	for _, node := range simulation.Delegates {
+		gossip, err := types.ToGossipFromCache(node.Db.GetCache(), transaction.Hash)
+		if err != nil {
+			continue
+		}
+		for _, rumor := range gossip.Rumors {
+			if rumor.NetworkId != "testnet" || !rumor.Verify("testnet") || rumor.Verify(types.DefaultChainId) {
+				t.Fatalf("expected rumors signed for the network [delegate=%s, rumor=%s]", node.Account.Address, rumor.String())
+			}
+		}
+	}
+}
+
+func TestFindNode(t *testing.T) {
+	simulation := newStartedSimulation(t, 6)
+	defer simulation.Stop()
+
+	// Every node joined the DHT.
+	for _, node := range simulation.Nodes() {
+		if len(node.DisGover.Peers()) == 0 {
+			t.Fatalf("expected k-buckets with peers [node=%s]", node.Account.Address)
+		}
+	}
+
+	// A node that called is added once it answers, not at endpoints nobody answers on.
+	delegate := simulation.Delegates[1]
+	impostor := NewAccount()
+	authentication, err := types.NewAuthenticationWithAccount(impostor, delegate.DisGover.ThisNode.NetworkId, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = delegate.DisGover.FindNodeGrpc(context.Background(), &disgoverproto.FindNode{
+		Authentication: &disgoverproto.Authentication{Hash: authentication.Hash, Time: authentication.Time, Signature: authentication.Signature, NetworkId: authentication.NetworkId},
+		Node: &disgoverproto.Node{
+			Address:      impostor.Address,
+			GrpcEndpoint: &disgoverproto.Endpoint{Host: "impostor", Port: 1973},
+			HttpEndpoint: &disgoverproto.Endpoint{Host: "impostor", Port: 1975},
+			Type:         types.TypeDelegate,
+			GenesisHash:  delegate.DisGover.ThisNode.GenesisHash,
+			NetworkId:    delegate.DisGover.ThisNode.NetworkId,
+		},
+		Target: impostor.Address,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	for _, peer := range delegate.DisGover.Peers() {
+		if peer.Address == impostor.Address {
+			t.Fatal("expected a node that does not answer to be left out of the k-buckets")
+		}
+	}
+
+	// Located without the seed.
+	simulation.Disconnect(simulation.Seed)
+	target := simulation.Delegates[5]
+	node, err := simulation.Delegates[0].DisGover.FindNode(target.Account.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Address != target.Account.Address || node.GrpcEndpoint.Host != target.Config.GrpcEndpoint.Host {
+		t.Fatalf("expected the node [node=%v]", node)
+	}
+	if _, err := simulation.Delegates[1].DisGover.FindNode(NewAccount().Address); err != types.ErrNotFound {
+		t.Fatalf("expected %v [err=%v]", types.ErrNotFound, err)
+	}
+
+	// A node that went away is dropped from the k-buckets.
+	simulation.Disconnect(target)
+	if _, err := simulation.Delegates[0].DisGover.FindNode(target.Account.Address); err != types.ErrNotFound {
+		t.Fatalf("expected %v [err=%v]", types.ErrNotFound, err)
+	}
+	for _, peer := range simulation.Delegates[0].DisGover.Peers() {
+		if peer.Address == target.Account.Address || peer.Address == simulation.Seed.Account.Address {
+			t.Fatalf("expected the node to be dropped [peer=%s]", peer.Address)
+		}
+	}
+}
+
+func TestSeedFailover(t *testing.T) {
+	simulation, err := NewSimulationWithSeeds(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simulation.Stop()
+	err = simulation.Start(10 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A seed that lost its registrations gets them back from the other seed.
+	seed := simulation.Seeds[1]
+	simulation.crash(seed)
+	os.RemoveAll(seed.dir)
+	err = simulation.Restart(seed, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectDelegates(t, seed, len(simulation.Delegates))
+
+	// One seed is enough to boot.
+	simulation.Disconnect(simulation.Seeds[0])
+	err = simulation.Restart(simulation.Delegates[0], 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectDelegates(t, simulation.Delegates[0], len(simulation.Delegates))
+
+	// No seed, the persisted peers are enough to boot.
+	simulation.Disconnect(simulation.Seeds[1])
+	delegate := simulation.Delegates[1]
+	err = simulation.Restart(delegate, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectDelegates(t, delegate, len(simulation.Delegates))
+	if delegate.DisGover.ThisNode.Type != types.TypeDelegate {
+		t.Fatalf("expected a delegate [type=%s]", delegate.DisGover.ThisNode.Type)
+	}
+	if len(delegate.DisGover.Peers()) == 0 {
+		t.Fatal("expected k-buckets with peers")
+	}
+}
+
+func TestReplicationMergesNewerRegistrations(t *testing.T) {
+	simulation, err := NewSimulationWithSeeds(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simulation.Stop()
+	err = simulation.Start(10 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed := simulation.Seeds[1]
+	replicate := func(node *types.Node) *disgoverproto.Replicate {
+		authentication, err := types.NewAuthenticationWithAccount(simulation.Seeds[0].Account, seed.DisGover.ThisNode.NetworkId, utils.ToMilliSeconds(simulation.Clock.Now()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		replicate := &disgoverproto.Replicate{
+			Authentication: &disgoverproto.Authentication{Hash: authentication.Hash, Time: authentication.Time, Signature: authentication.Signature, NetworkId: authentication.NetworkId},
+			GenesisHash:    seed.DisGover.ThisNode.GenesisHash,
+		}
+		if node != nil {
+			replicate.Nodes = append(replicate.Nodes, &disgoverproto.Node{
+				Address:      node.Address,
+				GrpcEndpoint: &disgoverproto.Endpoint{Host: node.GrpcEndpoint.Host, Port: node.GrpcEndpoint.Port},
+				HttpEndpoint: &disgoverproto.Endpoint{Host: node.HttpEndpoint.Host, Port: node.HttpEndpoint.Port},
+				Type:         node.Type,
+				GenesisHash:  node.GenesisHash,
+				NetworkId:    node.NetworkId,
+				Registered:   node.Registered,
+			})
+		}
+		return replicate
+	}
+	endpoint := func(address string) types.Endpoint {
+		node, err := types.ToNodeFromCache(seed.Db.GetCache(), address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *node.GrpcEndpoint
+	}
+
+	// A more recent registration moves the node to its new endpoints.
+	address := simulation.Delegates[0].Account.Address
+	registered, err := types.ToNodeFromCache(seed.Db.GetCache(), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved := *registered
+	moved.GrpcEndpoint = &types.Endpoint{Host: "moved", Port: registered.GrpcEndpoint.Port}
+	moved.Registered = registered.Registered + 1
+	if _, err := seed.DisGover.ReplicateGrpc(context.Background(), replicate(&moved)); err != nil {
+		t.Fatal(err)
+	}
+	if endpoint(address) != *moved.GrpcEndpoint {
+		t.Fatalf("expected the newer endpoint [endpoint=%v]", endpoint(address))
+	}
+
+	// An older one does not.
+	stale := moved
+	stale.GrpcEndpoint = &types.Endpoint{Host: "stale", Port: registered.GrpcEndpoint.Port}
+	stale.Registered = registered.Registered
+	if _, err := seed.DisGover.ReplicateGrpc(context.Background(), replicate(&stale)); err != nil {
+		t.Fatal(err)
+	}
+	if endpoint(address) != *moved.GrpcEndpoint {
+		t.Fatalf("expected the stale endpoint to be ignored [endpoint=%v]", endpoint(address))
+	}
+
+	// Registrations no seed renewed expire.
+	simulation.Clock.Advance(types.PersistedNodeTtl + time.Minute)
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	response, err := seed.DisGover.ReplicateGrpc(context.Background(), replicate(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Nodes) != 0 {
+		t.Fatalf("expected expired registrations to be left out [nodes=%d]", len(response.Nodes))
+	}
+}
+
+func TestHealthChecks(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	delegate := simulation.Delegates[0]
+	delegate.DisGover.CheckHealth()
+	if len(delegate.DisGover.Health()) != len(simulation.Nodes())-1 {
+		t.Fatalf("expected every other node to be checked [health=%d]", len(delegate.DisGover.Health()))
+	}
+	for _, health := range delegate.DisGover.Health() {
+		if !health.Available || health.LastSeen.IsZero() || len(health.History) != 1 {
+			t.Fatalf("expected the node to be available [health=%+v]", health)
+		}
+	}
+
+	// Unavailable after enough failed checks, DAPoS stops gossiping with it.
+	target := simulation.Delegates[3]
+	simulation.Disconnect(target)
+	for i := 0; i < types.HealthFailureThreshold; i++ {
+		delegate.DisGover.CheckHealth()
+	}
+	health, err := delegate.DisGover.NodeHealth(target.Account.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health.Available || health.ConsecutiveFailures != types.HealthFailureThreshold {
+		t.Fatalf("expected the node to be unavailable [health=%+v]", health)
+	}
+	node, err := types.ToNodeFromCache(delegate.Db.GetCache(), target.Account.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.IsAvailable(simulation.Clock.Now()) {
+		t.Fatalf("expected DAPoS to see the node as unavailable [status=%s]", node.Status)
+	}
+
+	// Evicted after more.
+	for i := types.HealthFailureThreshold; i < types.HealthEvictionThreshold; i++ {
+		delegate.DisGover.CheckHealth()
+	}
+	health, _ = delegate.DisGover.NodeHealth(target.Account.Address)
+	if !health.Evicted {
+		t.Fatalf("expected the node to be evicted [health=%+v]", health)
+	}
+
+	// Consensus still counts the delegate, only the routing of this node dropped it.
+	expectDelegates(t, delegate, len(simulation.Delegates))
+	for _, peer := range delegate.DisGover.Peers() {
+		if peer.Address == target.Account.Address {
+			t.Fatal("expected the node to be dropped from the k-buckets")
+		}
+	}
+
+	// Restored once it answers.
+	simulation.Reconnect(target)
+	delegate.DisGover.CheckHealth()
+	health, _ = delegate.DisGover.NodeHealth(target.Account.Address)
+	if !health.Available || health.Evicted {
+		t.Fatalf("expected the node to be restored [health=%+v]", health)
+	}
+	expectDelegates(t, delegate, len(simulation.Delegates))
+	node, _ = types.ToNodeFromCache(delegate.Db.GetCache(), target.Account.Address)
+	if !node.IsAvailable(simulation.Clock.Now()) {
+		t.Fatalf("expected DAPoS to see the node as available [status=%s]", node.Status)
+	}
+}
+
+func TestReputation(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	// Delegates that answered gossip keep a good reputation.
+	alice := NewAccount()
+	_, err := simulation.Run([]Transfer{{From: simulation.Treasury, To: alice.Address, Value: 1000}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	err = simulation.WaitForBalances(map[string]int64{alice.Address: 1000}, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	successes := int64(0)
+	for _, node := range simulation.Delegates {
+		for _, reputation := range node.DAPoS.GetReputations().Data.([]dapos.Reputation) {
+			if reputation.Score < 0.5 || !reputation.BannedUntil.IsZero() || reputation.Errors > 0 {
+				t.Fatalf("expected a good reputation [reputation=%+v]", reputation)
+			}
+			successes += reputation.Successes
+		}
+	}
+	if successes == 0 {
+		t.Fatal("expected gossip to be scored")
+	}
+
+	// A delegate that rumors about transactions that do not verify is banned.
+	delegate := simulation.Delegates[0]
+	liar := simulation.Delegates[1]
+	for i := 0; i < 4; i++ {
+		transaction := must(types.NewTransferTokensTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, NewAccount().Address, 1, 0, utils.ToMilliSeconds(simulation.Clock.Now())+int64(i)))
+		rumor := types.NewRumor(liar.Account.PrivateKey, liar.Account.Address, transaction.Hash, simulation.Genesis.ChainId, utils.ToMilliSeconds(simulation.Clock.Now()))
+		gossip := &proto.Gossip{
+			Transaction: &proto.Transaction{Hash: transaction.Hash, From: transaction.From, To: transaction.To, Value: transaction.Value + 1, Time: transaction.Time, Signature: transaction.Signature},
+			Rumors:      []*proto.Rumor{{Hash: rumor.Hash, Address: rumor.Address, TransactionHash: rumor.TransactionHash, Time: rumor.Time, Signature: rumor.Signature, NetworkId: rumor.NetworkId}},
+		}
+		if _, err := delegate.DAPoS.TypedGossipGrpc(context.Background(), gossip); err == nil {
+			t.Fatal("expected the transaction not to verify")
+		}
+	}
+	reputation := findReputation(t, delegate, liar.Account.Address)
+	if reputation.Invalid != 4 || reputation.BannedUntil.IsZero() {
+		t.Fatalf("expected the delegate to be banned [reputation=%+v]", reputation)
+	}
+}
+
+func findReputation(t *testing.T, node *Node, address string) dapos.Reputation {
+	for _, reputation := range node.DAPoS.GetReputations().Data.([]dapos.Reputation) {
+		if reputation.Address == address {
+			return reputation
+		}
+	}
+	t.Fatalf("expected a reputation [address=%s]", address)
+	return dapos.Reputation{}
+}
+
+func TestHealthWorkerFollowsClock(t *testing.T) {
+	simulation, err := NewSimulation(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simulation.Stop()
+	delegate := simulation.Delegates[0]
+	delegate.Config.HealthCheckInterval = int64(time.Minute / time.Millisecond)
+	if err := simulation.Start(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if len(delegate.DisGover.Health()) != 0 {
+		t.Fatal("expected no check before the interval")
+	}
+	simulation.Clock.Advance(time.Minute)
+	deadline := time.Now().Add(10 * time.Second)
+	for len(delegate.DisGover.Health()) != len(simulation.Nodes())-1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected every other node to be checked [health=%d]", len(delegate.DisGover.Health()))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func expectDelegates(t *testing.T, node *Node, count int) {
+	delegates, err := types.ToNodesByTypeFromCache(node.Db.GetCache(), types.TypeDelegate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delegates) != count {
+		t.Fatalf("expected %d delegates [delegates=%d, address=%s]", count, len(delegates), node.Account.Address)
+	}
+}
+
+func must(transaction *types.Transaction, err error) *types.Transaction {
+	if err != nil {
+		panic(err)
+	}
+	return transaction
+}
+
+func TestStagedRollout(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+	for _, delegate := range simulation.Delegates {
+		writeSoftware(t, delegate, "disgo", types.Version)
+	}
+
+	seed := simulation.Seed
+	seed.Config.UpdateStages = []int{25, 100}
+	seed.Config.UpdateStageTimeout = int64(time.Hour / time.Millisecond)
+	software := []byte("99.0.0")
+	manifest, err := types.NewManifest(seed.Account.PrivateKey, "99.0.0", types.Platform(), "disgo", types.Version, software, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = seed.DisGover.RollOut(manifest, software)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = seed.DisGover.RollOut(manifest, software)
+	if err == nil {
+		t.Fatal("expected the release to roll out once")
+	}
+
+	// Every delegate installs it, reboots into it and comes back healthy.
+	rollout := waitForRollout(t, simulation, manifest.Hash)
+	if rollout.Status != types.RolloutCompleted || rollout.Stage != 1 || len(rollout.Acks) != len(simulation.Delegates) {
+		t.Fatalf("expected the rollout to complete [rollout=%+v]", rollout)
+	}
+	for _, delegate := range simulation.Delegates {
+		ack := rollout.Acks[delegate.Account.Address]
+		if ack.Status != types.UpdateHealthy {
+			t.Fatalf("expected the delegate to be healthy [ack=%+v]", ack)
+		}
+		waitForReboots(t, delegate, 1)
+		if readSoftware(t, delegate, "disgo") != "99.0.0" || readSoftware(t, delegate, "disgo.previous") != types.Version {
+			t.Fatal("expected the release to be installed next to the previous disgo")
+		}
+		if _, err := os.Stat(filepath.Join(delegate.dir, "update.json")); !os.IsNotExist(err) {
+			t.Fatal("expected the update marker to be removed once healthy")
+		}
+	}
+}
+
+// legacyDisGover - A delegate of a release without ReleaseGrpc
+type legacyDisGover struct {
+	*disgover.DisGoverService
+}
+
+// ReleaseGrpc
+func (this legacyDisGover) ReleaseGrpc(ctx context.Context, release *disgoverproto.Release) (*disgoverproto.UpdateAck, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method ReleaseGrpc")
+}
+
+func TestRolloutPushesToLegacyDelegates(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+	for _, delegate := range simulation.Delegates {
+		writeSoftware(t, delegate, "disgo", types.Version)
+	}
+	legacy := simulation.Delegates[0]
+	simulation.Network.Register("disgover", legacy.Account.Address, legacyDisGover{legacy.DisGover})
+
+	seed := simulation.Seed
+	seed.Config.UpdateStages = []int{100}
+	seed.Config.UpdateStageTimeout = int64(time.Hour / time.Millisecond)
+	software := []byte("99.0.0")
+	manifest, err := types.NewManifest(seed.Account.PrivateKey, "99.0.0", types.Platform(), "disgo", types.Version, software, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = seed.DisGover.RollOut(manifest, software); err != nil {
+		t.Fatal(err)
+	}
+
+	// The software is pushed whole to the legacy delegate, it reboots into it without acknowledging.
+	rollout := waitForRollout(t, simulation, manifest.Hash)
+	if rollout.Status != types.RolloutCompleted {
+		t.Fatalf("expected the rollout to complete [rollout=%+v]", rollout)
+	}
+	if ack := rollout.Acks[legacy.Account.Address]; ack.Status != types.UpdatePushed {
+		t.Fatalf("expected the software to be pushed [ack=%+v]", ack)
+	}
+	waitForReboots(t, legacy, 1)
+	if readSoftware(t, legacy, "disgo") != "99.0.0" {
+		t.Fatal("expected the legacy delegate to install the release")
+	}
+}
+
+func TestRolloutHaltsOnRejection(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+	for _, delegate := range simulation.Delegates {
+		writeSoftware(t, delegate, "disgo", types.Version)
+	}
+
+	// No delegate runs the minimum version, the first stage rejects it.
+	seed := simulation.Seed
+	seed.Config.UpdateStages = []int{25, 100}
+	software := []byte("99.0.0")
+	manifest, err := types.NewManifest(seed.Account.PrivateKey, "99.0.0", types.Platform(), "disgo", "99.0.0", software, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = seed.DisGover.RollOut(manifest, software)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rollout := waitForRollout(t, simulation, manifest.Hash)
+	if rollout.Status != types.RolloutHalted || rollout.Stage != 0 || rollout.Reason == "" || len(rollout.Acks) != 1 {
+		t.Fatalf("expected the rollout to halt in the first stage [rollout=%+v]", rollout)
+	}
+	for _, ack := range rollout.Acks {
+		if ack.Status != types.UpdateRejected || ack.Version != types.Version {
+			t.Fatalf("expected the delegate to reject the release [ack=%+v]", ack)
+		}
+	}
+	for _, delegate := range simulation.Delegates {
+		if readSoftware(t, delegate, "disgo") != types.Version || delegate.Reboots() != 0 {
+			t.Fatal("expected no delegate to install the release")
+		}
+	}
+
+	// A manifest that was tampered with is never rolled out.
+	manifest.Version = "100.0.0"
+	_, err = seed.DisGover.RollOut(manifest, software)
+	if err == nil {
+		t.Fatal("expected a tampered manifest to be refused")
+	}
+}
+
+func TestRollbackAfterFailedBoots(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	// The release never came back healthy, the next boot is one too many.
+	delegate := simulation.Delegates[0]
+	software := []byte("99.0.0")
+	manifest, err := types.NewManifest(simulation.Seed.Account.PrivateKey, "99.0.0", types.Platform(), "disgo", "", software, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeSoftware(t, delegate, "disgo", "99.0.0")
+	writeSoftware(t, delegate, "disgo.previous", types.Version)
+	writeSoftware(t, delegate, "update.json", fmt.Sprintf(`{"manifest":%s,"attempts":%d}`, manifest.String(), types.UpdateBootAttempts))
+	err = simulation.Restart(delegate, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The boot is counted before DisGover runs, the previous disgo is restored and the rollback acknowledged.
+	if readSoftware(t, delegate, "disgo") != types.Version {
+		t.Fatal("expected the previous disgo to be restored")
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, err := os.Stat(filepath.Join(delegate.dir, "update.json"))
+		if os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the update marker to be removed once rolled back")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDelegatesShareArtifacts(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+	delegates := append([]*Node{}, simulation.Delegates...)
+	sort.Slice(delegates, func(i, j int) bool { return delegates[i].Account.Address < delegates[j].Account.Address })
+
+	// The first stage is one delegate, it can't send to the second.
+	first := delegates[0]
+	blocked := delegates[1]
+	simulation.Network.Block(first.Account.Address, blocked.Account.Address)
+
+	seed := simulation.Seed
+	seed.Config.UpdateStages = []int{25, 100}
+	software := bytes.Repeat([]byte("artifact"), types.UpdateChunkSize/3)
+	manifest, err := types.NewManifest(seed.Account.PrivateKey, "99.0.0", types.Platform(), "artifact.tar", "", software, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Chunks() != 3 {
+		t.Fatalf("expected the artifact to be sent in 3 chunks [chunks=%d]", manifest.Chunks())
+	}
+	_, err = seed.DisGover.RollOut(manifest, software)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rollout := waitForRollout(t, simulation, manifest.Hash)
+	if rollout.Status != types.RolloutCompleted {
+		t.Fatalf("expected the rollout to complete [rollout=%+v]", rollout)
+	}
+
+	// Only the first delegate and the one it can't reach download from the seed.
+	for _, delegate := range delegates {
+		if readSoftware(t, delegate, "artifact.tar") != string(software) || delegate.Reboots() != 0 {
+			t.Fatal("expected the artifact to be saved without a reboot")
+		}
+		transfer, err := delegate.DisGover.Transfer(manifest.SoftwareHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		source := first.Account.Address
+		failures := 0
+		if delegate == first || delegate == blocked {
+			source = seed.Account.Address
+		}
+		if delegate == blocked {
+			failures = 1
+		}
+		if !transfer.Complete || transfer.Received != 3 || len(transfer.Sources) != 1 || transfer.Sources[0] != source || transfer.Failures != failures {
+			t.Fatalf("expected the artifact from %s [transfer=%+v]", source, transfer)
+		}
+	}
+}
+
+func TestDownloadResumes(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	seed := simulation.Seed
+	seed.Config.UpdateStages = []int{100}
+	software := bytes.Repeat([]byte("artifact"), types.UpdateChunkSize/3)
+	manifest, err := types.NewManifest(seed.Account.PrivateKey, "99.0.0", types.Platform(), "artifact.tar", "", software, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A download cut off in the second chunk.
+	delegate := simulation.Delegates[0]
+	err = os.MkdirAll(filepath.Join(delegate.dir, "artifacts"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeSoftware(t, delegate, filepath.Join("artifacts", manifest.SoftwareHash+".part"), string(software[:types.UpdateChunkSize+100]))
+
+	_, err = seed.DisGover.RollOut(manifest, software)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rollout := waitForRollout(t, simulation, manifest.Hash)
+	if rollout.Status != types.RolloutCompleted {
+		t.Fatalf("expected the rollout to complete [rollout=%+v]", rollout)
+	}
+	transfer, err := delegate.DisGover.Transfer(manifest.SoftwareHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !transfer.Complete || transfer.ResumedFrom != 1 || transfer.Received != 3 {
+		t.Fatalf("expected the download to resume from the second chunk [transfer=%+v]", transfer)
+	}
+	if readSoftware(t, delegate, "artifact.tar") != string(software) || readSoftware(t, delegate, filepath.Join("artifacts", manifest.SoftwareHash)) != string(software) {
+		t.Fatal("expected the artifact to be saved and kept to share")
+	}
+	if _, err := os.Stat(filepath.Join(delegate.dir, "artifacts", manifest.SoftwareHash+".part")); !os.IsNotExist(err) {
+		t.Fatal("expected the partial download to be removed")
+	}
+}
+
+// waitForRollout - Advances the clock once every delegate of a stage installed disgo so they reboot together, until
+// the rollout ends
+func waitForReboots(t *testing.T, node *Node, reboots int) {
+	deadline := time.Now().Add(10 * time.Second)
+	for node.Reboots() != reboots {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the node to reboot %d times [reboots=%d]", reboots, node.Reboots())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func waitForRollout(t *testing.T, simulation *Simulation, hash string) *types.Rollout {
+	rebooting := map[int]bool{}
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		rollout, err := simulation.Seed.DisGover.Rollout(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rollout.Status != types.RolloutInProgress {
+			return rollout
+		}
+		installed := 0
+		for _, ack := range rollout.Acks {
+			if ack.Status == types.UpdateInstalled || ack.Status == types.UpdateHealthy || ack.Status == types.UpdatePushed {
+				installed++
+			}
+		}
+		if rollout.Manifest.Installs() && installed == types.StageSize(rollout.Stages[rollout.Stage], len(simulation.Delegates)) && !rebooting[rollout.Stage] {
+			rebooting[rollout.Stage] = true
+			simulation.Clock.Advance(types.UpdateRebootDelay)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the rollout")
+	return nil
+}
+
+func writeSoftware(t *testing.T, node *Node, fileName string, content string) {
+	err := ioutil.WriteFile(filepath.Join(node.dir, fileName), []byte(content), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readSoftware(t *testing.T, node *Node, fileName string) string {
+	bytes, err := ioutil.ReadFile(filepath.Join(node.dir, fileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bytes)
+}
+
+func TestTransactionReceiveTimeout(t *testing.T) {
+	simulation := newStartedSimulation(t, 3)
+	defer simulation.Stop()
+
+	transaction, err := types.NewTransferTokensTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, NewAccount().Address, 10, 0, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	simulation.Clock.Advance((types.TxReceiveTimeout + 1) * time.Millisecond)
+	response := simulation.Delegates[0].DAPoS.NewTransaction(transaction)
+	if response.Status != types.StatusTransactionTimeOut {
+		t.Fatalf("expected %s [status=%s]", types.StatusTransactionTimeOut, response.Status)
+	}
+}
+
+func TestTransactionFutureLimit(t *testing.T) {
+	simulation := newStartedSimulation(t, 3)
+	defer simulation.Stop()
+
+	limit := utils.ToMilliSeconds(simulation.Clock.Now().Add(types.TxFutureLimit))
+	transaction, err := types.NewTransferTokensTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, NewAccount().Address, 10, 0, limit+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := simulation.Delegates[0].DAPoS.NewTransaction(transaction)
+	if response.Status != types.StatusInvalidTransaction {
+		t.Fatalf("expected %s [status=%s]", types.StatusInvalidTransaction, response.Status)
+	}
+
+	// The same time is fine once the delegate's clock catches up.
+	simulation.Clock.Advance(time.Millisecond)
+	response = simulation.Delegates[0].DAPoS.NewTransaction(transaction)
+	if response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+}
+
+func TestPartitionCouldNotReachConsensus(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	simulation.Network.Partition(Addresses(simulation.Delegates[0], simulation.Delegates[1]))
+	transaction, response, err := simulation.Transfer(simulation.Delegates[0], simulation.Treasury, NewAccount().Address, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Status != types.StatusPending {
+		t.Fatalf("expected %s [status=%s]", types.StatusPending, response.Status)
+	}
+
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Second)
+	receipt, ok := simulation.Delegates[0].DAPoS.GetReceipt(transaction.Hash).Data.(*types.Receipt)
+	if !ok || receipt.Status != types.StatusCouldNotReachConsensus {
+		t.Fatalf("expected receipt with status %s", types.StatusCouldNotReachConsensus)
+	}
+	if receipt.HasEvent(types.EventQueued) {
+		t.Fatal("a partitioned delegate queued the transaction for execution")
+	}
+}
+
+func TestTimeoutsFollowMeasuredLatency(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	delegate := simulation.Delegates[0]
+	timeouts := delegate.DAPoS.GetTimeouts().Data.(*dapos.Timeouts)
+	if timeouts.GossipTimeout != types.GossipTimeout {
+		t.Fatalf("expected the upper bound before any round trip [gossipTimeout=%d]", timeouts.GossipTimeout)
+	}
+
+	// Round trips on the virtual clock take no time at all, so the lower bound applies.
+	_, err := simulation.Run([]Transfer{{From: simulation.Treasury, To: NewAccount().Address, Value: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	timeouts = delegate.DAPoS.GetTimeouts().Data.(*dapos.Timeouts)
+	if timeouts.GossipTimeout != delegate.Config.MinGossipTimeout {
+		t.Fatalf("expected the lower bound [gossipTimeout=%d]", timeouts.GossipTimeout)
+	}
+	if timeouts.TxReceiveTimeout != delegate.Config.MinTxReceiveTimeout {
+		t.Fatalf("expected the lower bound [txReceiveTimeout=%d]", timeouts.TxReceiveTimeout)
+	}
+	if expected := delegate.Config.MinTxReceiveTimeout + 3*delegate.Config.MinGossipTimeout; timeouts.ExecutionDelay != expected {
+		t.Fatalf("expected execution delay of %d [executionDelay=%d]", expected, timeouts.ExecutionDelay)
+	}
+	if len(timeouts.Delegates) != len(simulation.Delegates)-1 {
+		t.Fatalf("expected latencies for every other delegate [delegates=%d]", len(timeouts.Delegates))
+	}
+
+	// Latency passes on the virtual clock, the timeouts follow the measured round trips between the bounds.
+	simulation.Network.SetLatency(100*time.Millisecond, 0)
+	workload := make([]Transfer, 0, 10)
+	for i := 0; i < 10; i++ {
+		workload = append(workload, Transfer{From: simulation.Treasury, To: NewAccount().Address, Value: 1})
+	}
+	_, err = simulation.Run(workload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	timeouts = delegate.DAPoS.GetTimeouts().Data.(*dapos.Timeouts)
+	if timeouts.GossipTimeout != 400 {
+		t.Fatalf("expected twice the round trip [gossipTimeout=%d]", timeouts.GossipTimeout)
+	}
+	if expected := int64(types.TxReceiveTimeout * 400 / types.GossipTimeout); timeouts.TxReceiveTimeout != expected {
+		t.Fatalf("expected %d [txReceiveTimeout=%d]", expected, timeouts.TxReceiveTimeout)
+	}
+	if expected := timeouts.TxReceiveTimeout + 3*timeouts.GossipTimeout; timeouts.ExecutionDelay != expected {
+		t.Fatalf("expected execution delay of %d [executionDelay=%d]", expected, timeouts.ExecutionDelay)
+	}
+}
+
+func TestGossipTimeoutIsGoverned(t *testing.T) {
+	simulation, err := NewSimulation(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer simulation.Stop()
+	delegate := simulation.Delegates[0]
+	delegate.Config.MinGossipTimeout = 2 * types.GossipTimeout
+	err = simulation.Start(10 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A configured minimum above the governed maximum does not raise the timeout past it.
+	_, err = simulation.Run([]Transfer{{From: simulation.Treasury, To: NewAccount().Address, Value: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	timeouts := delegate.DAPoS.GetTimeouts().Data.(*dapos.Timeouts)
+	if len(timeouts.Delegates) == 0 {
+		t.Fatal("expected measured round trips")
+	}
+	if timeouts.GossipTimeout != types.GossipTimeout {
+		t.Fatalf("expected the governed maximum [gossipTimeout=%d]", timeouts.GossipTimeout)
+	}
+}
+
+func TestBatchedGossipConverges(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	recipient := NewAccount()
+	workload := make([]Transfer, 0, 20)
+	for i := 1; i <= 20; i++ {
+		workload = append(workload, Transfer{From: simulation.Treasury, To: recipient.Address, Value: int64(i)})
+	}
+	before := simulation.Network.Messages()
+	if _, err := simulation.Run(workload); err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+
+	err := simulation.WaitForBalances(map[string]int64{
+		simulation.Treasury.Address: GenesisBalance - 210,
+		recipient.Address:           210,
+	}, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unbatched, every delegate sends each transaction to its 3 peers on first receipt (a request and a response each).
+	unbatched := int64(len(workload) * 4 * 3 * 2)
+	if messages := simulation.Network.Messages() - before; messages >= unbatched {
+		t.Fatalf("expected fewer messages than unbatched gossip [messages=%d, unbatched=%d]", messages, unbatched)
+	}
+}
+
+func TestLegacyGossipIsServed(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	recipient := NewAccount()
+	transaction, err := types.NewTransferTokensTransaction(simulation.Treasury.PrivateKey, simulation.Treasury.Address, recipient.Address, 10, 0, utils.ToMilliSeconds(simulation.Clock.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gossip := &types.Gossip{Transaction: *transaction}
+
+	// A delegate of the previous release sends the gossip as JSON.
+	response, err := simulation.Delegates[0].DAPoS.GossipGrpc(context.Background(), &proto.Request{Payload: gossip.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	synchronizedGossip, err := types.ToGossipFromJson([]byte(response.Payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if synchronizedGossip.Transaction.Hash != transaction.Hash || len(synchronizedGossip.Rumors) == 0 {
+		t.Fatalf("expected the gossip back with the delegate's rumor [gossip=%s]", synchronizedGossip.String())
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+	err = simulation.WaitForBalances(map[string]int64{recipient.Address: 10}, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestartExecutesQueuedTransactions(t *testing.T) {
+	simulation := newStartedSimulation(t, 4)
+	defer simulation.Stop()
+
+	alice := NewAccount()
+	transactions, err := simulation.Run([]Transfer{
+		{From: simulation.Treasury, To: alice.Address, Value: 1000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := simulation.Settle(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Second)
+
+	// Consensus is reached, execution is still due; everything the delegate held in memory is lost.
+	restarted := simulation.Delegates[0]
+	if err := simulation.Restart(restarted, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	simulation.Execute(time.Minute)
+
+	err = simulation.WaitForBalances(map[string]int64{
+		simulation.Treasury.Address: GenesisBalance - 1000,
+		alice.Address:               1000,
+	}, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := restarted.DAPoS.GetReceipt(transactions[0].Hash)
+	receipt, ok := response.Data.(*types.Receipt)
+	if !ok || receipt.Status != types.StatusOk {
+		t.Fatalf("expected receipt with status %s [response=%s]", types.StatusOk, response.String())
+	}
+
+	// The timeline recorded before the restart is kept.
+	for _, event := range []string{types.EventReceived, types.EventConsensusReached, types.EventQueued, types.EventExecuted, types.EventPersisted} {
+		if !receipt.HasEvent(event) {
+			t.Fatalf("expected event %s [timeline=%v]", event, receipt.Timeline)
+		}
+	}
+}